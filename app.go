@@ -2,11 +2,25 @@ package main
 
 import (
 	"log"
+	"os"
 
 	src "github.com/arfaghifari/guild-board/src"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := src.Migrate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "apikey" {
+		if err := src.APIKey(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	log.Println("Starting Guild Board Service")
 
 	src.Main()
@@ -0,0 +1,63 @@
+package src
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/arfaghifari/guild-board/src/clock"
+	"github.com/arfaghifari/guild-board/src/config"
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/database"
+	authUsecase "github.com/arfaghifari/guild-board/src/usecase/auth"
+)
+
+var errAPIKeyUsage = errors.New("usage: apikey create staff <name> | apikey create adventurer <name> <adv_id> | apikey create quest_giver <name> <giver_id>")
+
+// APIKey runs the "apikey" subcommand of the service binary. The key is
+// printed once; only its hash is stored.
+func APIKey(args []string) error {
+	if len(args) < 3 || args[0] != "create" {
+		return errAPIKeyUsage
+	}
+	role, name := args[1], args[2]
+	var holderID int64
+	switch {
+	case role == constant.RoleStaff && len(args) == 3:
+	case (role == constant.RoleAdventurer || role == constant.RoleGiver) && len(args) == 4:
+		var err error
+		if holderID, err = strconv.ParseInt(args[3], 10, 64); err != nil || holderID <= 0 {
+			return errAPIKeyUsage
+		}
+	default:
+		return errAPIKeyUsage
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.Database.Driver == config.DriverMemory {
+		return errors.New("the memory store lives in the server: use the staff key it prints when it starts and POST /v2/api-keys")
+	}
+	db, err := database.Open(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	repos, err := newSQLRepositories(db, database.DialectOf(cfg.Database.Driver), cfg.Features.AutoMigrate)
+	if err != nil {
+		return err
+	}
+	u, err := authUsecase.NewUsecase(repos.auth, repos.adventurer, repos.giver, clock.NewClock())
+	if err != nil {
+		return err
+	}
+
+	token, _, err := u.IssueAPIKey(name, role, holderID)
+	if err != nil {
+		return err
+	}
+	fmt.Println(token)
+	return nil
+}
@@ -0,0 +1,120 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Kind tells how a client should react to an error.
+type Kind int
+
+const (
+	Internal Kind = iota
+	NotFound
+	Conflict
+	Forbidden
+	Validation
+	BadRequest
+	Unauthenticated
+)
+
+// Codes of an unexpected error, of a request that cannot be read and of a
+// request with fields that break their rules.
+const (
+	CodeInternal      = "internal_error"
+	CodeBadRequest    = "bad_request"
+	CodeInvalidFields = "invalid_fields"
+)
+
+// Error is a failure reported to clients. Code is a stable, machine readable
+// identifier; Message is meant for humans and may change. Fields lists the
+// request fields at fault, if any.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+}
+
+// FieldError tells what is wrong with one field of a request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func newError(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func NewNotFound(code, message string) *Error {
+	return newError(NotFound, code, message)
+}
+
+func NewConflict(code, message string) *Error {
+	return newError(Conflict, code, message)
+}
+
+func NewForbidden(code, message string) *Error {
+	return newError(Forbidden, code, message)
+}
+
+func NewValidation(code, message string) *Error {
+	return newError(Validation, code, message)
+}
+
+// NewUnauthenticated rejects a request that does not say who sends it.
+func NewUnauthenticated(code, message string) *Error {
+	return newError(Unauthenticated, code, message)
+}
+
+// NewBadRequest rejects a request whose body, path or query cannot be read.
+func NewBadRequest(message string) *Error {
+	return newError(BadRequest, CodeBadRequest, message)
+}
+
+// NewInvalidFields rejects a request because of the given fields.
+func NewInvalidFields(fields []FieldError) *Error {
+	problems := make([]string, len(fields))
+	for i, f := range fields {
+		problems[i] = f.Field + " " + f.Message
+	}
+	e := newError(Validation, CodeInvalidFields, "invalid request: "+strings.Join(problems, ", "))
+	e.Fields = fields
+	return e
+}
+
+var statusCodes = map[Kind]int{
+	Internal:        http.StatusInternalServerError,
+	NotFound:        http.StatusNotFound,
+	Conflict:        http.StatusConflict,
+	Forbidden:       http.StatusForbidden,
+	Validation:      http.StatusUnprocessableEntity,
+	BadRequest:      http.StatusBadRequest,
+	Unauthenticated: http.StatusUnauthorized,
+}
+
+// Describe returns the HTTP status, the code and the message err should be
+// answered with. Errors that are not an *Error are internal and their text is
+// not disclosed.
+func Describe(err error) (statusCode int, code, message string) {
+	var e *Error
+	if !errors.As(err, &e) {
+		return http.StatusInternalServerError, CodeInternal, http.StatusText(http.StatusInternalServerError)
+	}
+	// err.Error() keeps the details a caller wrapped around e
+	return statusCodes[e.Kind], e.Code, err.Error()
+}
+
+// Fields returns the request fields err blames, nil when it blames none.
+func Fields(err error) []FieldError {
+	var e *Error
+	if !errors.As(err, &e) {
+		return nil
+	}
+	return e.Fields
+}
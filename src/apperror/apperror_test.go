@@ -0,0 +1,49 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribe(t *testing.T) {
+	notFound := NewNotFound("quest_not_found", "quest not found")
+	tests := []struct {
+		name           string
+		err            error
+		wantStatusCode int
+		wantCode       string
+		wantMessage    string
+	}{
+		{"not found", notFound, http.StatusNotFound, "quest_not_found", "quest not found"},
+		{"conflict", NewConflict("quest_taken", "quest have been taken"), http.StatusConflict, "quest_taken", "quest have been taken"},
+		{"forbidden", NewForbidden("rank_too_low", "not capable adventurer rank"), http.StatusForbidden, "rank_too_low", "not capable adventurer rank"},
+		{"validation", NewValidation("invalid_filter", "invalid quest filter"), http.StatusUnprocessableEntity, "invalid_filter", "invalid quest filter"},
+		{"wrapped", fmt.Errorf("%w: unknown sort", NewValidation("invalid_filter", "invalid quest filter")),
+			http.StatusUnprocessableEntity, "invalid_filter", "invalid quest filter: unknown sort"},
+		{"unauthenticated", NewUnauthenticated("unauthenticated", "missing or unknown api key"), http.StatusUnauthorized, "unauthenticated", "missing or unknown api key"},
+		{"bad request", NewBadRequest("quest id must be valid"), http.StatusBadRequest, CodeBadRequest, "quest id must be valid"},
+		{"invalid fields", NewInvalidFields([]FieldError{{"name", "is required"}, {"rank", "must be between 1 and 100"}}),
+			http.StatusUnprocessableEntity, CodeInvalidFields, "invalid request: name is required, rank must be between 1 and 100"},
+		{"internal", errors.New("connection refused"), http.StatusInternalServerError, CodeInternal, "Internal Server Error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusCode, code, message := Describe(tt.err)
+			assert.Equal(t, tt.wantStatusCode, statusCode)
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, tt.wantMessage, message)
+		})
+	}
+	assert.True(t, errors.Is(fmt.Errorf("%w: detail", notFound), notFound))
+}
+
+func TestFields(t *testing.T) {
+	fields := []FieldError{{"adv_id", "is required"}}
+	assert.Equal(t, fields, Fields(fmt.Errorf("take quest: %w", NewInvalidFields(fields))))
+	assert.Nil(t, Fields(NewBadRequest("quest id must be valid")))
+	assert.Nil(t, Fields(errors.New("connection refused")))
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	modelAdv "github.com/arfaghifari/guild-board/src/model/adventurer"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+)
+
+func (c *client) ListAdventurers(ctx context.Context, filter modelAdv.AdventurerFilter) ([]modelAdv.Adventurer, int, error) {
+	query := pageQuery(filter.Limit, filter.Offset)
+	if filter.NamePrefix != "" {
+		query.Set("name", filter.NamePrefix)
+	}
+	setInt(query, "min_rank", int64(filter.MinRank))
+	setInt(query, "max_rank", int64(filter.MaxRank))
+	setSort(query, filter.Sort, filter.Desc)
+
+	var adventurers []modelAdv.Adventurer
+	header, err := c.do(ctx, http.MethodGet, "/v2/adventurers", query, nil, &adventurers)
+	if err != nil {
+		return nil, 0, err
+	}
+	return adventurers, totalCount(header), nil
+}
+
+func (c *client) CreateAdventurer(ctx context.Context, adventurer modelAdv.Adventurer) (modelAdv.Adventurer, error) {
+	body := struct {
+		Name string `json:"name"`
+		Rank int32  `json:"rank"`
+	}{adventurer.Name, adventurer.Rank}
+	var created modelAdv.Adventurer
+	_, err := c.do(ctx, http.MethodPost, "/v2/adventurers", nil, body, &created)
+	return created, err
+}
+
+func (c *client) GetAdventurer(ctx context.Context, advID int64) (modelAdv.Adventurer, error) {
+	var adventurer modelAdv.Adventurer
+	_, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v2/adventurers/%d", advID), nil, nil, &adventurer)
+	return adventurer, err
+}
+
+func (c *client) UpdateAdventurerRank(ctx context.Context, advID int64, rank int32) error {
+	body := struct {
+		Rank int32 `json:"rank"`
+	}{rank}
+	_, err := c.do(ctx, http.MethodPatch, fmt.Sprintf("/v2/adventurers/%d", advID), nil, body, nil)
+	return err
+}
+
+// GetAdventurerQuests lists the quests the adventurer is working on.
+func (c *client) GetAdventurerQuests(ctx context.Context, advID int64) ([]model.Quest, error) {
+	var quests []model.Quest
+	_, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v2/adventurers/%d/quests", advID), nil, nil, &quests)
+	return quests, err
+}
+
+func (c *client) GetProgress(ctx context.Context, advID int64) (modelAdv.Progress, error) {
+	var progress modelAdv.Progress
+	_, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v2/adventurers/%d/progress", advID), nil, nil, &progress)
+	return progress, err
+}
+
+func (c *client) GetAdventurerHistory(ctx context.Context, advID int64, limit, offset int) ([]model.Assignment, int, error) {
+	return c.history(ctx, fmt.Sprintf("/v2/adventurers/%d/history", advID), limit, offset)
+}
@@ -0,0 +1,217 @@
+// Package client calls the guild board API v2 for other services.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	modelAdv "github.com/arfaghifari/guild-board/src/model/adventurer"
+	modelGiver "github.com/arfaghifari/guild-board/src/model/giver"
+	modelLedger "github.com/arfaghifari/guild-board/src/model/ledger"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+)
+
+// Config locates the API and holds the API key to call it with. Reads failing
+// with a transport error or a 502, 503 or 504 are tried Retries more times,
+// waiting RetryWait and then twice as long after each attempt. Writes are never
+// retried: taking a quest twice is not the same as taking it once.
+type Config struct {
+	BaseURL    string
+	APIKey     string
+	HTTPClient *http.Client
+	Retries    int
+	RetryWait  time.Duration
+}
+
+// Client calls the API. Failures answered by the API are an *Error; listings
+// also return the number of matches across all pages. Quests are taken and
+// reported by the adventurer of the API key.
+type Client interface {
+	ListQuests(ctx context.Context, filter model.QuestFilter) ([]model.Quest, int, error)
+	CreateQuest(ctx context.Context, quest model.Quest) (model.Quest, error)
+	GetQuest(ctx context.Context, questID int64) (model.Quest, error)
+	UpdateQuest(ctx context.Context, questID int64, minimumRank, reward int32) error
+	DeleteQuest(ctx context.Context, questID int64) error
+	CancelQuest(ctx context.Context, questID int64) ([]int64, error)
+	GetTakers(ctx context.Context, questID int64) ([]model.TakenBy, error)
+	TakeQuest(ctx context.Context, questID int64) error
+	ReportQuest(ctx context.Context, questID int64, completed bool) error
+	GetQuestHistory(ctx context.Context, questID int64, limit, offset int) ([]model.Assignment, int, error)
+
+	ListAdventurers(ctx context.Context, filter modelAdv.AdventurerFilter) ([]modelAdv.Adventurer, int, error)
+	CreateAdventurer(ctx context.Context, adventurer modelAdv.Adventurer) (modelAdv.Adventurer, error)
+	GetAdventurer(ctx context.Context, advID int64) (modelAdv.Adventurer, error)
+	UpdateAdventurerRank(ctx context.Context, advID int64, rank int32) error
+	GetAdventurerQuests(ctx context.Context, advID int64) ([]model.Quest, error)
+	GetProgress(ctx context.Context, advID int64) (modelAdv.Progress, error)
+	GetAdventurerHistory(ctx context.Context, advID int64, limit, offset int) ([]model.Assignment, int, error)
+
+	CreateGiver(ctx context.Context, name string) (modelGiver.Giver, error)
+	GetGiver(ctx context.Context, giverID int64) (modelGiver.Giver, error)
+	ListGiverQuests(ctx context.Context, giverID int64, filter model.QuestFilter) ([]model.PostedQuest, int, error)
+
+	GetGuildBalance(ctx context.Context) (modelLedger.Balance, error)
+	GetGiverBalance(ctx context.Context, giverID int64) (modelLedger.Balance, error)
+	GetAdventurerBalance(ctx context.Context, advID int64) (modelLedger.Balance, error)
+	GetQuestEscrow(ctx context.Context, questID int64) (modelLedger.Balance, error)
+	GetQuestPayout(ctx context.Context, questID int64) (modelLedger.Payout, error)
+}
+
+type client struct {
+	baseURL   *url.URL
+	apiKey    string
+	http      *http.Client
+	retries   int
+	retryWait time.Duration
+}
+
+var errInvalidConfig = errors.New("client needs an absolute base url and no negative retries")
+
+func NewClient(cfg Config) (Client, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(cfg.BaseURL, "/"))
+	if err != nil || !baseURL.IsAbs() || cfg.Retries < 0 || cfg.RetryWait < 0 {
+		return nil, errInvalidConfig
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &client{baseURL, cfg.APIKey, httpClient, cfg.Retries, cfg.RetryWait}, nil
+}
+
+type envelope struct {
+	Header response.Header `json:"header"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// do sends body as JSON and decodes the data of the response into data. It
+// returns the response headers.
+func (c *client) do(ctx context.Context, method, path string, query url.Values, body, data interface{}) (http.Header, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+	endpoint := *c.baseURL
+	endpoint.Path += path
+	endpoint.RawQuery = query.Encode()
+
+	attempts := 1
+	if method == http.MethodGet {
+		attempts += c.retries
+	}
+	wait := c.retryWait
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, method, endpoint.String(), payload)
+		retry := attempt < attempts && (err != nil || retryable(resp.StatusCode))
+		if !retry {
+			if err != nil {
+				return nil, err
+			}
+			defer resp.Body.Close()
+			return resp.Header, decode(resp, data)
+		}
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+func (c *client) send(ctx context.Context, method, endpoint string, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.http.Do(req)
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return resp, err
+}
+
+func retryable(statusCode int) bool {
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// decode reads the envelope of resp. A failure without one, from a proxy for
+// instance, still becomes an *Error carrying the status code.
+func decode(resp *http.Response, data interface{}) error {
+	var env envelope
+	err := json.NewDecoder(resp.Body).Decode(&env)
+	if resp.StatusCode >= http.StatusBadRequest || env.Header.Error != "" {
+		if err != nil || env.Header.Error == "" {
+			return &Error{StatusCode: resp.StatusCode, Code: codeOf(resp.StatusCode), Message: http.StatusText(resp.StatusCode)}
+		}
+		return &Error{StatusCode: resp.StatusCode, Code: env.Header.Error, Message: env.Header.Message, Fields: env.Header.Fields}
+	}
+	if err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	if data == nil {
+		return nil
+	}
+	return json.Unmarshal(env.Data, data)
+}
+
+func totalCount(header http.Header) int {
+	total, _ := strconv.Atoi(header.Get("X-Total-Count"))
+	return total
+}
+
+func setInt(query url.Values, name string, value int64) {
+	if value != 0 {
+		query.Set(name, strconv.FormatInt(value, 10))
+	}
+}
+
+func setSort(query url.Values, sort string, desc bool) {
+	if sort == "" {
+		return
+	}
+	if desc {
+		sort = "-" + sort
+	}
+	query.Set("sort", sort)
+}
+
+func pageQuery(limit, offset int) url.Values {
+	query := url.Values{}
+	setInt(query, "limit", int64(limit))
+	setInt(query, "offset", int64(offset))
+	return query
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	modelAdv "github.com/arfaghifari/guild-board/src/model/adventurer"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient(Config{BaseURL: "http://guild.local/"})
+	assert.NoError(t, err)
+	for _, cfg := range []Config{
+		{},
+		{BaseURL: "/v2"},
+		{BaseURL: "http://guild.local", Retries: -1},
+		{BaseURL: "http://guild.local", RetryWait: -time.Second},
+	} {
+		_, err := NewClient(cfg)
+		assert.Error(t, err, "%+v", cfg)
+	}
+}
+
+// serve answers every request with the given statuses in turn, then with the
+// last one, and counts the requests.
+func serve(t *testing.T, bodies map[int]string, statuses ...int) (Client, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1))
+		if n > len(statuses) {
+			n = len(statuses)
+		}
+		status := statuses[n-1]
+		w.WriteHeader(status)
+		fmt.Fprint(w, bodies[status])
+	}))
+	t.Cleanup(server.Close)
+	c, err := NewClient(Config{BaseURL: server.URL, Retries: 2, RetryWait: time.Millisecond})
+	assert.NoError(t, err)
+	return c, &calls
+}
+
+const questBody = `{"header":{"error_code":"","status_code":200},"data":{"quest_id":1,"name":"menyelamatkan kucing"}}`
+
+func TestRetries(t *testing.T) {
+	bodies := map[int]string{
+		http.StatusOK:                 questBody,
+		http.StatusServiceUnavailable: `upstream unavailable`,
+	}
+	ctx := context.Background()
+
+	c, calls := serve(t, bodies, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK)
+	quest, err := c.GetQuest(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "menyelamatkan kucing", quest.Name)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+
+	c, calls = serve(t, bodies, http.StatusServiceUnavailable)
+	_, err = c.GetQuest(ctx, 1)
+	assert.True(t, errors.Is(err, ErrInternal))
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls), "one attempt and two retries")
+
+	c, calls = serve(t, bodies, http.StatusServiceUnavailable, http.StatusOK)
+	err = c.TakeQuest(ctx, 1)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls), "writes are not retried")
+
+	c, calls = serve(t, bodies, http.StatusServiceUnavailable)
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = c.GetQuest(cancelled, 1)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.LessOrEqual(t, atomic.LoadInt32(calls), int32(1))
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		wantErr   error
+		wantError *Error
+	}{
+		{
+			name:      "domain error",
+			status:    http.StatusConflict,
+			body:      `{"header":{"error_code":"already_joined","message":"adventurer already joined the quest","status_code":409},"data":{"success":false}}`,
+			wantErr:   ErrAlreadyJoined,
+			wantError: &Error{StatusCode: http.StatusConflict, Code: "already_joined", Message: "adventurer already joined the quest"},
+		},
+		{
+			name:    "invalid fields",
+			status:  http.StatusUnprocessableEntity,
+			body:    `{"header":{"error_code":"invalid_fields","message":"invalid request: adv_id is required","fields":[{"field":"adv_id","message":"is required"}],"status_code":422},"data":{"success":false}}`,
+			wantErr: ErrInvalidFields,
+			wantError: &Error{StatusCode: http.StatusUnprocessableEntity, Code: apperror.CodeInvalidFields, Message: "invalid request: adv_id is required",
+				Fields: []apperror.FieldError{{Field: "adv_id", Message: "is required"}}},
+		},
+		{
+			name:      "no envelope",
+			status:    http.StatusNotFound,
+			body:      `404 page not found`,
+			wantError: &Error{StatusCode: http.StatusNotFound, Message: "Not Found"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := serve(t, map[int]string{tt.status: tt.body}, tt.status)
+			err := c.TakeQuest(ctx, 1)
+			var apiErr *Error
+			assert.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, tt.wantError, apiErr)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			}
+			assert.False(t, errors.Is(err, ErrQuestNotFound))
+		})
+	}
+}
+
+func TestListQuery(t *testing.T) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer gb_key", r.Header.Get("Authorization"))
+		queries = append(queries, r.URL.Query())
+		w.Header().Set("X-Total-Count", "42")
+		fmt.Fprint(w, `{"header":{"error_code":"","status_code":200},"data":[]}`)
+	}))
+	defer server.Close()
+	c, _ := NewClient(Config{BaseURL: server.URL, APIKey: "gb_key"})
+	ctx := context.Background()
+
+	_, total, err := c.ListQuests(ctx, model.QuestFilter{Statuses: []int32{0, 1}, MinReward: 100, Name: "kucing", Sort: "reward", Desc: true, Limit: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 42, total)
+	_, _, err = c.ListAdventurers(ctx, modelAdv.AdventurerFilter{NamePrefix: "an", MaxRank: 12, Sort: "rank", Offset: 10})
+	assert.NoError(t, err)
+	_, _, err = c.GetQuestHistory(ctx, 1, 0, 0)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []url.Values{
+		{"status": {"0,1"}, "min_reward": {"100"}, "name": {"kucing"}, "sort": {"-reward"}, "limit": {"5"}},
+		{"name": {"an"}, "max_rank": {"12"}, "sort": {"rank"}, "offset": {"10"}},
+		{},
+	}, queries)
+}
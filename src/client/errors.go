@@ -0,0 +1,62 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+)
+
+// Error is a failure answered by the API. errors.Is matches it with the
+// sentinel of the same Code below.
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Fields     []apperror.FieldError
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("guild board: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code != "" && t.Code == e.Code
+}
+
+// Sentinels of the error codes of the API, see its README.
+var (
+	ErrBadRequest         = &Error{Code: apperror.CodeBadRequest}
+	ErrInvalidFields      = &Error{Code: apperror.CodeInvalidFields}
+	ErrInternal           = &Error{Code: apperror.CodeInternal}
+	ErrUnauthenticated    = &Error{Code: "unauthenticated"}
+	ErrPermissionDenied   = &Error{Code: "permission_denied"}
+	ErrOtherAdventurer    = &Error{Code: "other_adventurer"}
+	ErrQuestNotFound      = &Error{Code: "quest_not_found"}
+	ErrAdventurerNotFound = &Error{Code: "adventurer_not_found"}
+	ErrGiverNotFound      = &Error{Code: "giver_not_found"}
+	ErrPayoutNotFound     = &Error{Code: "payout_not_found"}
+	ErrQuestTaken         = &Error{Code: "quest_taken"}
+	ErrQuestNotTaken      = &Error{Code: "quest_not_taken"}
+	ErrNotInParty         = &Error{Code: "not_in_party"}
+	ErrRankTooLow         = &Error{Code: "rank_too_low"}
+	ErrQuestExpired       = &Error{Code: "quest_expired"}
+	ErrQuestCompleted     = &Error{Code: "quest_completed"}
+	ErrQuestCancelled     = &Error{Code: "quest_cancelled"}
+	ErrQuestChanged       = &Error{Code: "quest_changed"}
+	ErrAlreadyJoined      = &Error{Code: "already_joined"}
+	ErrPastDeadline       = &Error{Code: "past_deadline"}
+	ErrInvalidParty       = &Error{Code: "invalid_party"}
+	ErrInvalidRankRule    = &Error{Code: "invalid_rank_rule"}
+	ErrInvalidFilter      = &Error{Code: "invalid_filter"}
+)
+
+// codeOf names a failure that came without an envelope, from a proxy for
+// instance; only server errors have a code to match.
+func codeOf(statusCode int) string {
+	if statusCode >= http.StatusInternalServerError {
+		return apperror.CodeInternal
+	}
+	return ""
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	modelGiver "github.com/arfaghifari/guild-board/src/model/giver"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+)
+
+func (c *client) CreateGiver(ctx context.Context, name string) (modelGiver.Giver, error) {
+	body := struct {
+		Name string `json:"name"`
+	}{name}
+	var created modelGiver.Giver
+	_, err := c.do(ctx, http.MethodPost, "/v2/givers", nil, body, &created)
+	return created, err
+}
+
+func (c *client) GetGiver(ctx context.Context, giverID int64) (modelGiver.Giver, error) {
+	var giver modelGiver.Giver
+	_, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v2/givers/%d", giverID), nil, nil, &giver)
+	return giver, err
+}
+
+// ListGiverQuests lists the quests the giver posted, each with its party.
+func (c *client) ListGiverQuests(ctx context.Context, giverID int64, filter model.QuestFilter) ([]model.PostedQuest, int, error) {
+	var quests []model.PostedQuest
+	header, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v2/givers/%d/quests", giverID), questQuery(filter), nil, &quests)
+	if err != nil {
+		return nil, 0, err
+	}
+	return quests, totalCount(header), nil
+}
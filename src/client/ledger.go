@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	modelLedger "github.com/arfaghifari/guild-board/src/model/ledger"
+)
+
+func (c *client) balance(ctx context.Context, path string) (modelLedger.Balance, error) {
+	var balance modelLedger.Balance
+	_, err := c.do(ctx, http.MethodGet, path, nil, nil, &balance)
+	return balance, err
+}
+
+// GetGuildBalance gets what the guild paid for the quests posted by the staff.
+func (c *client) GetGuildBalance(ctx context.Context) (modelLedger.Balance, error) {
+	return c.balance(ctx, "/v2/guild/balance")
+}
+
+func (c *client) GetGiverBalance(ctx context.Context, giverID int64) (modelLedger.Balance, error) {
+	return c.balance(ctx, fmt.Sprintf("/v2/givers/%d/balance", giverID))
+}
+
+func (c *client) GetAdventurerBalance(ctx context.Context, advID int64) (modelLedger.Balance, error) {
+	return c.balance(ctx, fmt.Sprintf("/v2/adventurers/%d/balance", advID))
+}
+
+// GetQuestEscrow gets the reward held for a quest until it is completed or
+// cancelled.
+func (c *client) GetQuestEscrow(ctx context.Context, questID int64) (modelLedger.Balance, error) {
+	return c.balance(ctx, fmt.Sprintf("/v2/quests/%d/escrow", questID))
+}
+
+// GetQuestPayout gets how the reward of a completed quest was split between the
+// guild fee and the party.
+func (c *client) GetQuestPayout(ctx context.Context, questID int64) (modelLedger.Payout, error) {
+	var payout modelLedger.Payout
+	_, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v2/quests/%d/payout", questID), nil, nil, &payout)
+	return payout, err
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+)
+
+func (c *client) ListQuests(ctx context.Context, filter model.QuestFilter) ([]model.Quest, int, error) {
+	var quests []model.Quest
+	header, err := c.do(ctx, http.MethodGet, "/v2/quests", questQuery(filter), nil, &quests)
+	if err != nil {
+		return nil, 0, err
+	}
+	return quests, totalCount(header), nil
+}
+
+// questQuery encodes filter for the listings of quests.
+func questQuery(filter model.QuestFilter) url.Values {
+	query := pageQuery(filter.Limit, filter.Offset)
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = strconv.Itoa(int(status))
+		}
+		query.Set("status", strings.Join(statuses, ","))
+	}
+	setInt(query, "min_reward", int64(filter.MinReward))
+	setInt(query, "max_reward", int64(filter.MaxReward))
+	setInt(query, "min_rank", int64(filter.MinRank))
+	setInt(query, "max_rank", int64(filter.MaxRank))
+	if filter.Name != "" {
+		query.Set("name", filter.Name)
+	}
+	setSort(query, filter.Sort, filter.Desc)
+	return query
+}
+
+// CreateQuest sends the fields of quest a client may choose; the API sets the
+// others.
+func (c *client) CreateQuest(ctx context.Context, quest model.Quest) (model.Quest, error) {
+	body := model.NewQuest{
+		Name:         quest.Name,
+		Description:  quest.Description,
+		MinimumRank:  quest.MinimumRank,
+		RewardNumber: quest.RewardNumber,
+		Deadline:     quest.Deadline,
+		MinMembers:   quest.MinMembers,
+		MaxMembers:   quest.MaxMembers,
+		RankRule:     quest.RankRule,
+	}
+	var created model.Quest
+	_, err := c.do(ctx, http.MethodPost, "/v2/quests", nil, body, &created)
+	return created, err
+}
+
+func (c *client) GetQuest(ctx context.Context, questID int64) (model.Quest, error) {
+	var quest model.Quest
+	_, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v2/quests/%d", questID), nil, nil, &quest)
+	return quest, err
+}
+
+// UpdateQuest changes the minimum rank, the reward or both; zero keeps a value.
+func (c *client) UpdateQuest(ctx context.Context, questID int64, minimumRank, reward int32) error {
+	body := struct {
+		MinimumRank  int32 `json:"minimum_rank,omitempty"`
+		RewardNumber int32 `json:"reward_number,omitempty"`
+	}{minimumRank, reward}
+	_, err := c.do(ctx, http.MethodPatch, fmt.Sprintf("/v2/quests/%d", questID), nil, body, nil)
+	return err
+}
+
+func (c *client) DeleteQuest(ctx context.Context, questID int64) error {
+	_, err := c.do(ctx, http.MethodDelete, fmt.Sprintf("/v2/quests/%d", questID), nil, nil, nil)
+	return err
+}
+
+// CancelQuest returns the adventurers released from the quest's party.
+func (c *client) CancelQuest(ctx context.Context, questID int64) ([]int64, error) {
+	var cancelled struct {
+		ReleasedAdventurers []int64 `json:"released_adventurers"`
+	}
+	_, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v2/quests/%d/cancel", questID), nil, nil, &cancelled)
+	return cancelled.ReleasedAdventurers, err
+}
+
+func (c *client) GetTakers(ctx context.Context, questID int64) ([]model.TakenBy, error) {
+	var takers []model.TakenBy
+	_, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v2/quests/%d/takers", questID), nil, nil, &takers)
+	return takers, err
+}
+
+func (c *client) TakeQuest(ctx context.Context, questID int64) error {
+	_, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v2/quests/%d/takers", questID), nil, struct{}{}, nil)
+	return err
+}
+
+func (c *client) ReportQuest(ctx context.Context, questID int64, completed bool) error {
+	body := struct {
+		IsCompleted bool `json:"is_completed"`
+	}{completed}
+	_, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v2/quests/%d/report", questID), nil, body, nil)
+	return err
+}
+
+func (c *client) GetQuestHistory(ctx context.Context, questID int64, limit, offset int) ([]model.Assignment, int, error) {
+	return c.history(ctx, fmt.Sprintf("/v2/quests/%d/history", questID), limit, offset)
+}
+
+func (c *client) history(ctx context.Context, path string, limit, offset int) ([]model.Assignment, int, error) {
+	var history []model.Assignment
+	header, err := c.do(ctx, http.MethodGet, path, pageQuery(limit, offset), nil, &history)
+	if err != nil {
+		return nil, 0, err
+	}
+	return history, totalCount(header), nil
+}
@@ -0,0 +1,201 @@
+package src
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/client"
+	"github.com/arfaghifari/guild-board/src/clock"
+	"github.com/arfaghifari/guild-board/src/config"
+	"github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/logger"
+	modelAdv "github.com/arfaghifari/guild-board/src/model/adventurer"
+	modelLedger "github.com/arfaghifari/guild-board/src/model/ledger"
+	modelQuest "github.com/arfaghifari/guild-board/src/model/quest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Driver = config.DriverMemory
+	repos, _, _ := newRepositories(cfg)
+	appLogger, _ := logger.NewLogger("error")
+	u, _ := newUsecases(repos, clock.NewClock(), cfg.Progression, cfg.Commission)
+	router, err := newRouter(cfg, u, appLogger)
+	assert.NoError(t, err)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	anonymous, err := client.NewClient(client.Config{BaseURL: server.URL})
+	assert.NoError(t, err)
+	ctx := context.Background()
+	_, err = anonymous.GetQuest(ctx, 1)
+	assert.True(t, errors.Is(err, client.ErrUnauthenticated))
+
+	staffKey, _, err := u.auth.IssueAPIKey("front desk", constant.RoleStaff, 0)
+	assert.NoError(t, err)
+	c, err := client.NewClient(client.Config{BaseURL: server.URL, APIKey: staffKey})
+	assert.NoError(t, err)
+
+	andi, err := c.CreateAdventurer(ctx, modelAdv.Adventurer{Name: "andi", Rank: 11})
+	assert.NoError(t, err)
+	quest, err := c.CreateQuest(ctx, modelQuest.Quest{Name: "menyelamatkan kucing", MinimumRank: 11, RewardNumber: 200000})
+	assert.NoError(t, err)
+	assert.Equal(t, constant.RankRuleAll, quest.RankRule)
+	assert.True(t, errors.Is(c.TakeQuest(ctx, quest.ID), client.ErrPermissionDenied))
+
+	andiKey, _, err := u.auth.IssueAPIKey("andi", constant.RoleAdventurer, andi.ID)
+	assert.NoError(t, err)
+	asAndi, err := client.NewClient(client.Config{BaseURL: server.URL, APIKey: andiKey})
+	assert.NoError(t, err)
+	assert.NoError(t, asAndi.TakeQuest(ctx, quest.ID))
+	assert.True(t, errors.Is(asAndi.TakeQuest(ctx, quest.ID), client.ErrQuestTaken))
+	active, err := c.GetAdventurerQuests(ctx, andi.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{quest.ID}, []int64{active[0].ID})
+	assert.NoError(t, asAndi.ReportQuest(ctx, quest.ID, true))
+	assert.True(t, errors.Is(asAndi.ReportQuest(ctx, quest.ID, true), client.ErrQuestNotTaken))
+
+	history, total, err := c.GetAdventurerHistory(ctx, andi.ID, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, constant.OutcomeCompleted, history[0].Outcome)
+	andi, err = c.GetAdventurer(ctx, andi.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), andi.CompletedQuest)
+
+	assert.NoError(t, c.UpdateAdventurerRank(ctx, andi.ID, 12))
+	adventurers, total, err := c.ListAdventurers(ctx, modelAdv.AdventurerFilter{MinRank: 12})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "andi", adventurers[0].Name)
+
+	_, err = c.GetAdventurer(ctx, 99)
+	assert.True(t, errors.Is(err, client.ErrAdventurerNotFound))
+	_, err = c.CreateQuest(ctx, modelQuest.Quest{MinimumRank: 11, RewardNumber: 200000})
+	var apiErr *client.Error
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, []apperror.FieldError{{Field: "name", Message: "is required"}}, apiErr.Fields)
+
+	released, err := c.CancelQuest(ctx, quest.ID)
+	assert.True(t, errors.Is(err, client.ErrQuestCompleted))
+	assert.Empty(t, released)
+}
+
+func TestClientGivers(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Driver = config.DriverMemory
+	repos, _, _ := newRepositories(cfg)
+	appLogger, _ := logger.NewLogger("error")
+	u, _ := newUsecases(repos, clock.NewClock(), cfg.Progression, cfg.Commission)
+	router, err := newRouter(cfg, u, appLogger)
+	assert.NoError(t, err)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	ctx := context.Background()
+	staffKey, _, err := u.auth.IssueAPIKey("front desk", constant.RoleStaff, 0)
+	assert.NoError(t, err)
+	c, err := client.NewClient(client.Config{BaseURL: server.URL, APIKey: staffKey})
+	assert.NoError(t, err)
+
+	mayor, err := c.CreateGiver(ctx, "mayor of riverwood")
+	assert.NoError(t, err)
+	res, err := c.GetGiver(ctx, mayor.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, mayor.Name, res.Name)
+	_, err = c.GetGiver(ctx, 99)
+	assert.True(t, errors.Is(err, client.ErrGiverNotFound))
+
+	mayorKey, _, err := u.auth.IssueAPIKey("mayor", constant.RoleGiver, mayor.ID)
+	assert.NoError(t, err)
+	asMayor, err := client.NewClient(client.Config{BaseURL: server.URL, APIKey: mayorKey})
+	assert.NoError(t, err)
+	quest, err := asMayor.CreateQuest(ctx, modelQuest.Quest{Name: "menyelamatkan kucing", MinimumRank: 11, RewardNumber: 200000})
+	assert.NoError(t, err)
+	assert.Equal(t, mayor.ID, quest.CreatedBy)
+	assert.NoError(t, asMayor.UpdateQuest(ctx, quest.ID, 0, 250000))
+
+	posted, total, err := asMayor.ListGiverQuests(ctx, mayor.ID, modelQuest.QuestFilter{Statuses: []int32{constant.AvailableQuest}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, int32(250000), posted[0].RewardNumber)
+	assert.Empty(t, posted[0].Takers)
+}
+
+func TestClientLedger(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Driver = config.DriverMemory
+	cfg.Commission = config.Commission{Percent: 10, MinimumFee: 5000}
+	repos, _, _ := newRepositories(cfg)
+	appLogger, _ := logger.NewLogger("error")
+	u, _ := newUsecases(repos, clock.NewClock(), cfg.Progression, cfg.Commission)
+	router, err := newRouter(cfg, u, appLogger)
+	assert.NoError(t, err)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	ctx := context.Background()
+	staffKey, _, err := u.auth.IssueAPIKey("front desk", constant.RoleStaff, 0)
+	assert.NoError(t, err)
+	c, err := client.NewClient(client.Config{BaseURL: server.URL, APIKey: staffKey})
+	assert.NoError(t, err)
+
+	mayor, err := c.CreateGiver(ctx, "mayor of riverwood")
+	assert.NoError(t, err)
+	andi, err := c.CreateAdventurer(ctx, modelAdv.Adventurer{Name: "andi", Rank: 11})
+	assert.NoError(t, err)
+	mayorKey, _, err := u.auth.IssueAPIKey("mayor", constant.RoleGiver, mayor.ID)
+	assert.NoError(t, err)
+	asMayor, _ := client.NewClient(client.Config{BaseURL: server.URL, APIKey: mayorKey})
+	andiKey, _, err := u.auth.IssueAPIKey("andi", constant.RoleAdventurer, andi.ID)
+	assert.NoError(t, err)
+	asAndi, _ := client.NewClient(client.Config{BaseURL: server.URL, APIKey: andiKey})
+
+	rescue, err := asMayor.CreateQuest(ctx, modelQuest.Quest{Name: "menyelamatkan kucing", MinimumRank: 11, RewardNumber: 200000})
+	assert.NoError(t, err)
+	sewer, err := c.CreateQuest(ctx, modelQuest.Quest{Name: "membersihkan selokan", MinimumRank: 11, RewardNumber: 100000})
+	assert.NoError(t, err)
+	escrow, err := asAndi.GetQuestEscrow(ctx, rescue.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, modelLedger.EscrowAccount(rescue.ID), escrow.Account)
+	assert.Equal(t, int64(200000), escrow.Balance)
+
+	assert.NoError(t, asAndi.TakeQuest(ctx, rescue.ID))
+	assert.NoError(t, asAndi.ReportQuest(ctx, rescue.ID, true))
+	_, err = c.CancelQuest(ctx, sewer.ID)
+	assert.NoError(t, err)
+
+	payout, err := asAndi.GetQuestPayout(ctx, rescue.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(200000), payout.Gross)
+	assert.Equal(t, int64(20000), payout.GuildFee)
+	assert.Equal(t, int64(180000), payout.Net)
+	_, err = c.GetQuestPayout(ctx, sewer.ID)
+	assert.True(t, errors.Is(err, client.ErrPayoutNotFound))
+
+	escrow, err = c.GetQuestEscrow(ctx, rescue.ID)
+	assert.NoError(t, err)
+	assert.Zero(t, escrow.Balance)
+	earned, err := asAndi.GetAdventurerBalance(ctx, andi.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(180000), earned.Balance)
+	paid, err := asMayor.GetGiverBalance(ctx, mayor.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-200000), paid.Balance)
+	guild, err := c.GetGuildBalance(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20000), guild.Balance)
+
+	_, err = asAndi.GetGiverBalance(ctx, mayor.ID)
+	assert.True(t, errors.Is(err, client.ErrPermissionDenied))
+	_, err = asMayor.GetGuildBalance(ctx)
+	assert.True(t, errors.Is(err, client.ErrPermissionDenied))
+	_, err = c.GetAdventurerBalance(ctx, 99)
+	assert.True(t, errors.Is(err, client.ErrAdventurerNotFound))
+	_, err = c.GetQuestEscrow(ctx, 99)
+	assert.True(t, errors.Is(err, client.ErrQuestNotFound))
+}
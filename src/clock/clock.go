@@ -0,0 +1,25 @@
+package clock
+
+import "time"
+
+// Clock tells the current time; usecases take one so tests can pin it.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func NewClock() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// Fixed is a Clock stopped at a given time.
+type Fixed time.Time
+
+func (f Fixed) Now() time.Time {
+	return time.Time(f)
+}
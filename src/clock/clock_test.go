@@ -0,0 +1,21 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClock(t *testing.T) {
+	before := time.Now()
+	now := NewClock().Now()
+	assert.False(t, now.Before(before))
+	assert.False(t, now.After(time.Now()))
+}
+
+func TestFixed(t *testing.T) {
+	at := time.Date(2023, 7, 1, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, at, Fixed(at).Now())
+	assert.Equal(t, at, Fixed(at).Now())
+}
@@ -0,0 +1,298 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/arfaghifari/guild-board/src/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDefault(t *testing.T) {
+	assert.NoError(t, Default().Validate())
+}
+
+func TestDataSource(t *testing.T) {
+	assert.Equal(t, defaultDSN[DriverPostgres], Default().Database.DataSource())
+	assert.Equal(t, defaultDSN[DriverSQLite], Database{Driver: DriverSQLite}.DataSource())
+	assert.Equal(t, "file:test.db", Database{Driver: DriverSQLite, DSN: "file:test.db"}.DataSource())
+}
+
+func TestLoad(t *testing.T) {
+	yamlFile := writeFile(t, "guild.yaml", `
+database:
+  dsn: postgres://guild@db:5432/guild
+  max_open_conns: 20
+http:
+  port: 9000
+  read_timeout: 3s
+log:
+  level: debug
+commission:
+  percent: 10
+  minimum_fee: 5000
+  tiers:
+    - minimum_rank: 15
+      percent: 15
+`)
+	jsonFile := writeFile(t, "guild.json", `{"http": {"port": 9100, "write_timeout": "7s"}, "features": {"auto_migrate": true}}`)
+	badFile := writeFile(t, "bad.yaml", "http: [")
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		check   func(*testing.T, Config)
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			env:  map[string]string{},
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, Default(), cfg)
+			},
+			wantErr: false,
+		},
+		{
+			name: "yaml file",
+			env:  map[string]string{EnvConfigFile: yamlFile},
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, "postgres://guild@db:5432/guild", cfg.Database.DSN)
+				assert.Equal(t, 20, cfg.Database.MaxOpenConns)
+				assert.Equal(t, 5, cfg.Database.MaxIdleConns)
+				assert.Equal(t, 9000, cfg.HTTP.Port)
+				assert.Equal(t, 3*time.Second, cfg.HTTP.ReadTimeout)
+				assert.Equal(t, 5*time.Second, cfg.HTTP.WriteTimeout)
+				assert.Equal(t, "debug", cfg.Log.Level)
+				assert.Equal(t, Commission{Percent: 10, MinimumFee: 5000, Tiers: []CommissionTier{{MinimumRank: 15, Percent: 15}}}, cfg.Commission)
+			},
+			wantErr: false,
+		},
+		{
+			name: "json file",
+			env:  map[string]string{EnvConfigFile: jsonFile},
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, 9100, cfg.HTTP.Port)
+				assert.Equal(t, 7*time.Second, cfg.HTTP.WriteTimeout)
+				assert.True(t, cfg.Features.AutoMigrate)
+			},
+			wantErr: false,
+		},
+		{
+			name: "env overrides file",
+			env: map[string]string{
+				EnvConfigFile:                     yamlFile,
+				"GUILD_HTTP_PORT":                 "8080",
+				"GUILD_DB_DSN":                    "postgres://env@localhost/guild",
+				"GUILD_HTTP_READ_TIMEOUT":         "1s",
+				"GUILD_AUTO_MIGRATE":              "true",
+				"GUILD_LOG_LEVEL":                 "warn",
+				"GUILD_SCHEDULER_INTERVAL":        "0",
+				"GUILD_SCHEDULER_WORKING_TIMEOUT": "24h",
+				"GUILD_PROGRESSION_MAX_RANK":      "20",
+				"GUILD_PROGRESSION_REWARD_UNIT":   "0",
+				"GUILD_COMMISSION_PERCENT":        "12",
+			},
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, 8080, cfg.HTTP.Port)
+				assert.Equal(t, "postgres://env@localhost/guild", cfg.Database.DSN)
+				assert.Equal(t, time.Second, cfg.HTTP.ReadTimeout)
+				assert.True(t, cfg.Features.AutoMigrate)
+				assert.Equal(t, "warn", cfg.Log.Level)
+				assert.Equal(t, time.Duration(0), cfg.Scheduler.Interval)
+				assert.Equal(t, 24*time.Hour, cfg.Scheduler.WorkingTimeout)
+				assert.Equal(t, 20, cfg.Progression.MaxRank)
+				assert.Equal(t, 0, cfg.Progression.RewardUnit)
+				assert.Equal(t, 10, cfg.Progression.PromotionPoints)
+				assert.Equal(t, 12, cfg.Commission.Percent)
+				assert.Equal(t, 5000, cfg.Commission.MinimumFee)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "missing file",
+			env:     map[string]string{EnvConfigFile: filepath.Join(t.TempDir(), "missing.yaml")},
+			wantErr: true,
+		},
+		{
+			name:    "malformed file",
+			env:     map[string]string{EnvConfigFile: badFile},
+			wantErr: true,
+		},
+		{
+			name:    "malformed env",
+			env:     map[string]string{"GUILD_HTTP_PORT": "eighty"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid value",
+			env:     map[string]string{"GUILD_LOG_LEVEL": "verbose"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{EnvConfigFile, "GUILD_HTTP_PORT", "GUILD_DB_DSN", "GUILD_HTTP_READ_TIMEOUT", "GUILD_AUTO_MIGRATE", "GUILD_LOG_LEVEL", "GUILD_SCHEDULER_INTERVAL", "GUILD_SCHEDULER_WORKING_TIMEOUT", "GUILD_PROGRESSION_MAX_RANK", "GUILD_PROGRESSION_REWARD_UNIT"} {
+				t.Setenv(key, "")
+				os.Unsetenv(key)
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			res, err := Load()
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+				return
+			}
+			assert.NoError(t, err, tt.name)
+			tt.check(t, res)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			modify:  func(cfg *Config) {},
+			wantErr: false,
+		},
+		{
+			name:    "sqlite driver",
+			modify:  func(cfg *Config) { cfg.Database.Driver = DriverSQLite },
+			wantErr: false,
+		},
+		{
+			name:    "memory driver",
+			modify:  func(cfg *Config) { cfg.Database.Driver = DriverMemory },
+			wantErr: false,
+		},
+		{
+			name:    "unknown driver",
+			modify:  func(cfg *Config) { cfg.Database.Driver = "oracle" },
+			wantErr: true,
+		},
+		{
+			name:    "idle exceeds open",
+			modify:  func(cfg *Config) { cfg.Database.MaxIdleConns = 50 },
+			wantErr: true,
+		},
+		{
+			name:    "negative pool",
+			modify:  func(cfg *Config) { cfg.Database.MaxOpenConns = -1 },
+			wantErr: true,
+		},
+		{
+			name:    "port out of range",
+			modify:  func(cfg *Config) { cfg.HTTP.Port = 70000 },
+			wantErr: true,
+		},
+		{
+			name:    "zero timeout",
+			modify:  func(cfg *Config) { cfg.HTTP.WriteTimeout = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "scheduler disabled",
+			modify:  func(cfg *Config) { cfg.Scheduler.Interval = 0 },
+			wantErr: false,
+		},
+		{
+			name:    "negative scheduler interval",
+			modify:  func(cfg *Config) { cfg.Scheduler.Interval = -time.Second },
+			wantErr: true,
+		},
+		{
+			name:    "negative working timeout",
+			modify:  func(cfg *Config) { cfg.Scheduler.WorkingTimeout = -time.Hour },
+			wantErr: true,
+		},
+		{
+			name:    "zero promotion points",
+			modify:  func(cfg *Config) { cfg.Progression.PromotionPoints = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "negative demotion failures",
+			modify:  func(cfg *Config) { cfg.Progression.DemotionFailures = -1 },
+			wantErr: true,
+		},
+		{
+			name:    "zero min rank",
+			modify:  func(cfg *Config) { cfg.Progression.MinRank = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "max rank below min rank",
+			modify:  func(cfg *Config) { cfg.Progression.MinRank, cfg.Progression.MaxRank = 5, 3 },
+			wantErr: true,
+		},
+		{
+			name:    "uncapped max rank",
+			modify:  func(cfg *Config) { cfg.Progression.MaxRank = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "max rank above the highest rank",
+			modify:  func(cfg *Config) { cfg.Progression.MaxRank = constant.MaxRank + 1 },
+			wantErr: true,
+		},
+		{
+			name: "commission tiers",
+			modify: func(cfg *Config) {
+				cfg.Commission = Commission{Percent: 10, MinimumFee: 5000, Tiers: []CommissionTier{{MinimumRank: 15, Percent: 15}, {MinimumRank: 20, Percent: 20}}}
+			},
+			wantErr: false,
+		},
+		{
+			name:    "commission above 100 percent",
+			modify:  func(cfg *Config) { cfg.Commission.Percent = 101 },
+			wantErr: true,
+		},
+		{
+			name:    "negative minimum fee",
+			modify:  func(cfg *Config) { cfg.Commission.MinimumFee = -1 },
+			wantErr: true,
+		},
+		{
+			name:    "duplicate commission tiers",
+			modify:  func(cfg *Config) { cfg.Commission.Tiers = []CommissionTier{{MinimumRank: 15}, {MinimumRank: 15}} },
+			wantErr: true,
+		},
+		{
+			name:    "negative tier percent",
+			modify:  func(cfg *Config) { cfg.Commission.Tiers = []CommissionTier{{MinimumRank: 15, Percent: -5}} },
+			wantErr: true,
+		},
+		{
+			name:    "zero body size",
+			modify:  func(cfg *Config) { cfg.HTTP.MaxBodyBytes = 0 },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+		})
+	}
+}
@@ -4,4 +4,66 @@ const (
 	CompletedQuest = 2
 	AvailableQuest = 0
 	WorkingQuest   = 1
+	ExpiredQuest   = 3
+	CancelledQuest = 4
+)
+
+// Rank rules decide who may join a party quest: with RankRuleAll every member
+// needs the minimum rank, with RankRuleAverage the party's average rank does.
+const (
+	RankRuleAll     = "all"
+	RankRuleAverage = "average"
+)
+
+// Sort keys accepted by listings. Quests sort by creation time, reward or rank;
+// adventurers by id, rank or completed quests.
+const (
+	SortCreatedAt      = "created_at"
+	SortReward         = "reward"
+	SortRank           = "rank"
+	SortID             = "id"
+	SortCompletedQuest = "completed_quest"
+)
+
+// Page sizes of listings.
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Outcomes close an adventurer's assignment to a quest. A quest whose deadline
+// passes expires, one held past the working timeout is abandoned.
+const (
+	OutcomeCompleted = "completed"
+	OutcomeFailed    = "failed"
+	OutcomeAbandoned = "abandoned"
+	OutcomeExpired   = "expired"
+	OutcomeCancelled = "cancelled"
+	OutcomeDeleted   = "deleted"
+)
+
+// Bounds of the fields clients send. Names fit their VARCHAR(255) column.
+const (
+	MaxNameLength        = 255
+	MaxDescriptionLength = 2000
+	MinRank              = 1
+	MaxRank              = 100
+	MaxReward            = 1000000000
+)
+
+// Roles of API key holders: guild staff run the board, quest givers request
+// quests and adventurers act as the adventurer their key names.
+const (
+	RoleStaff      = "staff"
+	RoleGiver      = "quest_giver"
+	RoleAdventurer = "adventurer"
+)
+
+// Kinds of ledger transactions: a quest's reward is escrowed from whoever posts
+// it, released to the party that completes it or refunded when it closes
+// without being completed.
+const (
+	LedgerEscrow  = "escrow"
+	LedgerRelease = "release"
+	LedgerRefund  = "refund"
 )
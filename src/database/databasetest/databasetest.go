@@ -0,0 +1,36 @@
+// Package databasetest provides real databases for tests that need more than sqlmock.
+package databasetest
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/arfaghifari/guild-board/src/config"
+	"github.com/arfaghifari/guild-board/src/database"
+	"github.com/arfaghifari/guild-board/src/database/migration"
+)
+
+// NewSQLite opens a fully migrated SQLite database backed by a temporary file.
+// Syncing is off since the file is thrown away when the test finishes.
+func NewSQLite(t testing.TB) *sql.DB {
+	t.Helper()
+	cfg := config.Default().Database
+	cfg.Driver = config.DriverSQLite
+	cfg.DSN = "file:" + filepath.Join(t.TempDir(), "guild.db") + "?_foreign_keys=on&_busy_timeout=5000&_txlock=immediate&_sync=OFF"
+
+	db, err := database.Open(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := migration.NewMigrator(db, database.SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = migrator.Up(); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"database/sql"
+	"regexp"
+)
+
+// Dialect names the SQL flavour of a connection. Repository queries are written
+// for Postgres; the dialect adapts them to the other backends.
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite3"
+)
+
+var placeholder = regexp.MustCompile(`\$(\d+)`)
+
+// Rebind rewrites Postgres $n placeholders into the dialect's bind syntax.
+func (d Dialect) Rebind(query string) string {
+	if d != SQLite {
+		return query
+	}
+	// SQLite understands numbered ?NNN parameters, so reused placeholders keep working
+	return placeholder.ReplaceAllString(query, "?$1")
+}
+
+// Returning is the clause to append to an INSERT whose generated key is read
+// back by InsertID.
+func (d Dialect) Returning(column string) string {
+	if d == SQLite {
+		return ""
+	}
+	return " RETURNING " + column
+}
+
+// InsertID executes a prepared INSERT and returns the generated key.
+func (d Dialect) InsertID(stmt *sql.Stmt, args ...interface{}) (id int64, err error) {
+	if d != SQLite {
+		err = stmt.QueryRow(args...).Scan(&id)
+		return
+	}
+	res, err := stmt.Exec(args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// Bind wraps q so that every query is rebound for d.
+func Bind(q Querier, d Dialect) Querier {
+	if d != SQLite {
+		return q
+	}
+	return &boundQuerier{q, d}
+}
+
+type boundQuerier struct {
+	q Querier
+	d Dialect
+}
+
+func (b *boundQuerier) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return b.q.Exec(b.d.Rebind(query), args...)
+}
+
+func (b *boundQuerier) Prepare(query string) (*sql.Stmt, error) {
+	return b.q.Prepare(b.d.Rebind(query))
+}
+
+func (b *boundQuerier) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return b.q.Query(b.d.Rebind(query), args...)
+}
+
+func (b *boundQuerier) QueryRow(query string, args ...interface{}) *sql.Row {
+	return b.q.QueryRow(b.d.Rebind(query), args...)
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebind(t *testing.T) {
+	query := "UPDATE quest SET status = $1 WHERE quest_id = $2 AND status = $10"
+	assert.Equal(t, query, Postgres.Rebind(query))
+	assert.Equal(t, "UPDATE quest SET status = ?1 WHERE quest_id = ?2 AND status = ?10", SQLite.Rebind(query))
+}
+
+func TestReturning(t *testing.T) {
+	assert.Equal(t, " RETURNING id", Postgres.Returning("id"))
+	assert.Equal(t, "", SQLite.Returning("id"))
+}
+
+func TestInsertID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	query := regexp.QuoteMeta("INSERT INTO adventurer(name, rank) VALUES($1, $2) RETURNING id")
+	mock.ExpectPrepare(query).ExpectQuery().WithArgs("andi", 11).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	stmt, err := db.Prepare("INSERT INTO adventurer(name, rank) VALUES($1, $2) RETURNING id")
+	assert.NoError(t, err)
+	id, err := Postgres.InsertID(stmt, "andi", 11)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), id)
+
+	query = regexp.QuoteMeta("INSERT INTO adventurer(name, rank) VALUES(?1, ?2)")
+	mock.ExpectPrepare(query).ExpectExec().WithArgs("andi", 11).WillReturnResult(sqlmock.NewResult(8, 1))
+	stmt, err = Bind(db, SQLite).Prepare("INSERT INTO adventurer(name, rank) VALUES($1, $2)")
+	assert.NoError(t, err)
+	id, err = SQLite.InsertID(stmt, "andi", 11)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), id)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBind(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	assert.Equal(t, Querier(db), Bind(db, Postgres))
+
+	q := Bind(db, SQLite)
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM quest WHERE quest_id = ?1")).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name FROM quest WHERE quest_id = ?1")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"name"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT 1 FROM quest WHERE quest_id = ?1")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+
+	_, err = q.Exec("DELETE FROM quest WHERE quest_id = $1", 1)
+	assert.NoError(t, err)
+	rows, err := q.Query("SELECT name FROM quest WHERE quest_id = $1", 1)
+	assert.NoError(t, err)
+	rows.Close()
+	var one int
+	assert.NoError(t, q.QueryRow("SELECT 1 FROM quest WHERE quest_id = $1", 1).Scan(&one))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDialectOf(t *testing.T) {
+	assert.Equal(t, Postgres, DialectOf("postgres"))
+	assert.Equal(t, SQLite, DialectOf("sqlite"))
+}
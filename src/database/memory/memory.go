@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"sync"
+
+	modelAdv "github.com/arfaghifari/guild-board/src/model/adventurer"
+	modelAuth "github.com/arfaghifari/guild-board/src/model/auth"
+	modelGiver "github.com/arfaghifari/guild-board/src/model/giver"
+	modelLedger "github.com/arfaghifari/guild-board/src/model/ledger"
+	modelQuest "github.com/arfaghifari/guild-board/src/model/quest"
+)
+
+// Data holds the rows of every table. It is only reachable through Store, which
+// guards it.
+type Data struct {
+	Quests                  map[int64]modelQuest.Quest
+	Adventurers             map[int64]modelAdv.Adventurer
+	TakenBy                 []modelQuest.TakenBy
+	Assignments             []modelQuest.Assignment
+	APIKeys                 map[int64]modelAuth.APIKey
+	Givers                  map[int64]modelGiver.Giver
+	LedgerTransactions      []modelLedger.Transaction
+	Payouts                 map[int64]modelLedger.Payout
+	LastQuestID             int64
+	LastAdvID               int64
+	LastAssignmentID        int64
+	LastAPIKeyID            int64
+	LastGiverID             int64
+	LastLedgerTransactionID int64
+}
+
+func newData() *Data {
+	return &Data{
+		Quests:             map[int64]modelQuest.Quest{},
+		Adventurers:        map[int64]modelAdv.Adventurer{},
+		TakenBy:            []modelQuest.TakenBy{},
+		Assignments:        []modelQuest.Assignment{},
+		APIKeys:            map[int64]modelAuth.APIKey{},
+		Givers:             map[int64]modelGiver.Giver{},
+		LedgerTransactions: []modelLedger.Transaction{},
+		Payouts:            map[int64]modelLedger.Payout{},
+	}
+}
+
+func (d *Data) clone() *Data {
+	c := &Data{
+		Quests:                  make(map[int64]modelQuest.Quest, len(d.Quests)),
+		Adventurers:             make(map[int64]modelAdv.Adventurer, len(d.Adventurers)),
+		TakenBy:                 append([]modelQuest.TakenBy{}, d.TakenBy...),
+		Assignments:             append([]modelQuest.Assignment{}, d.Assignments...),
+		APIKeys:                 make(map[int64]modelAuth.APIKey, len(d.APIKeys)),
+		Givers:                  make(map[int64]modelGiver.Giver, len(d.Givers)),
+		LedgerTransactions:      append([]modelLedger.Transaction{}, d.LedgerTransactions...),
+		Payouts:                 make(map[int64]modelLedger.Payout, len(d.Payouts)),
+		LastQuestID:             d.LastQuestID,
+		LastAdvID:               d.LastAdvID,
+		LastAssignmentID:        d.LastAssignmentID,
+		LastAPIKeyID:            d.LastAPIKeyID,
+		LastGiverID:             d.LastGiverID,
+		LastLedgerTransactionID: d.LastLedgerTransactionID,
+	}
+	for id, quest := range d.Quests {
+		c.Quests[id] = quest
+	}
+	for id, adv := range d.Adventurers {
+		c.Adventurers[id] = adv
+	}
+	for id, key := range d.APIKeys {
+		c.APIKeys[id] = key
+	}
+	for id, giver := range d.Givers {
+		c.Givers[id] = giver
+	}
+	for id, payout := range d.Payouts {
+		c.Payouts[id] = payout
+	}
+	return c
+}
+
+// Store is a thread-safe in-memory database. Reads run concurrently, writes and
+// transactions are serialized.
+type Store struct {
+	mu     sync.RWMutex
+	writer sync.Mutex
+	data   *Data
+}
+
+func NewStore() *Store {
+	return &Store{data: newData()}
+}
+
+// Read gives fn a consistent view of the data. fn must not modify it.
+func (s *Store) Read(fn func(*Data)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fn(s.data)
+}
+
+// Write runs fn with exclusive access to the data.
+func (s *Store) Write(fn func(*Data) error) error {
+	s.writer.Lock()
+	defer s.writer.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.data)
+}
+
+// Transaction runs fn against a private copy of the data that replaces the
+// store's data only when fn returns nil. Transactions and writes are serialized,
+// so a transaction never overwrites changes it did not see.
+func (s *Store) Transaction(fn func(*Store) error) (err error) {
+	s.writer.Lock()
+	defer s.writer.Unlock()
+
+	s.mu.RLock()
+	tx := &Store{data: s.data.clone()}
+	s.mu.RUnlock()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.data = tx.data
+	s.mu.Unlock()
+	return nil
+}
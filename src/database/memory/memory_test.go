@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	modelAdv "github.com/arfaghifari/guild-board/src/model/adventurer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransaction(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func(*Store) error
+		outLen  int
+		wantErr bool
+	}{
+		{
+			name: "commit on success",
+			fn: func(tx *Store) error {
+				return tx.Write(func(d *Data) error {
+					d.Adventurers[1] = modelAdv.Adventurer{ID: 1}
+					return nil
+				})
+			},
+			outLen:  1,
+			wantErr: false,
+		},
+		{
+			name: "discard on error",
+			fn: func(tx *Store) error {
+				tx.Write(func(d *Data) error {
+					d.Adventurers[1] = modelAdv.Adventurer{ID: 1}
+					return nil
+				})
+				return errors.New("any error")
+			},
+			outLen:  0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStore()
+			err := s.Transaction(tt.fn)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			s.Read(func(d *Data) {
+				assert.Len(t, d.Adventurers, tt.outLen)
+			})
+		})
+	}
+}
+
+func TestTransactionSerialized(t *testing.T) {
+	const workers = 50
+	s := NewStore()
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s.Transaction(func(tx *Store) error {
+				return tx.Write(func(d *Data) error {
+					d.LastAdvID++
+					return nil
+				})
+			})
+		}()
+		go func() {
+			defer wg.Done()
+			s.Write(func(d *Data) error {
+				d.LastQuestID++
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+
+	s.Read(func(d *Data) {
+		assert.Equal(t, int64(workers), d.LastAdvID)
+		assert.Equal(t, int64(workers), d.LastQuestID)
+	})
+}
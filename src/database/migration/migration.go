@@ -0,0 +1,191 @@
+package migration
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/arfaghifari/guild-board/src/database"
+)
+
+//go:embed sql
+var files embed.FS
+
+// dirs holds the migration directory of each dialect under sql/
+var dirs = map[database.Dialect]string{
+	database.Postgres: "sql/postgres",
+	database.SQLite:   "sql/sqlite",
+}
+
+// fileName matches "<version>_<name>.<up|down>.sql", e.g. 0001_create_adventurer.up.sql
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Migrator interface {
+	Up() (int, error)
+	Down() (int, error)
+	Version() (int, error)
+}
+
+type migrator struct {
+	db         *sql.DB
+	dialect    database.Dialect
+	migrations []Migration
+}
+
+func NewMigrator(db *sql.DB, dialect database.Dialect) (Migrator, error) {
+	dir, ok := dirs[dialect]
+	if !ok {
+		return nil, fmt.Errorf("no migrations for dialect %q", dialect)
+	}
+	migrations, err := Load(files, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &migrator{db, dialect, migrations}, nil
+}
+
+// Load reads every up/down pair in dir and returns them ordered by version.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, _ := strconv.Atoi(match[1])
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := []Migration{}
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func (m *migrator) ensureVersionTable() error {
+	query := `CREATE TABLE IF NOT EXISTS schema_version (
+	version    INTEGER PRIMARY KEY,
+	applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`
+	_, err := database.Bind(m.db, m.dialect).Exec(query)
+	return err
+}
+
+// Version returns the latest applied migration, 0 when none has been applied.
+func (m *migrator) Version() (version int, err error) {
+	if err = m.ensureVersionTable(); err != nil {
+		return
+	}
+	query := `SELECT COALESCE(MAX(version), 0) FROM schema_version`
+	err = database.Bind(m.db, m.dialect).QueryRow(query).Scan(&version)
+	return
+}
+
+// Up applies every pending migration in order and returns the resulting version.
+func (m *migrator) Up() (int, error) {
+	current, err := m.Version()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, migration := range m.migrations {
+		if migration.Version <= current {
+			continue
+		}
+		query := `INSERT INTO schema_version(version) VALUES($1)`
+		if err := m.apply(migration.Up, query, migration.Version); err != nil {
+			return current, fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+		}
+		current = migration.Version
+	}
+
+	return current, nil
+}
+
+// Down rolls back the latest applied migration and returns the resulting version.
+func (m *migrator) Down() (int, error) {
+	current, err := m.Version()
+	if err != nil {
+		return 0, err
+	}
+	if current == 0 {
+		return 0, nil
+	}
+
+	previous := 0
+	for i, migration := range m.migrations {
+		if migration.Version != current {
+			continue
+		}
+		if i > 0 {
+			previous = m.migrations[i-1].Version
+		}
+		query := `DELETE FROM schema_version WHERE version = $1`
+		if err := m.apply(migration.Down, query, migration.Version); err != nil {
+			return current, fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+		}
+		return previous, nil
+	}
+
+	return current, fmt.Errorf("migration %d is applied but unknown to this binary", current)
+}
+
+// apply runs the migration script and the schema_version bookkeeping in one transaction.
+func (m *migrator) apply(script, versionQuery string, version int) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(script); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err = database.Bind(tx, m.dialect).Exec(versionQuery, version); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
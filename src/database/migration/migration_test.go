@@ -0,0 +1,349 @@
+package migration
+
+import (
+	"database/sql"
+	"log"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arfaghifari/guild-board/src/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func NewMock() (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	return db, mock
+}
+
+var bulkMigration = []Migration{
+	{
+		Version: 1,
+		Name:    "create_adventurer",
+		Up:      "CREATE TABLE adventurer (id SERIAL PRIMARY KEY);",
+		Down:    "DROP TABLE adventurer;",
+	},
+	{
+		Version: 2,
+		Name:    "create_quest",
+		Up:      "CREATE TABLE quest (quest_id SERIAL PRIMARY KEY);",
+		Down:    "DROP TABLE quest;",
+	},
+}
+
+var (
+	createVersionQuery = regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_version")
+	versionQuery       = regexp.QuoteMeta("SELECT COALESCE(MAX(version), 0) FROM schema_version")
+	insertVersionQuery = regexp.QuoteMeta("INSERT INTO schema_version(version) VALUES($1)")
+	deleteVersionQuery = regexp.QuoteMeta("DELETE FROM schema_version WHERE version = $1")
+)
+
+func TestNewMigrator(t *testing.T) {
+	db, _ := NewMock()
+	defer db.Close()
+	for _, dialect := range []database.Dialect{database.Postgres, database.SQLite} {
+		res, err := NewMigrator(db, dialect)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	}
+
+	res, err := NewMigrator(db, database.Dialect("oracle"))
+	assert.Error(t, err)
+	assert.Nil(t, res)
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		fsys     fstest.MapFS
+		outLen   int
+		outFirst string
+		wantErr  bool
+	}{
+		{
+			name: "success load ordered migrations",
+			fsys: fstest.MapFS{
+				"sql/0002_create_quest.up.sql":        {Data: []byte(bulkMigration[1].Up)},
+				"sql/0002_create_quest.down.sql":      {Data: []byte(bulkMigration[1].Down)},
+				"sql/0001_create_adventurer.up.sql":   {Data: []byte(bulkMigration[0].Up)},
+				"sql/0001_create_adventurer.down.sql": {Data: []byte(bulkMigration[0].Down)},
+				"sql/README.md":                       {Data: []byte("ignored")},
+			},
+			outLen:   2,
+			outFirst: "create_adventurer",
+			wantErr:  false,
+		},
+		{
+			name: "missing down migration",
+			fsys: fstest.MapFS{
+				"sql/0001_create_adventurer.up.sql": {Data: []byte(bulkMigration[0].Up)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "conflicting migration names",
+			fsys: fstest.MapFS{
+				"sql/0001_create_adventurer.up.sql": {Data: []byte(bulkMigration[0].Up)},
+				"sql/0001_create_quest.down.sql":    {Data: []byte(bulkMigration[1].Down)},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "missing directory",
+			fsys:    fstest.MapFS{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := Load(tt.fsys, "sql")
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+				return
+			}
+			assert.NoError(t, err, tt.name)
+			assert.Len(t, res, tt.outLen)
+			assert.Equal(t, tt.outFirst, res[0].Name)
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	versions := map[database.Dialect][]int{}
+	for dialect, dir := range dirs {
+		res, err := Load(files, dir)
+		assert.NoError(t, err, dialect)
+		for i, m := range res {
+			assert.Equal(t, i+1, m.Version, "migration versions must be contiguous")
+			versions[dialect] = append(versions[dialect], m.Version)
+		}
+	}
+	assert.NotEmpty(t, versions[database.Postgres])
+	assert.Equal(t, versions[database.Postgres], versions[database.SQLite], "every dialect must ship the same migrations")
+}
+
+func TestSQLiteMigrations(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "guild.db")+"?_foreign_keys=on&_sync=OFF")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	m, err := NewMigrator(db, database.SQLite)
+	assert.NoError(t, err)
+	migrations, _ := Load(files, dirs[database.SQLite])
+	latest := migrations[len(migrations)-1].Version
+
+	version, err := m.Up()
+	assert.NoError(t, err)
+	assert.Equal(t, latest, version)
+	_, err = db.Exec("INSERT INTO adventurer(name, rank) VALUES('andi', 11)")
+	assert.NoError(t, err)
+
+	for version > 0 {
+		previous := version
+		version, err = m.Down()
+		assert.NoError(t, err)
+		assert.Less(t, version, previous)
+	}
+	version, err = m.Up()
+	assert.NoError(t, err)
+	assert.Equal(t, latest, version)
+}
+
+func TestVersion(t *testing.T) {
+	db, mock := NewMock()
+	defer db.Close()
+	tests := []struct {
+		name       string
+		mock       func()
+		outVersion int
+		wantErr    bool
+	}{
+		{
+			name: "success get version",
+			mock: func() {
+				mock.ExpectExec(createVersionQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(versionQuery).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2))
+			},
+			outVersion: 2,
+			wantErr:    false,
+		},
+		{
+			name: "failed create version table",
+			mock: func() {
+				mock.ExpectExec(createVersionQuery).WillReturnError(sql.ErrConnDone)
+			},
+			outVersion: 0,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &migrator{
+				db:         db,
+				migrations: bulkMigration,
+			}
+			tt.mock()
+			res, err := m.Version()
+			assert.Equal(t, tt.outVersion, res)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUp(t *testing.T) {
+	db, mock := NewMock()
+	defer db.Close()
+	tests := []struct {
+		name       string
+		mock       func()
+		outVersion int
+		wantErr    bool
+	}{
+		{
+			name: "success apply pending migrations",
+			mock: func() {
+				mock.ExpectExec(createVersionQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(versionQuery).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(bulkMigration[1].Up)).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(insertVersionQuery).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			outVersion: 2,
+			wantErr:    false,
+		},
+		{
+			name: "already up to date",
+			mock: func() {
+				mock.ExpectExec(createVersionQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(versionQuery).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2))
+			},
+			outVersion: 2,
+			wantErr:    false,
+		},
+		{
+			name: "failed migration rolls back",
+			mock: func() {
+				mock.ExpectExec(createVersionQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(versionQuery).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(0))
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(bulkMigration[0].Up)).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(insertVersionQuery).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(bulkMigration[1].Up)).WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			outVersion: 1,
+			wantErr:    true,
+		},
+		{
+			name: "failed get version",
+			mock: func() {
+				mock.ExpectExec(createVersionQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(versionQuery).WillReturnError(sql.ErrConnDone)
+			},
+			outVersion: 0,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &migrator{
+				db:         db,
+				migrations: bulkMigration,
+			}
+			tt.mock()
+			res, err := m.Up()
+			assert.Equal(t, tt.outVersion, res)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDown(t *testing.T) {
+	db, mock := NewMock()
+	defer db.Close()
+	tests := []struct {
+		name       string
+		mock       func()
+		outVersion int
+		wantErr    bool
+	}{
+		{
+			name: "success roll back latest migration",
+			mock: func() {
+				mock.ExpectExec(createVersionQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(versionQuery).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2))
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(bulkMigration[1].Down)).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(deleteVersionQuery).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			outVersion: 1,
+			wantErr:    false,
+		},
+		{
+			name: "nothing to roll back",
+			mock: func() {
+				mock.ExpectExec(createVersionQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(versionQuery).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(0))
+			},
+			outVersion: 0,
+			wantErr:    false,
+		},
+		{
+			name: "unknown applied version",
+			mock: func() {
+				mock.ExpectExec(createVersionQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(versionQuery).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(7))
+			},
+			outVersion: 7,
+			wantErr:    true,
+		},
+		{
+			name: "failed begin transaction",
+			mock: func() {
+				mock.ExpectExec(createVersionQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(versionQuery).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+				mock.ExpectBegin().WillReturnError(sql.ErrConnDone)
+			},
+			outVersion: 1,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &migrator{
+				db:         db,
+				migrations: bulkMigration,
+			}
+			tt.mock()
+			res, err := m.Down()
+			assert.Equal(t, tt.outVersion, res)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,10 @@
+CREATE TABLE quest (
+	quest_id      SERIAL PRIMARY KEY,
+	name          VARCHAR(255) NOT NULL,
+	description   TEXT NOT NULL DEFAULT '',
+	minimum_rank  INTEGER NOT NULL,
+	reward_number INTEGER NOT NULL,
+	status        INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX quest_status_idx ON quest (status);
@@ -0,0 +1,7 @@
+ALTER TABLE adventurer DROP COLUMN failed_quest;
+
+DROP INDEX quest_deadline_idx;
+
+ALTER TABLE quest DROP COLUMN taken_at;
+ALTER TABLE quest DROP COLUMN deadline;
+ALTER TABLE quest DROP COLUMN created_at;
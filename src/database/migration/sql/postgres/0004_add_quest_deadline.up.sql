@@ -0,0 +1,7 @@
+ALTER TABLE quest ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE quest ADD COLUMN deadline TIMESTAMPTZ;
+ALTER TABLE quest ADD COLUMN taken_at TIMESTAMPTZ;
+
+CREATE INDEX quest_deadline_idx ON quest (deadline);
+
+ALTER TABLE adventurer ADD COLUMN failed_quest INTEGER NOT NULL DEFAULT 0;
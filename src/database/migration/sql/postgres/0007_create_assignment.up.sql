@@ -0,0 +1,19 @@
+CREATE TABLE assignment (
+	id          SERIAL PRIMARY KEY,
+	quest_id    INTEGER NOT NULL REFERENCES quest (quest_id) ON DELETE CASCADE,
+	adv_id      INTEGER NOT NULL REFERENCES adventurer (id) ON DELETE CASCADE,
+	taken_at    TIMESTAMPTZ NOT NULL,
+	finished_at TIMESTAMPTZ,
+	outcome     VARCHAR(16) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX assignment_quest_id_idx ON assignment (quest_id);
+CREATE INDEX assignment_adv_id_idx ON assignment (adv_id);
+
+-- parties holding a quest today start their history when the quest was taken,
+-- or created if they are still gathering members
+INSERT INTO assignment(quest_id, adv_id, taken_at)
+SELECT t.quest_id, t.adv_id, COALESCE(q.taken_at, q.created_at)
+FROM taken_by t
+JOIN quest q ON q.quest_id = t.quest_id
+WHERE q.status IN (0, 1);
@@ -0,0 +1,8 @@
+CREATE TABLE api_key (
+	id         SERIAL PRIMARY KEY,
+	name       VARCHAR(255) NOT NULL,
+	role       VARCHAR(16) NOT NULL,
+	adv_id     INTEGER REFERENCES adventurer (id) ON DELETE CASCADE,
+	key_hash   CHAR(64) NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,5 @@
+DROP INDEX quest_created_by_idx;
+
+ALTER TABLE api_key DROP COLUMN giver_id;
+ALTER TABLE quest DROP COLUMN created_by;
+DROP TABLE IF EXISTS quest_giver;
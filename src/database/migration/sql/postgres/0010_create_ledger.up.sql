@@ -0,0 +1,17 @@
+-- quest_id is kept without a reference so the ledger outlives deleted quests
+CREATE TABLE ledger_transaction (
+	id         SERIAL PRIMARY KEY,
+	quest_id   INTEGER,
+	kind       VARCHAR(16) NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE ledger_entry (
+	id             SERIAL PRIMARY KEY,
+	transaction_id INTEGER NOT NULL REFERENCES ledger_transaction (id) ON DELETE CASCADE,
+	account        VARCHAR(64) NOT NULL,
+	amount         BIGINT NOT NULL
+);
+
+CREATE INDEX ledger_transaction_quest_id_idx ON ledger_transaction (quest_id);
+CREATE INDEX ledger_entry_account_idx ON ledger_entry (account);
@@ -0,0 +1,9 @@
+-- quest_id is kept without a reference, like the ledger
+CREATE TABLE quest_payout (
+	quest_id       INTEGER PRIMARY KEY,
+	gross          BIGINT NOT NULL,
+	guild_fee      BIGINT NOT NULL,
+	adventurer_net BIGINT NOT NULL,
+	fee_percent    INTEGER NOT NULL,
+	created_at     TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,5 @@
+DELETE FROM assignment WHERE quest_id NOT IN (SELECT quest_id FROM quest);
+
+ALTER TABLE assignment
+	ADD CONSTRAINT assignment_quest_id_fkey
+	FOREIGN KEY (quest_id) REFERENCES quest (quest_id) ON DELETE CASCADE;
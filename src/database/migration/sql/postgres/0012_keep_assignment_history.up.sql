@@ -0,0 +1,2 @@
+-- quest_id loses its reference so the history outlives deleted quests
+ALTER TABLE assignment DROP CONSTRAINT assignment_quest_id_fkey;
@@ -0,0 +1,6 @@
+CREATE TABLE adventurer (
+	id              INTEGER PRIMARY KEY AUTOINCREMENT,
+	name            VARCHAR(255) NOT NULL,
+	rank            INTEGER NOT NULL,
+	completed_quest INTEGER NOT NULL DEFAULT 0
+);
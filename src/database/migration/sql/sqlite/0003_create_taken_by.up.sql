@@ -0,0 +1,7 @@
+CREATE TABLE taken_by (
+	quest_id INTEGER NOT NULL REFERENCES quest (quest_id) ON DELETE CASCADE,
+	adv_id   INTEGER NOT NULL REFERENCES adventurer (id) ON DELETE CASCADE,
+	PRIMARY KEY (quest_id, adv_id)
+);
+
+CREATE INDEX taken_by_adv_id_idx ON taken_by (adv_id);
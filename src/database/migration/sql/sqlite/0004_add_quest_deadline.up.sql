@@ -0,0 +1,10 @@
+-- SQLite cannot add a column with a non-constant default, so existing rows are
+-- backfilled and new rows always get created_at from the application.
+ALTER TABLE quest ADD COLUMN created_at TIMESTAMP;
+UPDATE quest SET created_at = CURRENT_TIMESTAMP;
+ALTER TABLE quest ADD COLUMN deadline TIMESTAMP;
+ALTER TABLE quest ADD COLUMN taken_at TIMESTAMP;
+
+CREATE INDEX quest_deadline_idx ON quest (deadline);
+
+ALTER TABLE adventurer ADD COLUMN failed_quest INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+ALTER TABLE taken_by DROP COLUMN reward;
+
+ALTER TABLE quest DROP COLUMN members;
+ALTER TABLE quest DROP COLUMN rank_rule;
+ALTER TABLE quest DROP COLUMN max_members;
+ALTER TABLE quest DROP COLUMN min_members;
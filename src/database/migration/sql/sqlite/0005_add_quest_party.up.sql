@@ -0,0 +1,7 @@
+ALTER TABLE quest ADD COLUMN min_members INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE quest ADD COLUMN max_members INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE quest ADD COLUMN rank_rule TEXT NOT NULL DEFAULT 'all';
+ALTER TABLE quest ADD COLUMN members INTEGER NOT NULL DEFAULT 0;
+UPDATE quest SET members = (SELECT COUNT(*) FROM taken_by WHERE taken_by.quest_id = quest.quest_id);
+
+ALTER TABLE taken_by ADD COLUMN reward INTEGER NOT NULL DEFAULT 0;
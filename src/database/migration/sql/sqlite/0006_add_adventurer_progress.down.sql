@@ -0,0 +1,2 @@
+ALTER TABLE adventurer DROP COLUMN failed_streak;
+ALTER TABLE adventurer DROP COLUMN rank_points;
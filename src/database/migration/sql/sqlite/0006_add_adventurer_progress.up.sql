@@ -0,0 +1,2 @@
+ALTER TABLE adventurer ADD COLUMN rank_points INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE adventurer ADD COLUMN failed_streak INTEGER NOT NULL DEFAULT 0;
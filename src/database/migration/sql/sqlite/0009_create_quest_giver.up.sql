@@ -0,0 +1,10 @@
+CREATE TABLE quest_giver (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	name       VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP NOT NULL
+);
+
+ALTER TABLE quest ADD COLUMN created_by INTEGER REFERENCES quest_giver (id);
+ALTER TABLE api_key ADD COLUMN giver_id INTEGER REFERENCES quest_giver (id) ON DELETE CASCADE;
+
+CREATE INDEX quest_created_by_idx ON quest (created_by);
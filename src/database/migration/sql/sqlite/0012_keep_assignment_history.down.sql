@@ -0,0 +1,18 @@
+CREATE TABLE assignment_history (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	quest_id    INTEGER NOT NULL REFERENCES quest (quest_id) ON DELETE CASCADE,
+	adv_id      INTEGER NOT NULL REFERENCES adventurer (id) ON DELETE CASCADE,
+	taken_at    TIMESTAMP NOT NULL,
+	finished_at TIMESTAMP,
+	outcome     VARCHAR(16) NOT NULL DEFAULT ''
+);
+
+INSERT INTO assignment_history(id, quest_id, adv_id, taken_at, finished_at, outcome)
+SELECT id, quest_id, adv_id, taken_at, finished_at, outcome FROM assignment
+WHERE quest_id IN (SELECT quest_id FROM quest);
+
+DROP TABLE assignment;
+ALTER TABLE assignment_history RENAME TO assignment;
+
+CREATE INDEX assignment_quest_id_idx ON assignment (quest_id);
+CREATE INDEX assignment_adv_id_idx ON assignment (adv_id);
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Conditions builds a WHERE clause with Postgres $n placeholders, numbering
+// the arguments in the order they are added.
+type Conditions struct {
+	conds []string
+	args  []interface{}
+}
+
+// Add appends cond, replacing each %s with the placeholder of the next arg.
+func (c *Conditions) Add(cond string, args ...interface{}) {
+	marks := make([]interface{}, len(args))
+	for i, arg := range args {
+		c.args = append(c.args, arg)
+		marks[i] = "$" + strconv.Itoa(len(c.args))
+	}
+	c.conds = append(c.conds, fmt.Sprintf(cond, marks...))
+}
+
+// In appends "column IN (...)" with one placeholder per value.
+func (c *Conditions) In(column string, values ...interface{}) {
+	c.Add(column+" IN ("+strings.TrimSuffix(strings.Repeat("%s, ", len(values)), ", ")+")", values...)
+}
+
+// Where is the clause to append to a query, empty without conditions.
+func (c *Conditions) Where() string {
+	if len(c.conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(c.conds, " AND ")
+}
+
+// Args returns the arguments of the clause, followed by extra ones.
+func (c *Conditions) Args(extra ...interface{}) []interface{} {
+	return append(append([]interface{}{}, c.args...), extra...)
+}
+
+// Next is the placeholder of the n-th argument added after the clause's own.
+func (c *Conditions) Next(n int) string {
+	return "$" + strconv.Itoa(len(c.args)+n)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike quotes the wildcards of s for a LIKE pattern using ESCAPE '\'.
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
@@ -0,0 +1,27 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditions(t *testing.T) {
+	c := &Conditions{}
+	assert.Equal(t, "", c.Where())
+	assert.Equal(t, []interface{}{}, c.Args())
+	assert.Equal(t, "$1", c.Next(1))
+
+	c.In("status", 0, 1)
+	c.Add("rank BETWEEN %s AND %s", 11, 13)
+	c.Add("deleted = FALSE")
+	assert.Equal(t, " WHERE status IN ($1, $2) AND rank BETWEEN $3 AND $4 AND deleted = FALSE", c.Where())
+	assert.Equal(t, []interface{}{0, 1, 11, 13, 20, 40}, c.Args(20, 40))
+	assert.Equal(t, []interface{}{0, 1, 11, 13}, c.Args(), "extra args are not kept")
+	assert.Equal(t, "$6", c.Next(2))
+}
+
+func TestEscapeLike(t *testing.T) {
+	assert.Equal(t, "andi", EscapeLike("andi"))
+	assert.Equal(t, `50\%\_off\\`, EscapeLike(`50%_off\`))
+}
@@ -1,28 +1,36 @@
 package adventurer
 
 import (
-	"encoding/json"
-	"log"
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/config"
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/handlers/http/auth"
+	"github.com/arfaghifari/guild-board/src/handlers/http/request"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	"github.com/arfaghifari/guild-board/src/logger"
 	model "github.com/arfaghifari/guild-board/src/model/adventurer"
+	"github.com/arfaghifari/guild-board/src/policy"
 	usecase "github.com/arfaghifari/guild-board/src/usecase/adventurer"
+	"github.com/arfaghifari/guild-board/src/validation"
 )
 
-type Header struct {
-	Error      string `json:"error_code"`
-	StatusCode int    `json:"status_code"`
-}
-
 type MessageResponse struct {
-	Header `json:"header"`
-	Data   SuccesMessage `json:"data"`
+	response.Header `json:"header"`
+	Data            SuccesMessage `json:"data"`
 }
 
 type AdvResponse struct {
-	Header `json:"header"`
-	Data   model.Adventurer `json:"data"`
+	response.Header `json:"header"`
+	Data            model.Adventurer `json:"data"`
+}
+
+type ProgressResponse struct {
+	response.Header `json:"header"`
+	Data            model.Progress `json:"data"`
 }
 
 type SuccesMessage struct {
@@ -30,136 +38,113 @@ type SuccesMessage struct {
 }
 
 type Handlers interface {
-	CreateAdventurer(http.ResponseWriter, *http.Request)
-	UpdateAdventurerRank(http.ResponseWriter, *http.Request)
-	GetAdventurer(http.ResponseWriter, *http.Request)
+	CreateAdventurer(http.ResponseWriter, *http.Request) (interface{}, error)
+	UpdateAdventurerRank(http.ResponseWriter, *http.Request) (interface{}, error)
+	GetAdventurer(http.ResponseWriter, *http.Request) (interface{}, error)
+	GetProgress(http.ResponseWriter, *http.Request) (interface{}, error)
+	GetAdventurerByID(http.ResponseWriter, *http.Request) (interface{}, error)
+	PatchAdventurer(http.ResponseWriter, *http.Request) (interface{}, error)
+	ListAdventurers(http.ResponseWriter, *http.Request) (interface{}, error)
 }
 
 type handlers struct {
-	usecase usecase.Usecase
+	usecase      usecase.Usecase
+	logger       logger.Logger
+	maxBodyBytes int64
 }
 
-func NewHandlers() (Handlers, error) {
-	usecase, _ := usecase.NewUsecase()
+var errMissingDependency = errors.New("adventurer handlers need a usecase and a logger")
+
+func NewHandlers(usecase usecase.Usecase, logger logger.Logger, cfg config.HTTP) (Handlers, error) {
+	if usecase == nil || logger == nil {
+		return nil, errMissingDependency
+	}
+
+	return &handlers{usecase, logger, cfg.MaxBodyBytes}, nil
+}
 
-	return &handlers{usecase}, nil
+// decode reads the JSON request body into v, bounded by the configured body size.
+func (h *handlers) decode(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	return request.Decode(w, r, h.maxBodyBytes, v)
 }
 
-func (h *handlers) CreateAdventurer(w http.ResponseWriter, r *http.Request) {
-	var (
-		statusCode = http.StatusBadRequest
-		resp       AdvResponse
-		adventurer model.Adventurer
-	)
-	defer func() {
-		w.Header().Set("Content-Type", "application/json")
-		resp.StatusCode = statusCode
-		responseWriter, err := json.Marshal(resp)
-		if err != nil {
-			log.Fatal("Failed build response")
-		}
-		if statusCode == http.StatusOK {
-			w.Write(responseWriter)
-		} else {
-			http.Error(w, string(responseWriter), statusCode)
-		}
-	}()
-	resp.Data = model.Adventurer{}
-	if err := json.NewDecoder(r.Body).Decode(&adventurer); err != nil {
-		resp.Header.Error = err.Error()
-		return
+// Rules of the fields clients send about adventurers, Required aside.
+var (
+	validID   = validation.Min(1)
+	validName = validation.MaxLength(constant.MaxNameLength)
+	validRank = validation.Between(constant.MinRank, constant.MaxRank)
+)
+
+func (h *handlers) CreateAdventurer(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	if err := auth.Authorize(r.Context(), policy.RegisterAdventurer); err != nil {
+		return model.Adventurer{}, err
 	}
-	if adventurer.Name == "" || adventurer.Rank <= 0 {
-		resp.Header.Error = "name and rank are required and must be valid"
-		return
+	var adventurer model.Adventurer
+	if err := h.decode(w, r, &adventurer); err != nil {
+		return model.Adventurer{}, err
+	}
+	if err := validation.Validate(
+		validation.Field("name", adventurer.Name, validation.Required, validName),
+		validation.Field("rank", adventurer.Rank, validation.Required, validRank),
+	); err != nil {
+		return model.Adventurer{}, err
 	}
 
 	res, err := h.usecase.CreateAdventurer(adventurer)
 	if err != nil {
-		statusCode = http.StatusInternalServerError
-		resp.Header.Error = err.Error()
-		return
+		return model.Adventurer{}, err
 	}
-	statusCode = http.StatusOK
-	resp.Data = res
+	return res, nil
 }
 
-func (h *handlers) UpdateAdventurerRank(w http.ResponseWriter, r *http.Request) {
-	var (
-		statusCode = http.StatusBadRequest
-		resp       MessageResponse
-		adventurer model.Adventurer
-	)
-	defer func() {
-		w.Header().Set("Content-Type", "application/json")
-		resp.StatusCode = statusCode
-		responseWriter, err := json.Marshal(resp)
-		if err != nil {
-			log.Fatal("Failed build response")
-		}
-		if statusCode == http.StatusOK {
-			w.Write(responseWriter)
-		} else {
-			http.Error(w, string(responseWriter), statusCode)
-		}
-	}()
-
-	if err := json.NewDecoder(r.Body).Decode(&adventurer); err != nil {
-		resp.Header.Error = err.Error()
-		return
+func (h *handlers) UpdateAdventurerRank(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	if err := auth.Authorize(r.Context(), policy.RankAdventurer); err != nil {
+		return SuccesMessage{}, err
 	}
-
-	if adventurer.ID <= 0 || adventurer.Rank <= 0 {
-		resp.Header.Error = "id and rank are required and must be valid"
-		return
+	var adventurer model.Adventurer
+	if err := h.decode(w, r, &adventurer); err != nil {
+		return SuccesMessage{}, err
+	}
+	if err := validation.Validate(
+		validation.Field("id", adventurer.ID, validation.Required, validID),
+		validation.Field("rank", adventurer.Rank, validation.Required, validRank),
+	); err != nil {
+		return SuccesMessage{}, err
 	}
 
 	err := h.usecase.UpdateAdventurerRank(adventurer)
 
 	if err != nil {
-		statusCode = http.StatusInternalServerError
-		resp.Header.Error = err.Error()
-		return
+		return SuccesMessage{}, err
 	}
-	statusCode = http.StatusOK
-	resp.Data.Success = true
+	return SuccesMessage{Success: true}, nil
 }
 
-func (h *handlers) GetAdventurer(w http.ResponseWriter, r *http.Request) {
-	var (
-		statusCode = http.StatusBadRequest
-		resp       AdvResponse
-	)
-	defer func() {
-		w.Header().Set("Content-Type", "application/json")
-		resp.StatusCode = statusCode
-		responseWriter, err := json.Marshal(resp)
-		if err != nil {
-			log.Fatal("Failed build response")
-		}
-		if statusCode == http.StatusOK {
-			w.Write(responseWriter)
-		} else {
-			http.Error(w, string(responseWriter), statusCode)
-		}
-	}()
-	resp.Data = model.Adventurer{}
+func (h *handlers) GetAdventurer(w http.ResponseWriter, r *http.Request) (interface{}, error) {
 	adv_id, err := strconv.Atoi(r.URL.Query().Get("adv_id"))
 	if err != nil {
-		resp.Header.Error = err.Error()
-		return
+		return model.Adventurer{}, apperror.NewBadRequest(err.Error())
 	}
 	if adv_id <= 0 {
-		resp.Header.Error = "Invalid id"
-		return
+		return model.Adventurer{}, apperror.NewBadRequest("Invalid id")
 	}
 
 	res, err := h.usecase.GetAdventurer(int64(adv_id))
 	if err != nil {
-		statusCode = http.StatusInternalServerError
-		resp.Header.Error = err.Error()
-		return
+		return model.Adventurer{}, err
+	}
+	return res, nil
+}
+
+func (h *handlers) GetProgress(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	adv_id, ok := request.PathID(r)
+	if !ok {
+		return model.Progress{}, apperror.NewBadRequest("adventurer id must be valid")
+	}
+
+	res, err := h.usecase.GetProgress(adv_id)
+	if err != nil {
+		return model.Progress{}, err
 	}
-	statusCode = http.StatusOK
-	resp.Data = res
+	return res, nil
 }
@@ -64,6 +64,37 @@ func (mr *MockUsecaseMockRecorder) GetAdventurer(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdventurer", reflect.TypeOf((*MockUsecase)(nil).GetAdventurer), arg0)
 }
 
+// GetProgress mocks base method.
+func (m *MockUsecase) GetProgress(arg0 int64) (adventurer.Progress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProgress", arg0)
+	ret0, _ := ret[0].(adventurer.Progress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProgress indicates an expected call of GetProgress.
+func (mr *MockUsecaseMockRecorder) GetProgress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgress", reflect.TypeOf((*MockUsecase)(nil).GetProgress), arg0)
+}
+
+// ListAdventurers mocks base method.
+func (m *MockUsecase) ListAdventurers(arg0 adventurer.AdventurerFilter) ([]adventurer.Adventurer, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAdventurers", arg0)
+	ret0, _ := ret[0].([]adventurer.Adventurer)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAdventurers indicates an expected call of ListAdventurers.
+func (mr *MockUsecaseMockRecorder) ListAdventurers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdventurers", reflect.TypeOf((*MockUsecase)(nil).ListAdventurers), arg0)
+}
+
 // UpdateAdventurerRank mocks base method.
 func (m *MockUsecase) UpdateAdventurerRank(arg0 adventurer.Adventurer) error {
 	m.ctrl.T.Helper()
@@ -76,4 +107,4 @@ func (m *MockUsecase) UpdateAdventurerRank(arg0 adventurer.Adventurer) error {
 func (mr *MockUsecaseMockRecorder) UpdateAdventurerRank(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdventurerRank", reflect.TypeOf((*MockUsecase)(nil).UpdateAdventurerRank), arg0)
-}
\ No newline at end of file
+}
@@ -9,12 +9,24 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/arfaghifari/guild-board/src/config"
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/handlers/http/auth"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	"github.com/arfaghifari/guild-board/src/logger"
 	model "github.com/arfaghifari/guild-board/src/model/adventurer"
+	modelAuth "github.com/arfaghifari/guild-board/src/model/auth"
+	advUsecase "github.com/arfaghifari/guild-board/src/usecase/adventurer"
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 )
 
+var testLogger, _ = logger.NewLogger("error")
+
+// staff registers and ranks adventurers; anyone may read them.
+var staff = modelAuth.Principal{KeyID: 1, Name: "front desk", Role: constant.RoleStaff}
+
 var adv = model.Adventurer{
 	ID:             1,
 	Name:           "andi",
@@ -33,9 +45,21 @@ var adv2 = model.Adventurer{
 }
 
 func TestNewHandlers(t *testing.T) {
-	res, err := NewHandlers()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	appLogger, _ := logger.NewLogger("info")
+
+	res, err := NewHandlers(NewMockUsecase(mockCtrl), appLogger, config.Default().HTTP)
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
+
+	res, err = NewHandlers(nil, appLogger, config.Default().HTTP)
+	assert.Error(t, err)
+	assert.Nil(t, res)
+
+	res, err = NewHandlers(NewMockUsecase(mockCtrl), nil, config.Default().HTTP)
+	assert.Error(t, err)
+	assert.Nil(t, res)
 }
 
 func TestCreateAdventurer(t *testing.T) {
@@ -108,7 +132,7 @@ func TestCreateAdventurer(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -125,7 +149,7 @@ func TestCreateAdventurer(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -142,7 +166,7 @@ func TestCreateAdventurer(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -159,7 +183,7 @@ func TestCreateAdventurer(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -182,12 +206,13 @@ func TestCreateAdventurer(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx := context.Background()
+			ctx := auth.WithPrincipal(context.Background(), staff)
 			router := mux.NewRouter()
 			h := &handlers{
 				usecase: tt.fields.u,
+				logger:  testLogger,
 			}
-			router.HandleFunc("/adventurer", h.CreateAdventurer).Methods(http.MethodPost)
+			router.HandleFunc("/adventurer", response.Handle(testLogger, h.CreateAdventurer)).Methods(http.MethodPost)
 			recorder := httptest.NewRecorder()
 			request, _ := http.NewRequest("POST", "/adventurer", strings.NewReader(tt.req.body))
 			request = request.WithContext(ctx)
@@ -276,7 +301,7 @@ func TestUpdateAdventureRank(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -293,7 +318,7 @@ func TestUpdateAdventureRank(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -310,7 +335,7 @@ func TestUpdateAdventureRank(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -327,7 +352,7 @@ func TestUpdateAdventureRank(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -350,12 +375,13 @@ func TestUpdateAdventureRank(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx := context.Background()
+			ctx := auth.WithPrincipal(context.Background(), staff)
 			router := mux.NewRouter()
 			h := &handlers{
 				usecase: tt.fields.u,
+				logger:  testLogger,
 			}
-			router.HandleFunc("/adventurer-rank", h.UpdateAdventurerRank).Methods(http.MethodPatch)
+			router.HandleFunc("/adventurer-rank", response.Handle(testLogger, h.UpdateAdventurerRank)).Methods(http.MethodPatch)
 			recorder := httptest.NewRecorder()
 			request, _ := http.NewRequest("PATCH", "/adventurer-rank", strings.NewReader(tt.req.body))
 			request = request.WithContext(ctx)
@@ -491,8 +517,9 @@ func TestGetAdventure(t *testing.T) {
 			router := mux.NewRouter()
 			h := &handlers{
 				usecase: tt.fields.u,
+				logger:  testLogger,
 			}
-			router.HandleFunc("/adventurer", h.GetAdventurer).Methods(http.MethodGet)
+			router.HandleFunc("/adventurer", response.Handle(testLogger, h.GetAdventurer)).Methods(http.MethodGet)
 			recorder := httptest.NewRecorder()
 			request, _ := http.NewRequest("GET", "/adventurer", strings.NewReader(``))
 			if tt.req.is {
@@ -515,3 +542,136 @@ func TestGetAdventure(t *testing.T) {
 		})
 	}
 }
+
+func TestGetProgress(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	progress := model.Progress{
+		AdventurerID:       adv.ID,
+		Rank:               11,
+		NextRank:           12,
+		RankPoints:         4,
+		PointsToNextRank:   6,
+		FailuresToDemotion: 3,
+	}
+	type fields struct {
+		u *MockUsecase
+	}
+	type responses struct {
+		body model.Progress
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		path           string
+		resp           responses
+		mock           func(*MockUsecase)
+		wantStatusCode int
+		wantErr        bool
+	}{
+		{
+			name: "success get progress",
+			fields: fields{
+				u: NewMockUsecase(mockCtrl),
+			},
+			path: "/adventurer/1/progress",
+			resp: responses{
+				body: progress,
+			},
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetProgress(adv.ID).Return(progress, nil).Times(1)
+			},
+			wantStatusCode: http.StatusOK,
+			wantErr:        false,
+		},
+		{
+			name: "adventurer not found",
+			fields: fields{
+				u: NewMockUsecase(mockCtrl),
+			},
+			path: "/adventurer/1/progress",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetProgress(adv.ID).Return(model.Progress{}, advUsecase.ErrAdventurerNotFound).Times(1)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name: "error at layer usecase",
+			fields: fields{
+				u: NewMockUsecase(mockCtrl),
+			},
+			path: "/adventurer/1/progress",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetProgress(adv.ID).Return(model.Progress{}, errors.New("any error")).Times(1)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+		{
+			name: "id not valid",
+			fields: fields{
+				u: NewMockUsecase(mockCtrl),
+			},
+			path: "/adventurer/-1/progress",
+			mock: func(usecase *MockUsecase) {
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := mux.NewRouter()
+			h := &handlers{
+				usecase: tt.fields.u,
+				logger:  testLogger,
+			}
+			router.HandleFunc("/adventurer/{id}/progress", response.Handle(testLogger, h.GetProgress)).Methods(http.MethodGet)
+			recorder := httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", tt.path, strings.NewReader(``))
+			tt.mock(tt.fields.u)
+			router.ServeHTTP(recorder, request)
+			var resp ProgressResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code, "error code")
+			assert.Equal(t, tt.resp.body, resp.Data)
+			if tt.wantErr {
+				assert.NotEqual(t, "", resp.Header.Error, "error message")
+			} else {
+				assert.Equal(t, "", resp.Header.Error, "error message")
+			}
+		})
+	}
+}
+
+func TestPermissions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	andi := modelAuth.Principal{KeyID: 2, Name: "andi", Role: constant.RoleAdventurer, AdventurerID: 1}
+	giver := modelAuth.Principal{KeyID: 3, Name: "dewi", Role: constant.RoleGiver}
+	h := &handlers{usecase: NewMockUsecase(mockCtrl), logger: testLogger}
+	tests := []struct {
+		name      string
+		handler   response.Handler
+		principal modelAuth.Principal
+		body      string
+	}{
+		{name: "adventurer registers an adventurer", handler: h.CreateAdventurer, principal: andi, body: `{"name":"budi","rank":11}`},
+		{name: "quest giver registers an adventurer", handler: h.CreateAdventurer, principal: giver, body: `{"name":"budi","rank":11}`},
+		{name: "adventurer ranks itself up", handler: h.UpdateAdventurerRank, principal: andi, body: `{"adv_id":1,"rank":100}`},
+		{name: "quest giver ranks an adventurer", handler: h.PatchAdventurer, principal: giver, body: `{"rank":12}`},
+		{name: "anonymous ranks an adventurer", handler: h.PatchAdventurer, body: `{"rank":12}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serveV2(tt.handler, tt.principal, http.MethodPost, "/adventurers/{id}", "/adventurers/1", tt.body)
+			var resp MessageResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, http.StatusForbidden, recorder.Code)
+			assert.Equal(t, "permission_denied", resp.Header.Error)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package adventurer
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/handlers/http/request"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	model "github.com/arfaghifari/guild-board/src/model/adventurer"
+)
+
+// TotalCountHeader carries the number of adventurers matching a listing across all pages.
+const TotalCountHeader = "X-Total-Count"
+
+type AdvListResponse struct {
+	response.Header `json:"header"`
+	Data            []model.Adventurer `json:"data"`
+}
+
+// ListAdventurers serves a page of adventurers whose name starts with the name
+// query parameter and whose rank is within min_rank and max_rank, ordered by
+// sort, prefixed with "-" to sort descending.
+func (h *handlers) ListAdventurers(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	filter, err := parseFilter(r.URL.Query())
+	if err != nil {
+		return []model.Adventurer{}, apperror.NewBadRequest(err.Error())
+	}
+
+	res, total, err := h.usecase.ListAdventurers(filter)
+	if err != nil {
+		return []model.Adventurer{}, err
+	}
+	w.Header().Set(TotalCountHeader, strconv.Itoa(total))
+	return res, nil
+}
+
+func parseFilter(query url.Values) (filter model.AdventurerFilter, err error) {
+	int32Params := []struct {
+		name string
+		dst  *int32
+	}{
+		{"min_rank", &filter.MinRank},
+		{"max_rank", &filter.MaxRank},
+	}
+	for _, param := range int32Params {
+		if err = request.QueryInt32(query, param.name, param.dst); err != nil {
+			return filter, err
+		}
+	}
+	page, err := request.QueryPage(query)
+	if err != nil {
+		return filter, err
+	}
+
+	filter.NamePrefix = query.Get("name")
+	filter.Limit, filter.Offset, filter.Sort, filter.Desc = page.Limit, page.Offset, page.Sort, page.Desc
+	return filter, nil
+}
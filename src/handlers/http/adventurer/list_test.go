@@ -0,0 +1,105 @@
+package adventurer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	model "github.com/arfaghifari/guild-board/src/model/adventurer"
+	advUsecase "github.com/arfaghifari/guild-board/src/usecase/adventurer"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListAdventurers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	advs := []model.Adventurer{{ID: 1, Name: "andi", Rank: 11, CompletedQuest: 3}}
+	tests := []struct {
+		name           string
+		path           string
+		mock           func(*MockUsecase)
+		outAdvs        []model.Adventurer
+		outTotal       string
+		wantStatusCode int
+	}{
+		{
+			name: "success list adventurers",
+			path: "/v2/adventurers?name=an&min_rank=11&max_rank=13&sort=-completed_quest&limit=10&offset=20",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ListAdventurers(model.AdventurerFilter{
+					NamePrefix: "an",
+					MinRank:    11,
+					MaxRank:    13,
+					Sort:       constant.SortCompletedQuest,
+					Desc:       true,
+					Limit:      10,
+					Offset:     20,
+				}).Return(advs, 21, nil).Times(1)
+			},
+			outAdvs:        advs,
+			outTotal:       "21",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "no parameters",
+			path: "/v2/adventurers",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ListAdventurers(model.AdventurerFilter{}).Return([]model.Adventurer{}, 0, nil).Times(1)
+			},
+			outAdvs:        []model.Adventurer{},
+			outTotal:       "0",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "rank not a number",
+			path:           "/v2/adventurers?min_rank=high",
+			mock:           func(usecase *MockUsecase) {},
+			outAdvs:        []model.Adventurer{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "offset not a number",
+			path:           "/v2/adventurers?offset=next",
+			mock:           func(usecase *MockUsecase) {},
+			outAdvs:        []model.Adventurer{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid filter",
+			path: "/v2/adventurers?sort=name",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ListAdventurers(model.AdventurerFilter{Sort: "name"}).
+					Return(nil, 0, fmt.Errorf("%w: unknown sort", advUsecase.ErrInvalidFilter)).Times(1)
+			},
+			outAdvs:        []model.Adventurer{},
+			wantStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "error at layer usecase",
+			path: "/v2/adventurers",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ListAdventurers(model.AdventurerFilter{}).Return(nil, 0, errors.New("any error")).Times(1)
+			},
+			outAdvs:        []model.Adventurer{},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			h := &handlers{usecase: u, logger: testLogger}
+			tt.mock(u)
+			recorder := serveV2(h.ListAdventurers, staff, http.MethodGet, "/v2/adventurers", tt.path, ``)
+			var resp AdvListResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code, "error code")
+			assert.Equal(t, tt.outAdvs, resp.Data)
+			assert.Equal(t, tt.outTotal, recorder.Header().Get(TotalCountHeader))
+		})
+	}
+}
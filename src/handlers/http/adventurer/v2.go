@@ -0,0 +1,51 @@
+package adventurer
+
+import (
+	"net/http"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/handlers/http/auth"
+	"github.com/arfaghifari/guild-board/src/handlers/http/request"
+	model "github.com/arfaghifari/guild-board/src/model/adventurer"
+	"github.com/arfaghifari/guild-board/src/policy"
+	"github.com/arfaghifari/guild-board/src/validation"
+)
+
+func (h *handlers) GetAdventurerByID(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	advID, ok := request.PathID(r)
+	if !ok {
+		return model.Adventurer{}, apperror.NewBadRequest("adventurer id must be valid")
+	}
+
+	res, err := h.usecase.GetAdventurer(advID)
+	if err != nil {
+		return model.Adventurer{}, err
+	}
+	return res, nil
+}
+
+// PatchAdventurer changes the adventurer's rank.
+func (h *handlers) PatchAdventurer(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	if err := auth.Authorize(r.Context(), policy.RankAdventurer); err != nil {
+		return SuccesMessage{}, err
+	}
+	var adventurer model.Adventurer
+	advID, ok := request.PathID(r)
+	if !ok {
+		return SuccesMessage{}, apperror.NewBadRequest("adventurer id must be valid")
+	}
+	if err := h.decode(w, r, &adventurer); err != nil {
+		return SuccesMessage{}, err
+	}
+	if err := validation.Validate(
+		validation.Field("rank", adventurer.Rank, validation.Required, validRank),
+	); err != nil {
+		return SuccesMessage{}, err
+	}
+	adventurer.ID = advID
+
+	if err := h.usecase.UpdateAdventurerRank(adventurer); err != nil {
+		return SuccesMessage{}, err
+	}
+	return SuccesMessage{Success: true}, nil
+}
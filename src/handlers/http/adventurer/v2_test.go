@@ -0,0 +1,153 @@
+package adventurer
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/arfaghifari/guild-board/src/handlers/http/auth"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	model "github.com/arfaghifari/guild-board/src/model/adventurer"
+	modelAuth "github.com/arfaghifari/guild-board/src/model/auth"
+	advUsecase "github.com/arfaghifari/guild-board/src/usecase/adventurer"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// serveV2 routes a single request of principal to handler registered on
+// pattern.
+func serveV2(handler response.Handler, principal modelAuth.Principal, method, pattern, path, body string) *httptest.ResponseRecorder {
+	router := mux.NewRouter()
+	router.HandleFunc(pattern, response.Handle(testLogger, handler)).Methods(method)
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	router.ServeHTTP(recorder, request.WithContext(auth.WithPrincipal(request.Context(), principal)))
+	return recorder
+}
+
+func TestGetAdventurerByID(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name           string
+		path           string
+		mock           func(*MockUsecase)
+		outAdv         model.Adventurer
+		wantStatusCode int
+	}{
+		{
+			name: "success get an adventurer",
+			path: "/v2/adventurers/1",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetAdventurer(adv.ID).Return(adv, nil).Times(1)
+			},
+			outAdv:         adv,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "adventurer not found",
+			path: "/v2/adventurers/1",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetAdventurer(adv.ID).Return(model.Adventurer{}, advUsecase.ErrAdventurerNotFound).Times(1)
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "error at layer usecase",
+			path: "/v2/adventurers/1",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetAdventurer(adv.ID).Return(model.Adventurer{}, errors.New("any error")).Times(1)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "id not valid",
+			path:           "/v2/adventurers/a",
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			h := &handlers{usecase: u, logger: testLogger}
+			tt.mock(u)
+			recorder := serveV2(h.GetAdventurerByID, staff, http.MethodGet, "/v2/adventurers/{id}", tt.path, ``)
+			var resp AdvResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code, "error code")
+			assert.Equal(t, tt.outAdv, resp.Data)
+			assert.Equal(t, tt.wantStatusCode != http.StatusOK, resp.Header.Error != "", "error message")
+		})
+	}
+}
+
+func TestPatchAdventurer(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		mock           func(*MockUsecase)
+		wantStatusCode int
+	}{
+		{
+			name: "success update rank",
+			path: "/v2/adventurers/1",
+			body: `{"rank": 12}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().UpdateAdventurerRank(adv2).Return(nil).Times(1)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "error at layer usecase",
+			path: "/v2/adventurers/1",
+			body: `{"rank": 12}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().UpdateAdventurerRank(adv2).Return(errors.New("any error")).Times(1)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "rank not valid",
+			path:           "/v2/adventurers/1",
+			body:           `{"rank": 0}`,
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "json failed",
+			path:           "/v2/adventurers/1",
+			body:           `{`,
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "id not valid",
+			path:           "/v2/adventurers/-1",
+			body:           `{"rank": 12}`,
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			h := &handlers{usecase: u, logger: testLogger}
+			tt.mock(u)
+			recorder := serveV2(h.PatchAdventurer, staff, http.MethodPatch, "/v2/adventurers/{id}", tt.path, tt.body)
+			var resp MessageResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code, "error code")
+			assert.Equal(t, tt.wantStatusCode == http.StatusOK, resp.Data.Success)
+		})
+	}
+}
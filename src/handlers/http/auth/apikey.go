@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/arfaghifari/guild-board/src/config"
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/handlers/http/request"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	"github.com/arfaghifari/guild-board/src/logger"
+	model "github.com/arfaghifari/guild-board/src/model/auth"
+	"github.com/arfaghifari/guild-board/src/policy"
+	usecase "github.com/arfaghifari/guild-board/src/usecase/auth"
+	"github.com/arfaghifari/guild-board/src/validation"
+)
+
+type APIKeyResponse struct {
+	response.Header `json:"header"`
+	Data            model.IssuedAPIKey `json:"data"`
+}
+
+type Handlers interface {
+	CreateAPIKey(http.ResponseWriter, *http.Request) (interface{}, error)
+}
+
+type handlers struct {
+	usecase      usecase.Usecase
+	logger       logger.Logger
+	maxBodyBytes int64
+}
+
+var errMissingDependency = errors.New("api key handlers need a usecase and a logger")
+
+func NewHandlers(usecase usecase.Usecase, logger logger.Logger, cfg config.HTTP) (Handlers, error) {
+	if usecase == nil || logger == nil {
+		return nil, errMissingDependency
+	}
+
+	return &handlers{usecase, logger, cfg.MaxBodyBytes}, nil
+}
+
+// CreateAPIKey issues a key to the staff, a quest giver or an adventurer. It
+// works with every database driver, so a server on the memory store can hand
+// out the keys its quest flow needs.
+func (h *handlers) CreateAPIKey(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	if err := Authorize(r.Context(), policy.IssueAPIKey); err != nil {
+		return model.IssuedAPIKey{}, err
+	}
+	var key model.NewAPIKey
+	if err := request.Decode(w, r, h.maxBodyBytes, &key); err != nil {
+		return model.IssuedAPIKey{}, err
+	}
+	if err := validation.Validate(
+		validation.Field("name", key.Name, validation.Required, validation.MaxLength(constant.MaxNameLength)),
+		validation.Field("role", key.Role, validation.Required),
+	); err != nil {
+		return model.IssuedAPIKey{}, err
+	}
+	// only the holder the role names may be set
+	if (key.Role != constant.RoleAdventurer && key.AdventurerID != 0) || (key.Role != constant.RoleGiver && key.GiverID != 0) {
+		return model.IssuedAPIKey{}, usecase.ErrInvalidKey
+	}
+
+	holderID := key.AdventurerID
+	if key.Role == constant.RoleGiver {
+		holderID = key.GiverID
+	}
+	token, issued, err := h.usecase.IssueAPIKey(key.Name, key.Role, holderID)
+	if err != nil {
+		return model.IssuedAPIKey{}, err
+	}
+	return model.IssuedAPIKey{Key: token, APIKey: issued}, nil
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arfaghifari/guild-board/src/config"
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	model "github.com/arfaghifari/guild-board/src/model/auth"
+	advUsecase "github.com/arfaghifari/guild-board/src/usecase/adventurer"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var staff = model.Principal{KeyID: 1, Name: "front desk", Role: constant.RoleStaff}
+
+func TestNewHandlers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	res, err := NewHandlers(NewMockUsecase(mockCtrl), testLogger, config.Default().HTTP)
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+
+	res, err = NewHandlers(nil, testLogger, config.Default().HTTP)
+	assert.Error(t, err)
+	assert.Nil(t, res)
+
+	res, err = NewHandlers(NewMockUsecase(mockCtrl), nil, config.Default().HTTP)
+	assert.Error(t, err)
+	assert.Nil(t, res)
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	createdAt := time.Date(2023, time.July, 1, 9, 0, 0, 0, time.UTC)
+	andiKey := model.APIKey{ID: 2, Name: "andi", Role: constant.RoleAdventurer, AdventurerID: 1, CreatedAt: createdAt}
+	mayorKey := model.APIKey{ID: 3, Name: "mayor", Role: constant.RoleGiver, GiverID: 1, CreatedAt: createdAt}
+	tests := []struct {
+		name           string
+		principal      model.Principal
+		body           string
+		mock           func(*MockUsecase)
+		outKey         model.IssuedAPIKey
+		wantStatusCode int
+		wantCode       string
+	}{
+		{
+			name:      "success issued an adventurer key",
+			principal: staff,
+			body:      `{"name":"andi","role":"adventurer","adv_id":1}`,
+			mock: func(u *MockUsecase) {
+				u.EXPECT().IssueAPIKey("andi", constant.RoleAdventurer, int64(1)).Return("gb_andi", andiKey, nil).Times(1)
+			},
+			outKey:         model.IssuedAPIKey{Key: "gb_andi", APIKey: andiKey},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:      "success issued a quest giver key",
+			principal: staff,
+			body:      `{"name":"mayor","role":"quest_giver","giver_id":1}`,
+			mock: func(u *MockUsecase) {
+				u.EXPECT().IssueAPIKey("mayor", constant.RoleGiver, int64(1)).Return("gb_mayor", mayorKey, nil).Times(1)
+			},
+			outKey:         model.IssuedAPIKey{Key: "gb_mayor", APIKey: mayorKey},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "failed without a name and a role",
+			principal:      staff,
+			body:           `{}`,
+			mock:           func(u *MockUsecase) {},
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantCode:       "invalid_fields",
+		},
+		{
+			name:           "failed staff key naming a quest giver",
+			principal:      staff,
+			body:           `{"name":"desk","role":"staff","giver_id":1}`,
+			mock:           func(u *MockUsecase) {},
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantCode:       "invalid_api_key",
+		},
+		{
+			name:           "failed not staff",
+			principal:      andi,
+			body:           `{"name":"desk","role":"staff"}`,
+			mock:           func(u *MockUsecase) {},
+			wantStatusCode: http.StatusForbidden,
+			wantCode:       "permission_denied",
+		},
+		{
+			name:      "failed unknown adventurer",
+			principal: staff,
+			body:      `{"name":"andi","role":"adventurer","adv_id":9}`,
+			mock: func(u *MockUsecase) {
+				u.EXPECT().IssueAPIKey("andi", constant.RoleAdventurer, int64(9)).Return("", model.APIKey{}, advUsecase.ErrAdventurerNotFound).Times(1)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantCode:       "adventurer_not_found",
+		},
+		{
+			name:      "failed to store",
+			principal: staff,
+			body:      `{"name":"desk","role":"staff"}`,
+			mock: func(u *MockUsecase) {
+				u.EXPECT().IssueAPIKey("desk", constant.RoleStaff, int64(0)).Return("", model.APIKey{}, errors.New("any error")).Times(1)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantCode:       "internal_error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			tt.mock(u)
+			h := &handlers{usecase: u, logger: testLogger, maxBodyBytes: config.Default().HTTP.MaxBodyBytes}
+			request := httptest.NewRequest(http.MethodPost, "/v2/api-keys", strings.NewReader(tt.body))
+			recorder := httptest.NewRecorder()
+			response.Handle(testLogger, h.CreateAPIKey)(recorder, request.WithContext(WithPrincipal(request.Context(), tt.principal)))
+			var resp APIKeyResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code)
+			assert.Equal(t, tt.wantCode, resp.Header.Error)
+			assert.Equal(t, tt.outKey, resp.Data)
+		})
+	}
+}
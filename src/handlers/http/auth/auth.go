@@ -0,0 +1,104 @@
+// Package auth authenticates API requests with the bearer API key they carry.
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	"github.com/arfaghifari/guild-board/src/logger"
+	model "github.com/arfaghifari/guild-board/src/model/auth"
+	modelLedger "github.com/arfaghifari/guild-board/src/model/ledger"
+	"github.com/arfaghifari/guild-board/src/policy"
+	usecase "github.com/arfaghifari/guild-board/src/usecase/auth"
+)
+
+var ErrOtherAdventurer = apperror.NewForbidden("other_adventurer", "adventurers act only for themselves")
+
+type principalKey struct{}
+
+// Middleware lets a request through only with a known API key in its
+// Authorization header, and puts the key's principal in the request context.
+func Middleware(u usecase.Usecase, appLogger logger.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, err := u.Authenticate(bearer(r))
+			if err != nil {
+				header := response.Header{}
+				header.StatusCode, header.Error, header.Message = apperror.Describe(err)
+				if header.StatusCode == http.StatusUnauthorized {
+					w.Header().Set("WWW-Authenticate", `Bearer realm="guild-board"`)
+				} else {
+					appLogger.Errorf("[HTTP] %s %s, err: %v", r.Method, r.URL.Path, err)
+				}
+				response.Write(w, appLogger, header, nil)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+func bearer(r *http.Request) string {
+	const scheme = "bearer "
+	value := r.Header.Get("Authorization")
+	if len(value) < len(scheme) || !strings.EqualFold(value[:len(scheme)], scheme) {
+		return ""
+	}
+	return strings.TrimSpace(value[len(scheme):])
+}
+
+func WithPrincipal(ctx context.Context, principal model.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom returns the caller of an authenticated request.
+func PrincipalFrom(ctx context.Context) (model.Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(model.Principal)
+	return principal, ok
+}
+
+// Authorize fails with policy.ErrPermissionDenied unless the caller may do
+// action.
+func Authorize(ctx context.Context, action policy.Action) error {
+	principal, _ := PrincipalFrom(ctx)
+	return policy.Authorize(principal, action)
+}
+
+// AuthorizeOwner is Authorize for an action on a quest. owner returns the
+// quest giver who posted the quest and is only called when the caller could
+// act as that giver.
+func AuthorizeOwner(ctx context.Context, action policy.Action, owner func() (int64, error)) error {
+	principal, _ := PrincipalFrom(ctx)
+	if err := policy.Authorize(principal, action); err == nil || !policy.OwnerMay(principal, action) {
+		return err
+	}
+	ownerID, err := owner()
+	if err != nil {
+		return err
+	}
+	return policy.AuthorizeOwner(principal, action, ownerID)
+}
+
+// AuthorizeAccount is Authorize for an action on a ledger account, which the
+// quest giver or adventurer holding it may also do.
+func AuthorizeAccount(ctx context.Context, action policy.Action, account modelLedger.Account) error {
+	principal, _ := PrincipalFrom(ctx)
+	return policy.AuthorizeAccount(principal, action, account)
+}
+
+// Adventurer returns the adventurer calling. advID is the adventurer a client
+// named in the request, if any; it must be the caller.
+func Adventurer(ctx context.Context, advID int64) (int64, error) {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok || principal.Role != constant.RoleAdventurer {
+		return 0, policy.ErrPermissionDenied
+	}
+	if advID != 0 && advID != principal.AdventurerID {
+		return 0, ErrOtherAdventurer
+	}
+	return principal.AdventurerID, nil
+}
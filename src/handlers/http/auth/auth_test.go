@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	"github.com/arfaghifari/guild-board/src/logger"
+	model "github.com/arfaghifari/guild-board/src/model/auth"
+	modelLedger "github.com/arfaghifari/guild-board/src/model/ledger"
+	"github.com/arfaghifari/guild-board/src/policy"
+	usecase "github.com/arfaghifari/guild-board/src/usecase/auth"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testLogger, _ = logger.NewLogger("error")
+
+var andi = model.Principal{KeyID: 2, Name: "andi", Role: constant.RoleAdventurer, AdventurerID: 1}
+
+func TestMiddleware(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name          string
+		authorization string
+		mock          func(*MockUsecase)
+		wantStatus    int
+		wantCode      string
+		wantChallenge bool
+	}{
+		{
+			name:          "success",
+			authorization: "Bearer gb_andi",
+			mock: func(u *MockUsecase) {
+				u.EXPECT().Authenticate("gb_andi").Return(andi, nil).Times(1)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "success scheme in lower case",
+			authorization: "bearer gb_andi",
+			mock: func(u *MockUsecase) {
+				u.EXPECT().Authenticate("gb_andi").Return(andi, nil).Times(1)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "failed without a key",
+			mock: func(u *MockUsecase) {
+				u.EXPECT().Authenticate("").Return(model.Principal{}, usecase.ErrUnauthenticated).Times(1)
+			},
+			wantStatus:    http.StatusUnauthorized,
+			wantCode:      "unauthenticated",
+			wantChallenge: true,
+		},
+		{
+			name:          "failed basic auth",
+			authorization: "Basic YW5kaTpzZWNyZXQ=",
+			mock: func(u *MockUsecase) {
+				u.EXPECT().Authenticate("").Return(model.Principal{}, usecase.ErrUnauthenticated).Times(1)
+			},
+			wantStatus:    http.StatusUnauthorized,
+			wantCode:      "unauthenticated",
+			wantChallenge: true,
+		},
+		{
+			name:          "failed to look the key up",
+			authorization: "Bearer gb_andi",
+			mock: func(u *MockUsecase) {
+				u.EXPECT().Authenticate("gb_andi").Return(model.Principal{}, errors.New("some error")).Times(1)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "internal_error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			tt.mock(u)
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal, ok := PrincipalFrom(r.Context())
+				assert.True(t, ok)
+				assert.Equal(t, andi, principal)
+			})
+			req := httptest.NewRequest(http.MethodGet, "/quest/1", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			Middleware(u, testLogger)(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantChallenge, rec.Header().Get("WWW-Authenticate") != "")
+			if tt.wantCode != "" {
+				var body struct {
+					Header response.Header `json:"header"`
+				}
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+				assert.Equal(t, tt.wantCode, body.Header.Error)
+			}
+		})
+	}
+}
+
+func TestAdventurer(t *testing.T) {
+	staff := model.Principal{KeyID: 1, Name: "front desk", Role: constant.RoleStaff}
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		advID   int64
+		out     int64
+		wantErr error
+	}{
+		{name: "success the caller", ctx: WithPrincipal(context.Background(), andi), out: 1},
+		{name: "success naming the caller", ctx: WithPrincipal(context.Background(), andi), advID: 1, out: 1},
+		{name: "failed naming another adventurer", ctx: WithPrincipal(context.Background(), andi), advID: 2, wantErr: ErrOtherAdventurer},
+		{name: "failed staff", ctx: WithPrincipal(context.Background(), staff), advID: 1, wantErr: policy.ErrPermissionDenied},
+		{name: "failed anonymous", ctx: context.Background(), wantErr: policy.ErrPermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := Adventurer(tt.ctx, tt.advID)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.out, res)
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	assert.NoError(t, Authorize(WithPrincipal(context.Background(), andi), policy.TakeQuest))
+	assert.Equal(t, policy.ErrPermissionDenied, Authorize(WithPrincipal(context.Background(), andi), policy.DeleteQuest))
+	assert.Equal(t, policy.ErrPermissionDenied, Authorize(context.Background(), policy.TakeQuest))
+}
+
+func TestAuthorizeOwner(t *testing.T) {
+	staff := model.Principal{KeyID: 1, Name: "front desk", Role: constant.RoleStaff}
+	mayor := model.Principal{KeyID: 3, Name: "mayor", Role: constant.RoleGiver, GiverID: 1}
+	lookupErr := errors.New("some error")
+	owner := func(id int64, err error) func() (int64, error) {
+		return func() (int64, error) { return id, err }
+	}
+	unused := func() (int64, error) {
+		t.Fatal("the owner must not be looked up")
+		return 0, nil
+	}
+	tests := []struct {
+		name      string
+		principal model.Principal
+		owner     func() (int64, error)
+		wantErr   error
+	}{
+		{name: "success staff", principal: staff, owner: unused},
+		{name: "success owner", principal: mayor, owner: owner(1, nil)},
+		{name: "failed another owner", principal: mayor, owner: owner(2, nil), wantErr: policy.ErrPermissionDenied},
+		{name: "failed to look the owner up", principal: mayor, owner: owner(0, lookupErr), wantErr: lookupErr},
+		{name: "failed adventurer", principal: andi, owner: unused, wantErr: policy.ErrPermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := AuthorizeOwner(WithPrincipal(context.Background(), tt.principal), policy.EditQuest, tt.owner)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestAuthorizeAccount(t *testing.T) {
+	ctx := WithPrincipal(context.Background(), andi)
+	assert.NoError(t, AuthorizeAccount(ctx, policy.ViewBalance, modelLedger.AdventurerAccount(andi.AdventurerID)))
+	assert.Equal(t, policy.ErrPermissionDenied, AuthorizeAccount(ctx, policy.ViewBalance, modelLedger.Guild))
+	assert.Equal(t, policy.ErrPermissionDenied, AuthorizeAccount(context.Background(), policy.ViewBalance, modelLedger.Guild))
+}
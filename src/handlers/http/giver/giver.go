@@ -0,0 +1,112 @@
+package giver
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/config"
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/handlers/http/auth"
+	"github.com/arfaghifari/guild-board/src/handlers/http/quest"
+	"github.com/arfaghifari/guild-board/src/handlers/http/request"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	"github.com/arfaghifari/guild-board/src/logger"
+	model "github.com/arfaghifari/guild-board/src/model/giver"
+	modelQuest "github.com/arfaghifari/guild-board/src/model/quest"
+	"github.com/arfaghifari/guild-board/src/policy"
+	usecase "github.com/arfaghifari/guild-board/src/usecase/giver"
+	"github.com/arfaghifari/guild-board/src/validation"
+)
+
+type GiverResponse struct {
+	response.Header `json:"header"`
+	Data            model.Giver `json:"data"`
+}
+
+type PostedQuestListResponse struct {
+	response.Header `json:"header"`
+	Data            []modelQuest.PostedQuest `json:"data"`
+}
+
+type Handlers interface {
+	CreateGiver(http.ResponseWriter, *http.Request) (interface{}, error)
+	GetGiver(http.ResponseWriter, *http.Request) (interface{}, error)
+	ListGiverQuests(http.ResponseWriter, *http.Request) (interface{}, error)
+}
+
+type handlers struct {
+	usecase      usecase.Usecase
+	logger       logger.Logger
+	maxBodyBytes int64
+}
+
+var errMissingDependency = errors.New("giver handlers need a usecase and a logger")
+
+func NewHandlers(usecase usecase.Usecase, logger logger.Logger, cfg config.HTTP) (Handlers, error) {
+	if usecase == nil || logger == nil {
+		return nil, errMissingDependency
+	}
+
+	return &handlers{usecase, logger, cfg.MaxBodyBytes}, nil
+}
+
+// decode reads the JSON request body into v, bounded by the configured body size.
+func (h *handlers) decode(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	return request.Decode(w, r, h.maxBodyBytes, v)
+}
+
+func (h *handlers) CreateGiver(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	if err := auth.Authorize(r.Context(), policy.RegisterGiver); err != nil {
+		return model.Giver{}, err
+	}
+	var giver model.Giver
+	if err := h.decode(w, r, &giver); err != nil {
+		return model.Giver{}, err
+	}
+	if err := validation.Validate(
+		validation.Field("name", giver.Name, validation.Required, validation.MaxLength(constant.MaxNameLength)),
+	); err != nil {
+		return model.Giver{}, err
+	}
+
+	res, err := h.usecase.CreateGiver(model.Giver{Name: giver.Name})
+	if err != nil {
+		return model.Giver{}, err
+	}
+	return res, nil
+}
+
+func (h *handlers) GetGiver(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	giverID, ok := request.PathID(r)
+	if !ok {
+		return model.Giver{}, apperror.NewBadRequest("giver id must be valid")
+	}
+
+	res, err := h.usecase.GetGiver(giverID)
+	if err != nil {
+		return model.Giver{}, err
+	}
+	return res, nil
+}
+
+// ListGiverQuests serves a page of the quests the giver posted, each with its
+// status and the adventurers assigned to it, filtered like ListQuests.
+func (h *handlers) ListGiverQuests(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	giverID, ok := request.PathID(r)
+	if !ok {
+		return []modelQuest.PostedQuest{}, apperror.NewBadRequest("giver id must be valid")
+	}
+	filter, err := quest.ParseFilter(r.URL.Query())
+	if err != nil {
+		return []modelQuest.PostedQuest{}, apperror.NewBadRequest(err.Error())
+	}
+
+	res, total, err := h.usecase.ListGiverQuests(giverID, filter)
+	if err != nil {
+		return []modelQuest.PostedQuest{}, err
+	}
+	w.Header().Set(quest.TotalCountHeader, strconv.Itoa(total))
+	return res, nil
+}
@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: giver.go
+
+// Package mock_giver is a generated GoMock package.
+package giver
+
+import (
+	reflect "reflect"
+
+	giver "github.com/arfaghifari/guild-board/src/model/giver"
+	quest "github.com/arfaghifari/guild-board/src/model/quest"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUsecase is a mock of Usecase interface.
+type MockUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsecaseMockRecorder
+}
+
+// MockUsecaseMockRecorder is the mock recorder for MockUsecase.
+type MockUsecaseMockRecorder struct {
+	mock *MockUsecase
+}
+
+// NewMockUsecase creates a new mock instance.
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	mock := &MockUsecase{ctrl: ctrl}
+	mock.recorder = &MockUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateGiver mocks base method.
+func (m *MockUsecase) CreateGiver(arg0 giver.Giver) (giver.Giver, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGiver", arg0)
+	ret0, _ := ret[0].(giver.Giver)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGiver indicates an expected call of CreateGiver.
+func (mr *MockUsecaseMockRecorder) CreateGiver(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGiver", reflect.TypeOf((*MockUsecase)(nil).CreateGiver), arg0)
+}
+
+// GetGiver mocks base method.
+func (m *MockUsecase) GetGiver(arg0 int64) (giver.Giver, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGiver", arg0)
+	ret0, _ := ret[0].(giver.Giver)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGiver indicates an expected call of GetGiver.
+func (mr *MockUsecaseMockRecorder) GetGiver(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGiver", reflect.TypeOf((*MockUsecase)(nil).GetGiver), arg0)
+}
+
+// ListGiverQuests mocks base method.
+func (m *MockUsecase) ListGiverQuests(arg0 int64, arg1 quest.QuestFilter) ([]quest.PostedQuest, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGiverQuests", arg0, arg1)
+	ret0, _ := ret[0].([]quest.PostedQuest)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListGiverQuests indicates an expected call of ListGiverQuests.
+func (mr *MockUsecaseMockRecorder) ListGiverQuests(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGiverQuests", reflect.TypeOf((*MockUsecase)(nil).ListGiverQuests), arg0, arg1)
+}
@@ -0,0 +1,239 @@
+package giver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arfaghifari/guild-board/src/config"
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/handlers/http/auth"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	"github.com/arfaghifari/guild-board/src/logger"
+	modelAuth "github.com/arfaghifari/guild-board/src/model/auth"
+	model "github.com/arfaghifari/guild-board/src/model/giver"
+	modelQuest "github.com/arfaghifari/guild-board/src/model/quest"
+	giverUsecase "github.com/arfaghifari/guild-board/src/usecase/giver"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+var testLogger, _ = logger.NewLogger("error")
+
+var (
+	staff  = modelAuth.Principal{KeyID: 1, Name: "front desk", Role: constant.RoleStaff}
+	caller = modelAuth.Principal{KeyID: 2, Name: "andi", Role: constant.RoleAdventurer, AdventurerID: 1}
+	mayor  = model.Giver{ID: 1, Name: "mayor of riverwood", CreatedAt: time.Date(2023, time.July, 1, 9, 0, 0, 0, time.UTC)}
+)
+
+// serveV2 routes a single request of principal to handler registered on
+// pattern.
+func serveV2(handler response.Handler, principal modelAuth.Principal, method, pattern, path, body string) *httptest.ResponseRecorder {
+	router := mux.NewRouter()
+	router.HandleFunc(pattern, response.Handle(testLogger, handler)).Methods(method)
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	router.ServeHTTP(recorder, request.WithContext(auth.WithPrincipal(request.Context(), principal)))
+	return recorder
+}
+
+func TestNewHandlers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	res, err := NewHandlers(NewMockUsecase(mockCtrl), testLogger, config.Default().HTTP)
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+
+	res, err = NewHandlers(nil, testLogger, config.Default().HTTP)
+	assert.Error(t, err)
+	assert.Nil(t, res)
+
+	res, err = NewHandlers(NewMockUsecase(mockCtrl), nil, config.Default().HTTP)
+	assert.Error(t, err)
+	assert.Nil(t, res)
+}
+
+func TestCreateGiver(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name           string
+		principal      modelAuth.Principal
+		body           string
+		mock           func(*MockUsecase)
+		outGiver       model.Giver
+		wantStatusCode int
+		wantCode       string
+	}{
+		{
+			name:      "success created a quest giver",
+			principal: staff,
+			body:      `{"name":"mayor of riverwood"}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().CreateGiver(model.Giver{Name: mayor.Name}).Return(mayor, nil).Times(1)
+			},
+			outGiver:       mayor,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "failed without a name",
+			principal:      staff,
+			body:           `{}`,
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantCode:       "invalid_fields",
+		},
+		{
+			name:           "failed not staff",
+			principal:      caller,
+			body:           `{"name":"mayor of riverwood"}`,
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusForbidden,
+			wantCode:       "permission_denied",
+		},
+		{
+			name:      "failed to store",
+			principal: staff,
+			body:      `{"name":"mayor of riverwood"}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().CreateGiver(gomock.Any()).Return(model.Giver{}, errors.New("any error")).Times(1)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantCode:       "internal_error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			tt.mock(u)
+			h := &handlers{usecase: u, logger: testLogger, maxBodyBytes: config.Default().HTTP.MaxBodyBytes}
+			recorder := serveV2(h.CreateGiver, tt.principal, http.MethodPost, "/v2/givers", "/v2/givers", tt.body)
+			var resp GiverResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code)
+			assert.Equal(t, tt.wantCode, resp.Header.Error)
+			assert.Equal(t, tt.outGiver, resp.Data)
+		})
+	}
+}
+
+func TestGetGiver(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name           string
+		path           string
+		mock           func(*MockUsecase)
+		outGiver       model.Giver
+		wantStatusCode int
+	}{
+		{
+			name: "success get a quest giver",
+			path: "/v2/givers/1",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetGiver(mayor.ID).Return(mayor, nil).Times(1)
+			},
+			outGiver:       mayor,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "quest giver not found",
+			path: "/v2/givers/1",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetGiver(mayor.ID).Return(model.Giver{}, giverUsecase.ErrGiverNotFound).Times(1)
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "invalid id",
+			path:           "/v2/givers/0",
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			tt.mock(u)
+			h := &handlers{usecase: u, logger: testLogger}
+			recorder := serveV2(h.GetGiver, caller, http.MethodGet, "/v2/givers/{id}", tt.path, "")
+			var resp GiverResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code)
+			assert.Equal(t, tt.outGiver, resp.Data)
+		})
+	}
+}
+
+func TestListGiverQuests(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	posted := []modelQuest.PostedQuest{{
+		Quest:  modelQuest.Quest{ID: 1, Name: "menyelamatkan kucing", Status: constant.WorkingQuest, CreatedBy: mayor.ID},
+		Takers: []modelQuest.Taker{{AdventurerID: 1, Name: "andi", Rank: 11}},
+	}}
+	tests := []struct {
+		name           string
+		path           string
+		mock           func(*MockUsecase)
+		outPosted      []modelQuest.PostedQuest
+		wantTotal      string
+		wantStatusCode int
+	}{
+		{
+			name: "success lists the quests of the giver",
+			path: "/v2/givers/1/quests?status=1&limit=5",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ListGiverQuests(mayor.ID, modelQuest.QuestFilter{Statuses: []int32{constant.WorkingQuest}, Limit: 5}).Return(posted, 6, nil).Times(1)
+			},
+			outPosted:      posted,
+			wantTotal:      "6",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "quest giver not found",
+			path: "/v2/givers/1/quests",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ListGiverQuests(mayor.ID, modelQuest.QuestFilter{}).Return(nil, 0, giverUsecase.ErrGiverNotFound).Times(1)
+			},
+			outPosted:      []modelQuest.PostedQuest{},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "invalid filter",
+			path:           "/v2/givers/1/quests?limit=many",
+			mock:           func(usecase *MockUsecase) {},
+			outPosted:      []modelQuest.PostedQuest{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid id",
+			path:           "/v2/givers/x/quests",
+			mock:           func(usecase *MockUsecase) {},
+			outPosted:      []modelQuest.PostedQuest{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			tt.mock(u)
+			h := &handlers{usecase: u, logger: testLogger}
+			recorder := serveV2(h.ListGiverQuests, caller, http.MethodGet, "/v2/givers/{id}/quests", tt.path, "")
+			var resp PostedQuestListResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code)
+			assert.Equal(t, tt.wantTotal, recorder.Header().Get("X-Total-Count"))
+			assert.Equal(t, tt.outPosted, resp.Data)
+		})
+	}
+}
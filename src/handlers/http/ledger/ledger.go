@@ -0,0 +1,122 @@
+package ledger
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/handlers/http/auth"
+	"github.com/arfaghifari/guild-board/src/handlers/http/request"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	"github.com/arfaghifari/guild-board/src/logger"
+	model "github.com/arfaghifari/guild-board/src/model/ledger"
+	"github.com/arfaghifari/guild-board/src/policy"
+	usecase "github.com/arfaghifari/guild-board/src/usecase/ledger"
+)
+
+type BalanceResponse struct {
+	response.Header `json:"header"`
+	Data            model.Balance `json:"data"`
+}
+
+type PayoutResponse struct {
+	response.Header `json:"header"`
+	Data            model.Payout `json:"data"`
+}
+
+type Handlers interface {
+	GetGuildBalance(http.ResponseWriter, *http.Request) (interface{}, error)
+	GetGiverBalance(http.ResponseWriter, *http.Request) (interface{}, error)
+	GetAdventurerBalance(http.ResponseWriter, *http.Request) (interface{}, error)
+	GetQuestEscrow(http.ResponseWriter, *http.Request) (interface{}, error)
+	GetQuestPayout(http.ResponseWriter, *http.Request) (interface{}, error)
+}
+
+type handlers struct {
+	usecase usecase.Usecase
+	logger  logger.Logger
+}
+
+var errMissingDependency = errors.New("ledger handlers need a usecase and a logger")
+
+func NewHandlers(usecase usecase.Usecase, logger logger.Logger) (Handlers, error) {
+	if usecase == nil || logger == nil {
+		return nil, errMissingDependency
+	}
+
+	return &handlers{usecase, logger}, nil
+}
+
+func (h *handlers) GetGuildBalance(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	if err := auth.AuthorizeAccount(r.Context(), policy.ViewBalance, model.Guild); err != nil {
+		return model.Balance{}, err
+	}
+
+	res, err := h.usecase.GetGuildBalance()
+	if err != nil {
+		return model.Balance{}, err
+	}
+	return res, nil
+}
+
+func (h *handlers) GetGiverBalance(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	giverID, ok := request.PathID(r)
+	if !ok {
+		return model.Balance{}, apperror.NewBadRequest("giver id must be valid")
+	}
+	if err := auth.AuthorizeAccount(r.Context(), policy.ViewBalance, model.GiverAccount(giverID)); err != nil {
+		return model.Balance{}, err
+	}
+
+	res, err := h.usecase.GetGiverBalance(giverID)
+	if err != nil {
+		return model.Balance{}, err
+	}
+	return res, nil
+}
+
+func (h *handlers) GetAdventurerBalance(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	advID, ok := request.PathID(r)
+	if !ok {
+		return model.Balance{}, apperror.NewBadRequest("adventurer id must be valid")
+	}
+	if err := auth.AuthorizeAccount(r.Context(), policy.ViewBalance, model.AdventurerAccount(advID)); err != nil {
+		return model.Balance{}, err
+	}
+
+	res, err := h.usecase.GetAdventurerBalance(advID)
+	if err != nil {
+		return model.Balance{}, err
+	}
+	return res, nil
+}
+
+// GetQuestEscrow serves what the escrow of a quest holds. Rewards are public,
+// so any key may read it.
+func (h *handlers) GetQuestEscrow(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	questID, ok := request.PathID(r)
+	if !ok {
+		return model.Balance{}, apperror.NewBadRequest("quest id must be valid")
+	}
+
+	res, err := h.usecase.GetQuestEscrow(questID)
+	if err != nil {
+		return model.Balance{}, err
+	}
+	return res, nil
+}
+
+// GetQuestPayout serves how the reward of a completed quest was split between
+// the guild fee and the party. Like the escrow, any key may read it.
+func (h *handlers) GetQuestPayout(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	questID, ok := request.PathID(r)
+	if !ok {
+		return model.Payout{}, apperror.NewBadRequest("quest id must be valid")
+	}
+
+	res, err := h.usecase.GetQuestPayout(questID)
+	if err != nil {
+		return model.Payout{}, err
+	}
+	return res, nil
+}
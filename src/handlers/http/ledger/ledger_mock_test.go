@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ledger.go
+
+// Package mock_ledger is a generated GoMock package.
+package ledger
+
+import (
+	reflect "reflect"
+
+	ledger "github.com/arfaghifari/guild-board/src/model/ledger"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUsecase is a mock of Usecase interface.
+type MockUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsecaseMockRecorder
+}
+
+// MockUsecaseMockRecorder is the mock recorder for MockUsecase.
+type MockUsecaseMockRecorder struct {
+	mock *MockUsecase
+}
+
+// NewMockUsecase creates a new mock instance.
+func NewMockUsecase(ctrl *gomock.Controller) *MockUsecase {
+	mock := &MockUsecase{ctrl: ctrl}
+	mock.recorder = &MockUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetAdventurerBalance mocks base method.
+func (m *MockUsecase) GetAdventurerBalance(arg0 int64) (ledger.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdventurerBalance", arg0)
+	ret0, _ := ret[0].(ledger.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdventurerBalance indicates an expected call of GetAdventurerBalance.
+func (mr *MockUsecaseMockRecorder) GetAdventurerBalance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdventurerBalance", reflect.TypeOf((*MockUsecase)(nil).GetAdventurerBalance), arg0)
+}
+
+// GetGiverBalance mocks base method.
+func (m *MockUsecase) GetGiverBalance(arg0 int64) (ledger.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGiverBalance", arg0)
+	ret0, _ := ret[0].(ledger.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGiverBalance indicates an expected call of GetGiverBalance.
+func (mr *MockUsecaseMockRecorder) GetGiverBalance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGiverBalance", reflect.TypeOf((*MockUsecase)(nil).GetGiverBalance), arg0)
+}
+
+// GetGuildBalance mocks base method.
+func (m *MockUsecase) GetGuildBalance() (ledger.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuildBalance")
+	ret0, _ := ret[0].(ledger.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuildBalance indicates an expected call of GetGuildBalance.
+func (mr *MockUsecaseMockRecorder) GetGuildBalance() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuildBalance", reflect.TypeOf((*MockUsecase)(nil).GetGuildBalance))
+}
+
+// GetQuestEscrow mocks base method.
+func (m *MockUsecase) GetQuestEscrow(arg0 int64) (ledger.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestEscrow", arg0)
+	ret0, _ := ret[0].(ledger.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestEscrow indicates an expected call of GetQuestEscrow.
+func (mr *MockUsecaseMockRecorder) GetQuestEscrow(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestEscrow", reflect.TypeOf((*MockUsecase)(nil).GetQuestEscrow), arg0)
+}
+
+// GetQuestPayout mocks base method.
+func (m *MockUsecase) GetQuestPayout(arg0 int64) (ledger.Payout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestPayout", arg0)
+	ret0, _ := ret[0].(ledger.Payout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestPayout indicates an expected call of GetQuestPayout.
+func (mr *MockUsecaseMockRecorder) GetQuestPayout(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestPayout", reflect.TypeOf((*MockUsecase)(nil).GetQuestPayout), arg0)
+}
@@ -0,0 +1,258 @@
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/handlers/http/auth"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	"github.com/arfaghifari/guild-board/src/logger"
+	modelAuth "github.com/arfaghifari/guild-board/src/model/auth"
+	model "github.com/arfaghifari/guild-board/src/model/ledger"
+	advUsecase "github.com/arfaghifari/guild-board/src/usecase/adventurer"
+	usecase "github.com/arfaghifari/guild-board/src/usecase/ledger"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+var testLogger, _ = logger.NewLogger("error")
+
+var (
+	staff = modelAuth.Principal{KeyID: 1, Name: "front desk", Role: constant.RoleStaff}
+	mayor = modelAuth.Principal{KeyID: 2, Name: "mayor", Role: constant.RoleGiver, GiverID: 1}
+	andi  = modelAuth.Principal{KeyID: 3, Name: "andi", Role: constant.RoleAdventurer, AdventurerID: 1}
+)
+
+// serveV2 routes a single request of principal to handler registered on
+// pattern.
+func serveV2(handler response.Handler, principal modelAuth.Principal, pattern, path string) *httptest.ResponseRecorder {
+	router := mux.NewRouter()
+	router.HandleFunc(pattern, response.Handle(testLogger, handler)).Methods(http.MethodGet)
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	router.ServeHTTP(recorder, request.WithContext(auth.WithPrincipal(request.Context(), principal)))
+	return recorder
+}
+
+func TestNewHandlers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	res, err := NewHandlers(NewMockUsecase(mockCtrl), testLogger)
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+
+	res, err = NewHandlers(nil, testLogger)
+	assert.Error(t, err)
+	assert.Nil(t, res)
+
+	res, err = NewHandlers(NewMockUsecase(mockCtrl), nil)
+	assert.Error(t, err)
+	assert.Nil(t, res)
+}
+
+func TestGetBalance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	guild := model.Balance{Account: model.Guild, Balance: -500}
+	giver := model.Balance{Account: model.GiverAccount(1), Balance: -300}
+	adventurer := model.Balance{Account: model.AdventurerAccount(1), Balance: 150}
+	escrow := model.Balance{Account: model.EscrowAccount(1), Balance: 200}
+	tests := []struct {
+		name           string
+		handler        func(Handlers) response.Handler
+		principal      modelAuth.Principal
+		pattern        string
+		path           string
+		mock           func(*MockUsecase)
+		outBalance     model.Balance
+		wantStatusCode int
+		wantCode       string
+	}{
+		{
+			name:      "success staff gets the guild balance",
+			handler:   func(h Handlers) response.Handler { return h.GetGuildBalance },
+			principal: staff,
+			pattern:   "/v2/guild/balance",
+			path:      "/v2/guild/balance",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetGuildBalance().Return(guild, nil).Times(1)
+			},
+			outBalance:     guild,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "failed a giver gets the guild balance",
+			handler:        func(h Handlers) response.Handler { return h.GetGuildBalance },
+			principal:      mayor,
+			pattern:        "/v2/guild/balance",
+			path:           "/v2/guild/balance",
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusForbidden,
+			wantCode:       "permission_denied",
+		},
+		{
+			name:      "success a giver gets its balance",
+			handler:   func(h Handlers) response.Handler { return h.GetGiverBalance },
+			principal: mayor,
+			pattern:   "/v2/givers/{id}/balance",
+			path:      "/v2/givers/1/balance",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetGiverBalance(int64(1)).Return(giver, nil).Times(1)
+			},
+			outBalance:     giver,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "failed a giver gets another giver balance",
+			handler:        func(h Handlers) response.Handler { return h.GetGiverBalance },
+			principal:      mayor,
+			pattern:        "/v2/givers/{id}/balance",
+			path:           "/v2/givers/2/balance",
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusForbidden,
+			wantCode:       "permission_denied",
+		},
+		{
+			name:           "failed invalid giver id",
+			handler:        func(h Handlers) response.Handler { return h.GetGiverBalance },
+			principal:      staff,
+			pattern:        "/v2/givers/{id}/balance",
+			path:           "/v2/givers/0/balance",
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantCode:       "bad_request",
+		},
+		{
+			name:      "success an adventurer gets its balance",
+			handler:   func(h Handlers) response.Handler { return h.GetAdventurerBalance },
+			principal: andi,
+			pattern:   "/v2/adventurers/{id}/balance",
+			path:      "/v2/adventurers/1/balance",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetAdventurerBalance(int64(1)).Return(adventurer, nil).Times(1)
+			},
+			outBalance:     adventurer,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:      "failed adventurer not found",
+			handler:   func(h Handlers) response.Handler { return h.GetAdventurerBalance },
+			principal: staff,
+			pattern:   "/v2/adventurers/{id}/balance",
+			path:      "/v2/adventurers/9/balance",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetAdventurerBalance(int64(9)).Return(model.Balance{}, advUsecase.ErrAdventurerNotFound).Times(1)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantCode:       "adventurer_not_found",
+		},
+		{
+			name:           "failed an adventurer gets another adventurer balance",
+			handler:        func(h Handlers) response.Handler { return h.GetAdventurerBalance },
+			principal:      andi,
+			pattern:        "/v2/adventurers/{id}/balance",
+			path:           "/v2/adventurers/2/balance",
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusForbidden,
+			wantCode:       "permission_denied",
+		},
+		{
+			name:      "success any key gets a quest escrow",
+			handler:   func(h Handlers) response.Handler { return h.GetQuestEscrow },
+			principal: andi,
+			pattern:   "/v2/quests/{id}/escrow",
+			path:      "/v2/quests/1/escrow",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetQuestEscrow(int64(1)).Return(escrow, nil).Times(1)
+			},
+			outBalance:     escrow,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:      "failed get a quest escrow",
+			handler:   func(h Handlers) response.Handler { return h.GetQuestEscrow },
+			principal: staff,
+			pattern:   "/v2/quests/{id}/escrow",
+			path:      "/v2/quests/1/escrow",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetQuestEscrow(int64(1)).Return(model.Balance{}, errors.New("any error")).Times(1)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantCode:       "internal_error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			tt.mock(u)
+			h := &handlers{usecase: u, logger: testLogger}
+			recorder := serveV2(tt.handler(h), tt.principal, tt.pattern, tt.path)
+			var resp BalanceResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code)
+			assert.Equal(t, tt.wantCode, resp.Header.Error)
+			assert.Equal(t, tt.outBalance, resp.Data)
+		})
+	}
+}
+
+func TestGetQuestPayout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	payout := model.Payout{QuestID: 1, Gross: 200000, GuildFee: 20000, Net: 180000, FeePercent: 10}
+	tests := []struct {
+		name           string
+		path           string
+		mock           func(*MockUsecase)
+		outPayout      model.Payout
+		wantStatusCode int
+		wantCode       string
+	}{
+		{
+			name: "success get a quest payout",
+			path: "/v2/quests/1/payout",
+			mock: func(u *MockUsecase) {
+				u.EXPECT().GetQuestPayout(int64(1)).Return(payout, nil).Times(1)
+			},
+			outPayout:      payout,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "failed quest not paid out",
+			path: "/v2/quests/2/payout",
+			mock: func(u *MockUsecase) {
+				u.EXPECT().GetQuestPayout(int64(2)).Return(model.Payout{}, usecase.ErrPayoutNotFound).Times(1)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantCode:       "payout_not_found",
+		},
+		{
+			name:           "invalid quest id",
+			path:           "/v2/quests/abc/payout",
+			mock:           func(*MockUsecase) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantCode:       "bad_request",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			tt.mock(u)
+			h := &handlers{usecase: u, logger: testLogger}
+			recorder := serveV2(h.GetQuestPayout, andi, "/v2/quests/{id}/payout", tt.path)
+			var resp PayoutResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code)
+			assert.Equal(t, tt.wantCode, resp.Header.Error)
+			assert.Equal(t, tt.outPayout, resp.Data)
+		})
+	}
+}
@@ -0,0 +1,260 @@
+// Package openapi builds an OpenAPI 3 document whose schemas are derived from
+// Go types and serves it with a Swagger UI page.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const Version = "3.0.3"
+
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+
+	names map[reflect.Type]string
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem holds the operations of a path by lower case method.
+type PathItem map[string]*Operation
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Scheme      string `json:"scheme,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// SecurityRequirement names the security schemes an operation accepts.
+type SecurityRequirement map[string][]string
+
+type Operation struct {
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+func New(title, version string) *Document {
+	return &Document{
+		OpenAPI:    Version,
+		Info:       Info{Title: title, Version: version},
+		Paths:      map[string]PathItem{},
+		Components: Components{Schemas: map[string]*Schema{}},
+		names:      map[reflect.Type]string{},
+	}
+}
+
+// Add documents the route of method and the mux path template p.
+func (d *Document) Add(method, p string, op Operation) {
+	if d.Paths[p] == nil {
+		d.Paths[p] = PathItem{}
+	}
+	d.Paths[p][strings.ToLower(method)] = &op
+}
+
+// Has tells whether the route of method and p is documented.
+func (d *Document) Has(method, p string) bool {
+	return d.Paths[p][strings.ToLower(method)] != nil
+}
+
+// Body is a required JSON request body shaped like v.
+func (d *Document) Body(v interface{}) *RequestBody {
+	return &RequestBody{Required: true, Content: jsonContent(d.Schema(v))}
+}
+
+// Responses answers with a JSON body shaped like v on success and on each of
+// the failure status codes.
+func (d *Document) Responses(v interface{}, failures ...int) map[string]Response {
+	content := jsonContent(d.Schema(v))
+	responses := map[string]Response{
+		"200": {Description: http.StatusText(http.StatusOK), Content: content},
+	}
+	for _, status := range failures {
+		responses[fmt.Sprint(status)] = Response{Description: http.StatusText(status), Content: content}
+	}
+	return responses
+}
+
+// Bearer adds an HTTP bearer scheme to the components and returns the
+// requirement of it.
+func (d *Document) Bearer(name, description string) []SecurityRequirement {
+	if d.Components.SecuritySchemes == nil {
+		d.Components.SecuritySchemes = map[string]SecurityScheme{}
+	}
+	d.Components.SecuritySchemes[name] = SecurityScheme{Type: "http", Scheme: "bearer", Description: description}
+	return []SecurityRequirement{{name: {}}}
+}
+
+// PathParam is a required path parameter shaped like v.
+func (d *Document) PathParam(name, description string, v interface{}) Parameter {
+	return Parameter{Name: name, In: "path", Description: description, Required: true, Schema: d.Schema(v)}
+}
+
+// Query is an optional query parameter shaped like v.
+func (d *Document) Query(name, description string, v interface{}) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: d.Schema(v)}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Schema describes the JSON encoding of v. Named structs are added to the
+// components once and referenced.
+func (d *Document) Schema(v interface{}) *Schema {
+	return d.schemaOf(reflect.TypeOf(v))
+}
+
+func (d *Document) schemaOf(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := d.schemaOf(t.Elem())
+		if s.Ref != "" {
+			return s
+		}
+		nullable := *s
+		nullable.Nullable = true
+		return &nullable
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: d.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return d.object(t)
+		}
+		return d.component(t)
+	}
+	return &Schema{}
+}
+
+// component names t after its package, numbering types whose package and
+// name are already taken by another type.
+func (d *Document) component(t reflect.Type) *Schema {
+	name, ok := d.names[t]
+	if !ok {
+		base := path.Base(t.PkgPath()) + "." + t.Name()
+		name = base
+		for i := 2; d.Components.Schemas[name] != nil; i++ {
+			name = fmt.Sprintf("%s%d", base, i)
+		}
+		d.names[t] = name
+		// registered before its fields so that recursive types end
+		s := &Schema{}
+		d.Components.Schemas[name] = s
+		*s = *d.object(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (d *Document) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	d.addFields(s, t)
+	return s
+}
+
+// addFields follows encoding/json: "-" hides a field and untagged embedded
+// structs are flattened.
+func (d *Document) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			d.addFields(s, ft)
+			continue
+		}
+		if !f.IsExported() && !(f.Anonymous && ft.Kind() == reflect.Struct) {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		s.Properties[name] = d.schemaOf(f.Type)
+	}
+}
+
+func jsonContent(s *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: s}}
+}
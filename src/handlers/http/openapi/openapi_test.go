@@ -0,0 +1,92 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type meta struct {
+	Code string `json:"error_code"`
+}
+
+type tree struct {
+	meta     `json:"meta"`
+	Name     string    `json:"name"`
+	Rank     int32     `json:"rank"`
+	ID       int64     `json:"id"`
+	Done     *bool     `json:"done,omitempty"`
+	At       time.Time `json:"at"`
+	Children []tree    `json:"children"`
+	Parent   *tree     `json:"parent"`
+	Secret   string    `json:"-"`
+	Untagged float64
+	hidden   int
+}
+
+type flat struct {
+	meta
+	Name string `json:"name"`
+}
+
+func TestSchema(t *testing.T) {
+	d := New("Test", "1.0.0")
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/openapi.tree"}, d.Schema(tree{}))
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/openapi.tree"}}, d.Schema([]tree{}))
+
+	treeRef := &Schema{Ref: "#/components/schemas/openapi.tree"}
+	assert.Equal(t, &Schema{Type: "object", Properties: map[string]*Schema{
+		"meta":     {Ref: "#/components/schemas/openapi.meta"},
+		"name":     {Type: "string"},
+		"rank":     {Type: "integer", Format: "int32"},
+		"id":       {Type: "integer", Format: "int64"},
+		"done":     {Type: "boolean", Nullable: true},
+		"at":       {Type: "string", Format: "date-time"},
+		"children": {Type: "array", Items: treeRef},
+		"parent":   treeRef,
+		"Untagged": {Type: "number", Format: "double"},
+	}}, d.Components.Schemas["openapi.tree"])
+
+	d.Schema(flat{})
+	assert.Equal(t, &Schema{Type: "object", Properties: map[string]*Schema{
+		"error_code": {Type: "string"},
+		"name":       {Type: "string"},
+	}}, d.Components.Schemas["openapi.flat"])
+}
+
+func TestDocument(t *testing.T) {
+	d := New("Test", "1.0.0")
+	d.Add(http.MethodPost, "/trees/{id}", Operation{
+		Summary:     "Grow a tree",
+		Parameters:  []Parameter{d.PathParam("id", "tree id", int64(0))},
+		RequestBody: d.Body(flat{}),
+		Responses:   d.Responses(tree{}, http.StatusNotFound),
+		Security:    d.Bearer("apiKey", ""),
+	})
+	assert.True(t, d.Has(http.MethodPost, "/trees/{id}"))
+	assert.False(t, d.Has(http.MethodGet, "/trees/{id}"))
+	assert.False(t, d.Has(http.MethodPost, "/trees"))
+
+	recorder := httptest.NewRecorder()
+	Serve(d)(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	var served struct {
+		OpenAPI string `json:"openapi"`
+		Paths   map[string]map[string]struct {
+			Responses map[string]Response `json:"responses"`
+		} `json:"paths"`
+	}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &served))
+	assert.Equal(t, Version, served.OpenAPI)
+	assert.Equal(t, "Not Found", served.Paths["/trees/{id}"]["post"].Responses["404"].Description)
+	assert.Contains(t, recorder.Body.String(), `"security":[{"apiKey":[]}]`)
+	assert.Contains(t, recorder.Body.String(), `"securitySchemes":{"apiKey":{"type":"http","scheme":"bearer"}}`)
+
+	recorder = httptest.NewRecorder()
+	UI("Test", "/openapi.json")(recorder, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.Contains(t, recorder.Body.String(), `url: "/openapi.json"`)
+}
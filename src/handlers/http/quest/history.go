@@ -0,0 +1,52 @@
+package quest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/handlers/http/request"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+)
+
+type AssignmentListResponse struct {
+	response.Header `json:"header"`
+	Data            []model.Assignment `json:"data"`
+}
+
+// GetQuestHistory serves a page of the adventurers who held the quest, newest first.
+func (h *handlers) GetQuestHistory(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	return h.history(w, r, "quest", func(filter *model.AssignmentFilter, id int64) {
+		filter.QuestID = id
+	})
+}
+
+// GetAdventurerHistory serves a page of the quests the adventurer held, newest first.
+func (h *handlers) GetAdventurerHistory(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	return h.history(w, r, "adventurer", func(filter *model.AssignmentFilter, id int64) {
+		filter.AdventurerID = id
+	})
+}
+
+func (h *handlers) history(w http.ResponseWriter, r *http.Request, owner string, scope func(*model.AssignmentFilter, int64)) (interface{}, error) {
+	var filter model.AssignmentFilter
+	id, ok := request.PathID(r)
+	if !ok {
+		return []model.Assignment{}, apperror.NewBadRequest(owner + " id must be valid")
+	}
+	scope(&filter, id)
+
+	page, err := request.QueryPage(r.URL.Query())
+	if err != nil {
+		return []model.Assignment{}, apperror.NewBadRequest(err.Error())
+	}
+	filter.Limit, filter.Offset = page.Limit, page.Offset
+
+	res, total, err := h.usecase.ListAssignments(filter)
+	if err != nil {
+		return []model.Assignment{}, err
+	}
+	w.Header().Set(TotalCountHeader, strconv.Itoa(total))
+	return res, nil
+}
@@ -0,0 +1,120 @@
+package quest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+	advUsecase "github.com/arfaghifari/guild-board/src/usecase/adventurer"
+	qstUsecase "github.com/arfaghifari/guild-board/src/usecase/quest"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetHistory(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	takenAt := time.Date(2023, time.July, 1, 9, 0, 0, 0, time.UTC)
+	finishedAt := takenAt.Add(time.Hour)
+	history := []model.Assignment{
+		{ID: 2, QuestID: 1, AdventurerID: 1, TakenAt: takenAt},
+		{ID: 1, QuestID: 1, AdventurerID: 2, TakenAt: takenAt, FinishedAt: &finishedAt, Outcome: constant.OutcomeAbandoned},
+	}
+	tests := []struct {
+		name           string
+		adventurer     bool
+		path           string
+		mock           func(*MockUsecase)
+		outHistory     []model.Assignment
+		outTotal       string
+		wantStatusCode int
+	}{
+		{
+			name: "success quest history",
+			path: "/v2/quests/1/history?limit=2&offset=4",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ListAssignments(model.AssignmentFilter{QuestID: 1, Limit: 2, Offset: 4}).Return(history, 6, nil).Times(1)
+			},
+			outHistory:     history,
+			outTotal:       "6",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:       "success adventurer history",
+			adventurer: true,
+			path:       "/v2/adventurers/2/history",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ListAssignments(model.AssignmentFilter{AdventurerID: 2}).Return(history[1:], 1, nil).Times(1)
+			},
+			outHistory:     history[1:],
+			outTotal:       "1",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "invalid id",
+			path:           "/v2/quests/abc/history",
+			mock:           func(usecase *MockUsecase) {},
+			outHistory:     []model.Assignment{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "offset not a number",
+			path:           "/v2/quests/1/history?offset=next",
+			mock:           func(usecase *MockUsecase) {},
+			outHistory:     []model.Assignment{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid filter",
+			path: "/v2/quests/1/history?limit=500",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ListAssignments(model.AssignmentFilter{QuestID: 1, Limit: 500}).
+					Return(nil, 0, fmt.Errorf("%w: limit too large", qstUsecase.ErrInvalidFilter)).Times(1)
+			},
+			outHistory:     []model.Assignment{},
+			wantStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "adventurer not found",
+			adventurer: true,
+			path:       "/v2/adventurers/9/history",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ListAssignments(model.AssignmentFilter{AdventurerID: 9}).Return(nil, 0, advUsecase.ErrAdventurerNotFound).Times(1)
+			},
+			outHistory:     []model.Assignment{},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "error at layer usecase",
+			path: "/v2/quests/1/history",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ListAssignments(model.AssignmentFilter{QuestID: 1}).Return(nil, 0, errors.New("any error")).Times(1)
+			},
+			outHistory:     []model.Assignment{},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			h := &handlers{usecase: u, logger: testLogger}
+			tt.mock(u)
+			handler, pattern := h.GetQuestHistory, "/v2/quests/{id}/history"
+			if tt.adventurer {
+				handler, pattern = h.GetAdventurerHistory, "/v2/adventurers/{id}/history"
+			}
+			recorder := serveV2(handler, caller, http.MethodGet, pattern, tt.path, ``)
+			var resp AssignmentListResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code, "error code")
+			assert.Equal(t, tt.outHistory, resp.Data)
+			assert.Equal(t, tt.outTotal, recorder.Header().Get(TotalCountHeader))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package quest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/handlers/http/request"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+)
+
+// TotalCountHeader carries the number of quests matching a listing across all pages.
+const TotalCountHeader = "X-Total-Count"
+
+type QuestListResponse struct {
+	response.Header `json:"header"`
+	Data            []model.Quest `json:"data"`
+}
+
+// ListQuests serves a page of quests filtered by the status, min_reward,
+// max_reward, min_rank, max_rank and name query parameters and ordered by
+// sort, prefixed with "-" to sort descending.
+func (h *handlers) ListQuests(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	filter, err := ParseFilter(r.URL.Query())
+	if err != nil {
+		return []model.Quest{}, apperror.NewBadRequest(err.Error())
+	}
+
+	res, total, err := h.usecase.ListQuests(filter)
+	if err != nil {
+		return []model.Quest{}, err
+	}
+	w.Header().Set(TotalCountHeader, strconv.Itoa(total))
+	return res, nil
+}
+
+// ParseFilter reads the query parameters of ListQuests, for every listing of
+// quests.
+func ParseFilter(query url.Values) (filter model.QuestFilter, err error) {
+	for _, list := range query["status"] {
+		for _, value := range strings.Split(list, ",") {
+			status, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+			if err != nil {
+				return filter, fmt.Errorf("status must be a list of numbers")
+			}
+			filter.Statuses = append(filter.Statuses, int32(status))
+		}
+	}
+
+	int32Params := []struct {
+		name string
+		dst  *int32
+	}{
+		{"min_reward", &filter.MinReward},
+		{"max_reward", &filter.MaxReward},
+		{"min_rank", &filter.MinRank},
+		{"max_rank", &filter.MaxRank},
+	}
+	for _, param := range int32Params {
+		if err = request.QueryInt32(query, param.name, param.dst); err != nil {
+			return filter, err
+		}
+	}
+	page, err := request.QueryPage(query)
+	if err != nil {
+		return filter, err
+	}
+
+	filter.Name = query.Get("name")
+	filter.Limit, filter.Offset, filter.Sort, filter.Desc = page.Limit, page.Offset, page.Sort, page.Desc
+	return filter, nil
+}
@@ -0,0 +1,114 @@
+package quest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+	qstUsecase "github.com/arfaghifari/guild-board/src/usecase/quest"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListQuests(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name           string
+		path           string
+		mock           func(*MockUsecase)
+		outQuests      []model.Quest
+		outTotal       string
+		wantStatusCode int
+	}{
+		{
+			name: "success list quests",
+			path: "/v2/quests?status=0,1&status=4&min_reward=100&max_reward=500&min_rank=11&max_rank=13&name=kucing&sort=-reward&limit=10&offset=20",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ListQuests(model.QuestFilter{
+					Statuses:  []int32{constant.AvailableQuest, constant.WorkingQuest, constant.CancelledQuest},
+					MinReward: 100,
+					MaxReward: 500,
+					MinRank:   11,
+					MaxRank:   13,
+					Name:      "kucing",
+					Sort:      constant.SortReward,
+					Desc:      true,
+					Limit:     10,
+					Offset:    20,
+				}).Return(bulkQuest[0:2], 22, nil).Times(1)
+			},
+			outQuests:      bulkQuest[0:2],
+			outTotal:       "22",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "no parameters",
+			path: "/v2/quests",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ListQuests(model.QuestFilter{}).Return([]model.Quest{}, 0, nil).Times(1)
+			},
+			outQuests:      []model.Quest{},
+			outTotal:       "0",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "status not a number",
+			path:           "/v2/quests?status=0,open",
+			mock:           func(usecase *MockUsecase) {},
+			outQuests:      []model.Quest{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "reward not a number",
+			path:           "/v2/quests?min_reward=many",
+			mock:           func(usecase *MockUsecase) {},
+			outQuests:      []model.Quest{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "limit not a number",
+			path:           "/v2/quests?limit=all",
+			mock:           func(usecase *MockUsecase) {},
+			outQuests:      []model.Quest{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid filter",
+			path: "/v2/quests?sort=name",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ListQuests(model.QuestFilter{Sort: "name"}).
+					Return(nil, 0, fmt.Errorf("%w: unknown sort", qstUsecase.ErrInvalidFilter)).Times(1)
+			},
+			outQuests:      []model.Quest{},
+			wantStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "error at layer usecase",
+			path: "/v2/quests",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ListQuests(model.QuestFilter{}).Return(nil, 0, errors.New("any error")).Times(1)
+			},
+			outQuests:      []model.Quest{},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			h := &handlers{usecase: u, logger: testLogger}
+			tt.mock(u)
+			recorder := serveV2(h.ListQuests, caller, http.MethodGet, "/v2/quests", tt.path, ``)
+			var resp QuestListResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code, "error code")
+			assert.Equal(t, tt.outQuests, resp.Data)
+			assert.Equal(t, tt.outTotal, recorder.Header().Get(TotalCountHeader))
+		})
+	}
+}
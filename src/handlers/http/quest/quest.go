@@ -1,394 +1,339 @@
 package quest
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/config"
 	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/handlers/http/auth"
+	"github.com/arfaghifari/guild-board/src/handlers/http/request"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	"github.com/arfaghifari/guild-board/src/logger"
 	model "github.com/arfaghifari/guild-board/src/model/quest"
+	"github.com/arfaghifari/guild-board/src/policy"
 	usecase "github.com/arfaghifari/guild-board/src/usecase/quest"
+	"github.com/arfaghifari/guild-board/src/validation"
 )
 
-type Header struct {
-	Error      string `json:"error_code"`
-	StatusCode int    `json:"status_code"`
-}
-
 type GetQuestByStatusResponse struct {
-	Header `json:"header"`
-	Data   []model.GetQuestByStatus `json:"data"`
+	response.Header `json:"header"`
+	Data            []model.GetQuestByStatus `json:"data"`
 }
 
 type GetQuestActiveAdventurer struct {
-	Header `json:"header"`
-	Data   []model.Quest `json:"data"`
+	response.Header `json:"header"`
+	Data            []model.Quest `json:"data"`
 }
 
 type MessageResponse struct {
-	Header `json:"header"`
-	Data   SuccesMessage `json:"data"`
+	response.Header `json:"header"`
+	Data            SuccesMessage `json:"data"`
 }
 
 type QuestResponse struct {
-	Header `json:"header"`
-	Data   model.Quest `json:"data"`
+	response.Header `json:"header"`
+	Data            model.Quest `json:"data"`
 }
 
 type SuccesMessage struct {
 	Success bool `json:"success"`
 }
 
+type CancelResponse struct {
+	response.Header `json:"header"`
+	Data            CancelMessage `json:"data"`
+}
+
+// CancelMessage lists the adventurers taken off the cancelled quest.
+type CancelMessage struct {
+	Success             bool    `json:"success"`
+	ReleasedAdventurers []int64 `json:"released_adventurers"`
+}
+
 type Handlers interface {
-	GetQuestByStatus(http.ResponseWriter, *http.Request)
-	CreateQuest(http.ResponseWriter, *http.Request)
-	DeleteQuest(http.ResponseWriter, *http.Request)
-	UpdateQuestRank(http.ResponseWriter, *http.Request)
-	UpdateQuestReward(http.ResponseWriter, *http.Request)
-	TakeQuest(http.ResponseWriter, *http.Request)
-	ReportQuest(http.ResponseWriter, *http.Request)
-	GetQuestActiveAdventurer(http.ResponseWriter, *http.Request)
+	GetQuestByStatus(http.ResponseWriter, *http.Request) (interface{}, error)
+	CreateQuest(http.ResponseWriter, *http.Request) (interface{}, error)
+	DeleteQuest(http.ResponseWriter, *http.Request) (interface{}, error)
+	UpdateQuestRank(http.ResponseWriter, *http.Request) (interface{}, error)
+	UpdateQuestReward(http.ResponseWriter, *http.Request) (interface{}, error)
+	TakeQuest(http.ResponseWriter, *http.Request) (interface{}, error)
+	ReportQuest(http.ResponseWriter, *http.Request) (interface{}, error)
+	GetQuestActiveAdventurer(http.ResponseWriter, *http.Request) (interface{}, error)
+	CancelQuest(http.ResponseWriter, *http.Request) (interface{}, error)
+	GetQuest(http.ResponseWriter, *http.Request) (interface{}, error)
+	RemoveQuest(http.ResponseWriter, *http.Request) (interface{}, error)
+	PatchQuest(http.ResponseWriter, *http.Request) (interface{}, error)
+	GetTakers(http.ResponseWriter, *http.Request) (interface{}, error)
+	AddTaker(http.ResponseWriter, *http.Request) (interface{}, error)
+	SubmitReport(http.ResponseWriter, *http.Request) (interface{}, error)
+	GetAdventurerQuests(http.ResponseWriter, *http.Request) (interface{}, error)
+	ListQuests(http.ResponseWriter, *http.Request) (interface{}, error)
+	GetQuestHistory(http.ResponseWriter, *http.Request) (interface{}, error)
+	GetAdventurerHistory(http.ResponseWriter, *http.Request) (interface{}, error)
 }
 
 type handlers struct {
-	usecase usecase.Usecase
+	usecase      usecase.Usecase
+	logger       logger.Logger
+	maxBodyBytes int64
 }
 
-func NewHandlers() (Handlers, error) {
-	usecase, _ := usecase.NewUsecase()
+var errMissingDependency = errors.New("quest handlers need a usecase and a logger")
 
-	return &handlers{usecase}, nil
+func NewHandlers(usecase usecase.Usecase, logger logger.Logger, cfg config.HTTP) (Handlers, error) {
+	if usecase == nil || logger == nil {
+		return nil, errMissingDependency
+	}
+
+	return &handlers{usecase, logger, cfg.MaxBodyBytes}, nil
+}
+
+// decode reads the JSON request body into v, bounded by the configured body size.
+func (h *handlers) decode(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	return request.Decode(w, r, h.maxBodyBytes, v)
 }
 
+// Rules of the fields clients send about quests, Required aside.
+var (
+	validID          = validation.Min(1)
+	validName        = validation.MaxLength(constant.MaxNameLength)
+	validDescription = validation.MaxLength(constant.MaxDescriptionLength)
+	validRank        = validation.Between(constant.MinRank, constant.MaxRank)
+	validReward      = validation.Between(1, constant.MaxReward)
+	validRankRule    = validation.OneOf(constant.RankRuleAll, constant.RankRuleAverage)
+)
+
 func GetHello(w http.ResponseWriter, r *http.Request) {
 	log.Println("Hello World")
 	fmt.Fprintf(w, "HELLO NAKAMA")
 }
 
-func (h *handlers) GetQuestByStatus(w http.ResponseWriter, r *http.Request) {
-	var (
-		statusCode = http.StatusBadRequest
-		resp       GetQuestByStatusResponse
-	)
-	resp.Data = []model.GetQuestByStatus{}
-	defer func() {
-		w.Header().Set("Content-Type", "application/json")
-		resp.StatusCode = statusCode
-		responseWriter, err := json.Marshal(resp)
-		if err != nil {
-			log.Fatal("Failed build response")
-		}
-		if statusCode == http.StatusOK {
-			w.Write(responseWriter)
-		} else {
-			http.Error(w, string(responseWriter), statusCode)
-		}
-	}()
+func (h *handlers) GetQuestByStatus(w http.ResponseWriter, r *http.Request) (interface{}, error) {
 	status, err := strconv.Atoi(r.URL.Query().Get("status"))
 	if err != nil {
-		resp.Header.Error = err.Error()
-		return
-	}
-	if status != constant.AvailableQuest && status != constant.CompletedQuest {
-		resp.Header.Error = "Invalid status number"
-		return
+		return []model.GetQuestByStatus{}, apperror.NewBadRequest(err.Error())
 	}
 	res, err := h.usecase.GetQuestByStatus(int32(status))
 	if err != nil {
-		statusCode = http.StatusInternalServerError
-		resp.Header.Error = err.Error()
-		return
+		return []model.GetQuestByStatus{}, err
 	}
-	statusCode = http.StatusOK
-	resp.Data = res
-
+	return res, nil
 }
 
-func (h *handlers) CreateQuest(w http.ResponseWriter, r *http.Request) {
-	var (
-		statusCode = http.StatusBadRequest
-		resp       QuestResponse
-		quest      model.Quest
-	)
-	defer func() {
-		w.Header().Set("Content-Type", "application/json")
-		resp.StatusCode = statusCode
-		responseWriter, err := json.Marshal(resp)
-		if err != nil {
-			log.Fatal("Failed build response")
-		}
-		if statusCode == http.StatusOK {
-			w.Write(responseWriter)
-		} else {
-			http.Error(w, string(responseWriter), statusCode)
-		}
-	}()
-	resp.Data = model.Quest{}
-
-	if err := json.NewDecoder(r.Body).Decode(&quest); err != nil {
-		resp.Header.Error = err.Error()
-		return
-	}
-	if quest.Name == "" || quest.MinimumRank <= 0 || quest.RewardNumber <= 0 {
-		resp.Header.Error = "name, minimum_rank and reward_number are required and must be valid"
-		return
-	}
-
-	res, err := h.usecase.CreateQuest(quest)
+func (h *handlers) CreateQuest(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	if err := auth.Authorize(r.Context(), policy.CreateQuest); err != nil {
+		return model.Quest{}, err
+	}
+	var quest model.NewQuest
+	if err := h.decode(w, r, &quest); err != nil {
+		return model.Quest{}, err
+	}
+	// a party needs at least its minimum, and never fewer than one member
+	minMembers := quest.MinMembers
+	if minMembers < 1 {
+		minMembers = 1
+	}
+	if err := validation.Validate(
+		validation.Field("name", quest.Name, validation.Required, validName),
+		validation.Field("description", quest.Description, validDescription),
+		validation.Field("minimum_rank", quest.MinimumRank, validation.Required, validRank),
+		validation.Field("reward_number", quest.RewardNumber, validation.Required, validReward),
+		validation.Field("deadline", quest.Deadline, validation.After(time.Now())),
+		validation.Field("min_members", quest.MinMembers, validation.Min(1)),
+		validation.Field("max_members", quest.MaxMembers, validation.Min(int64(minMembers))),
+		validation.Field("rank_rule", quest.RankRule, validRankRule),
+	); err != nil {
+		return model.Quest{}, err
+	}
+
+	// A quest belongs to the giver whose key posts it; staff post for the guild.
+	principal, _ := auth.PrincipalFrom(r.Context())
+	res, err := h.usecase.CreateQuest(model.Quest{
+		Name:         quest.Name,
+		Description:  quest.Description,
+		MinimumRank:  quest.MinimumRank,
+		RewardNumber: quest.RewardNumber,
+		Deadline:     quest.Deadline,
+		MinMembers:   quest.MinMembers,
+		MaxMembers:   quest.MaxMembers,
+		RankRule:     quest.RankRule,
+		CreatedBy:    principal.GiverID,
+	})
 
 	if err != nil {
-		statusCode = http.StatusInternalServerError
-		resp.Header.Error = err.Error()
-		return
+		return model.Quest{}, err
 	}
-	statusCode = http.StatusOK
-	resp.Data = res
+	return res, nil
+}
 
+// owner looks up the quest giver who posted the quest, for AuthorizeOwner.
+func (h *handlers) owner(questID int64) func() (int64, error) {
+	return func() (int64, error) {
+		quest, err := h.usecase.GetQuest(questID)
+		return quest.CreatedBy, err
+	}
 }
 
-func (h *handlers) DeleteQuest(w http.ResponseWriter, r *http.Request) {
-	var (
-		statusCode = http.StatusBadRequest
-		resp       MessageResponse
-		quest      model.Quest
-	)
-	defer func() {
-		w.Header().Set("Content-Type", "application/json")
-		resp.StatusCode = statusCode
-		responseWriter, err := json.Marshal(resp)
-		if err != nil {
-			log.Fatal("Failed build response")
-		}
-		if statusCode == http.StatusOK {
-			w.Write(responseWriter)
-		} else {
-			http.Error(w, string(responseWriter), statusCode)
-		}
-	}()
-
-	if err := json.NewDecoder(r.Body).Decode(&quest); err != nil {
-		resp.Header.Error = err.Error()
-		return
-	}
-
-	if quest.ID <= 0 {
-		resp.Header.Error = "quest_id is required and must be valid"
-		return
+func (h *handlers) DeleteQuest(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	if err := auth.Authorize(r.Context(), policy.DeleteQuest); err != nil {
+		return SuccesMessage{}, err
+	}
+	var quest model.Quest
+	if err := h.decode(w, r, &quest); err != nil {
+		return SuccesMessage{}, err
+	}
+	if err := validation.Validate(
+		validation.Field("quest_id", quest.ID, validation.Required, validID),
+	); err != nil {
+		return SuccesMessage{}, err
 	}
 
 	err := h.usecase.DeleteQuest(quest)
 
 	if err != nil {
-		statusCode = http.StatusInternalServerError
-		resp.Header.Error = err.Error()
-		return
+		return SuccesMessage{}, err
 	}
-	statusCode = http.StatusOK
-	resp.Data.Success = true
+	return SuccesMessage{Success: true}, nil
 }
 
-func (h *handlers) UpdateQuestRank(w http.ResponseWriter, r *http.Request) {
-	var (
-		statusCode = http.StatusBadRequest
-		resp       MessageResponse
-		quest      model.Quest
-	)
-	defer func() {
-		w.Header().Set("Content-Type", "application/json")
-		resp.StatusCode = statusCode
-		responseWriter, err := json.Marshal(resp)
-		if err != nil {
-			log.Fatal("Failed build response")
-		}
-		if statusCode == http.StatusOK {
-			w.Write(responseWriter)
-		} else {
-			http.Error(w, string(responseWriter), statusCode)
-		}
-	}()
-
-	if err := json.NewDecoder(r.Body).Decode(&quest); err != nil {
-		resp.Header.Error = err.Error()
-		return
-	}
-
-	if quest.ID <= 0 || quest.MinimumRank <= 0 {
-		resp.Header.Error = "quest_id and minimum_rank are required and must be valid"
-		return
+func (h *handlers) UpdateQuestRank(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	var quest model.Quest
+	if err := h.decode(w, r, &quest); err != nil {
+		return SuccesMessage{}, err
+	}
+	if err := validation.Validate(
+		validation.Field("quest_id", quest.ID, validation.Required, validID),
+		validation.Field("minimum_rank", quest.MinimumRank, validation.Required, validRank),
+	); err != nil {
+		return SuccesMessage{}, err
+	}
+	if err := auth.AuthorizeOwner(r.Context(), policy.EditQuest, h.owner(quest.ID)); err != nil {
+		return SuccesMessage{}, err
 	}
 
 	err := h.usecase.UpdateQuestRank(quest)
 
 	if err != nil {
-		statusCode = http.StatusInternalServerError
-		resp.Header.Error = err.Error()
-		return
+		return SuccesMessage{}, err
 	}
-	statusCode = http.StatusOK
-	resp.Data.Success = true
+	return SuccesMessage{Success: true}, nil
 }
 
-func (h *handlers) UpdateQuestReward(w http.ResponseWriter, r *http.Request) {
-	var (
-		statusCode = http.StatusBadRequest
-		resp       MessageResponse
-		quest      model.Quest
-	)
-	defer func() {
-		w.Header().Set("Content-Type", "application/json")
-		resp.StatusCode = statusCode
-		responseWriter, err := json.Marshal(resp)
-		if err != nil {
-			log.Fatal("Failed build response")
-		}
-		if statusCode == http.StatusOK {
-			w.Write(responseWriter)
-		} else {
-			http.Error(w, string(responseWriter), statusCode)
-		}
-	}()
-
-	if err := json.NewDecoder(r.Body).Decode(&quest); err != nil {
-		resp.Header.Error = err.Error()
-		return
-	}
-
-	if quest.ID <= 0 || quest.RewardNumber <= 0 {
-		resp.Header.Error = "quest_id and reward_number are required and must be valid"
-		return
+func (h *handlers) UpdateQuestReward(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	var quest model.Quest
+	if err := h.decode(w, r, &quest); err != nil {
+		return SuccesMessage{}, err
+	}
+	if err := validation.Validate(
+		validation.Field("quest_id", quest.ID, validation.Required, validID),
+		validation.Field("reward_number", quest.RewardNumber, validation.Required, validReward),
+	); err != nil {
+		return SuccesMessage{}, err
+	}
+	if err := auth.AuthorizeOwner(r.Context(), policy.EditQuest, h.owner(quest.ID)); err != nil {
+		return SuccesMessage{}, err
 	}
 
 	err := h.usecase.UpdateQuestReward(quest)
 
 	if err != nil {
-		statusCode = http.StatusInternalServerError
-		resp.Header.Error = err.Error()
-		return
+		return SuccesMessage{}, err
 	}
-	statusCode = http.StatusOK
-	resp.Data.Success = true
+	return SuccesMessage{Success: true}, nil
 }
 
-func (h *handlers) TakeQuest(w http.ResponseWriter, r *http.Request) {
-	var (
-		statusCode    = http.StatusBadRequest
-		resp          MessageResponse
-		takeByRequest model.TakenBy
-	)
-	defer func() {
-		w.Header().Set("Content-Type", "application/json")
-		resp.StatusCode = statusCode
-		responseWriter, err := json.Marshal(resp)
-		if err != nil {
-			log.Fatal("Failed build response")
-		}
-		if statusCode == http.StatusOK {
-			w.Write(responseWriter)
-		} else {
-			http.Error(w, string(responseWriter), statusCode)
-		}
-	}()
-
-	if err := json.NewDecoder(r.Body).Decode(&takeByRequest); err != nil {
-		resp.Header.Error = err.Error()
-		return
-	}
-
-	if takeByRequest.AdventurerID <= 0 || takeByRequest.QuestID <= 0 {
-		resp.Header.Error = "adv_id and quest_id are required and must be valid"
-		return
-	}
-
-	err := h.usecase.TakeQuest(takeByRequest.QuestID, takeByRequest.AdventurerID)
+func (h *handlers) TakeQuest(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	if err := auth.Authorize(r.Context(), policy.TakeQuest); err != nil {
+		return SuccesMessage{}, err
+	}
+	var takeByRequest model.TakenBy
+	if err := h.decode(w, r, &takeByRequest); err != nil {
+		return SuccesMessage{}, err
+	}
+	if err := validation.Validate(
+		validation.Field("quest_id", takeByRequest.QuestID, validation.Required, validID),
+		validation.Field("adv_id", takeByRequest.AdventurerID, validID),
+	); err != nil {
+		return SuccesMessage{}, err
+	}
+	advID, err := auth.Adventurer(r.Context(), takeByRequest.AdventurerID)
+	if err != nil {
+		return SuccesMessage{}, err
+	}
+
+	err = h.usecase.TakeQuest(takeByRequest.QuestID, advID)
 
 	if err != nil {
-		statusCode = http.StatusInternalServerError
-		resp.Header.Error = err.Error()
-		return
+		return SuccesMessage{}, err
 	}
-	statusCode = http.StatusOK
-	resp.Data.Success = true
+	return SuccesMessage{Success: true}, nil
 }
 
-func (h *handlers) ReportQuest(w http.ResponseWriter, r *http.Request) {
-	var (
-		statusCode  = http.StatusBadRequest
-		resp        MessageResponse
-		reportQuest model.ReportQuest
-	)
-	defer func() {
-		w.Header().Set("Content-Type", "application/json")
-		resp.StatusCode = statusCode
-		responseWriter, err := json.Marshal(resp)
-		if err != nil {
-			log.Fatal("Failed build response")
-		}
-		if statusCode == http.StatusOK {
-			w.Write(responseWriter)
-		} else {
-			http.Error(w, string(responseWriter), statusCode)
-		}
-	}()
-
-	if err := json.NewDecoder(r.Body).Decode(&reportQuest); err != nil {
-		resp.Header.Error = err.Error()
-		return
-	}
-
-	if reportQuest.AdventurerID <= 0 || reportQuest.QuestID <= 0 || reportQuest.IsCompleted == nil {
-		resp.Header.Error = "adv_id, quest_id and is_completed are required and must be valid"
-		return
-	}
-
-	err := h.usecase.ReportQuest(reportQuest.QuestID, reportQuest.AdventurerID, *reportQuest.IsCompleted)
+func (h *handlers) ReportQuest(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	if err := auth.Authorize(r.Context(), policy.ReportQuest); err != nil {
+		return SuccesMessage{}, err
+	}
+	var reportQuest model.ReportQuest
+	if err := h.decode(w, r, &reportQuest); err != nil {
+		return SuccesMessage{}, err
+	}
+	if err := validation.Validate(
+		validation.Field("quest_id", reportQuest.QuestID, validation.Required, validID),
+		validation.Field("adv_id", reportQuest.AdventurerID, validID),
+		validation.Field("is_completed", reportQuest.IsCompleted, validation.Required),
+	); err != nil {
+		return SuccesMessage{}, err
+	}
+	advID, err := auth.Adventurer(r.Context(), reportQuest.AdventurerID)
+	if err != nil {
+		return SuccesMessage{}, err
+	}
+
+	err = h.usecase.ReportQuest(reportQuest.QuestID, advID, *reportQuest.IsCompleted)
 
 	if err != nil {
-		statusCode = http.StatusInternalServerError
-		resp.Header.Error = err.Error()
-		return
+		return SuccesMessage{}, err
 	}
-	statusCode = http.StatusOK
-	resp.Data.Success = true
+	return SuccesMessage{Success: true}, nil
 }
 
-func (h *handlers) GetQuestActiveAdventurer(w http.ResponseWriter, r *http.Request) {
-	var (
-		statusCode = http.StatusBadRequest
-		resp       GetQuestActiveAdventurer
-	)
-	resp.Data = []model.Quest{}
-	defer func() {
-		w.Header().Set("Content-Type", "application/json")
-		resp.StatusCode = statusCode
-		responseWriter, err := json.Marshal(resp)
-		if err != nil {
-			log.Fatal("Failed build response")
-		}
-		if statusCode == http.StatusOK {
-			w.Write(responseWriter)
-		} else {
-			http.Error(w, string(responseWriter), statusCode)
-		}
-	}()
+func (h *handlers) GetQuestActiveAdventurer(w http.ResponseWriter, r *http.Request) (interface{}, error) {
 	adv_id, err := strconv.Atoi(r.URL.Query().Get("adv_id"))
 	if err != nil {
-		resp.Header.Error = err.Error()
-		return
+		return []model.Quest{}, apperror.NewBadRequest(err.Error())
 	}
 	if adv_id <= 0 {
-		resp.Header.Error = "Invalid  id"
-		return
+		return []model.Quest{}, apperror.NewBadRequest("Invalid  id")
 	}
 
 	res, err := h.usecase.GetQuestActiveAdventurer(int64(adv_id))
 	if err != nil {
-		statusCode = http.StatusInternalServerError
-		resp.Header.Error = err.Error()
-		return
+		return []model.Quest{}, err
 	}
-	statusCode = http.StatusOK
-	resp.Data = res
+	return res, nil
+}
 
+func (h *handlers) CancelQuest(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	questID, ok := request.PathID(r)
+	if !ok {
+		return CancelMessage{ReleasedAdventurers: []int64{}}, apperror.NewBadRequest("quest id must be valid")
+	}
+	if err := auth.AuthorizeOwner(r.Context(), policy.CancelQuest, h.owner(questID)); err != nil {
+		return CancelMessage{ReleasedAdventurers: []int64{}}, err
+	}
+
+	released, err := h.usecase.CancelQuest(questID)
+	if err != nil {
+		return CancelMessage{ReleasedAdventurers: []int64{}}, err
+	}
+	for _, adv_id := range released {
+		h.logger.Infof("[Quest] quest %d cancelled, adventurer %d released", questID, adv_id)
+	}
+	return CancelMessage{Success: true, ReleasedAdventurers: released}, nil
 }
@@ -6,6 +6,7 @@ package quest
 
 import (
 	reflect "reflect"
+	time "time"
 
 	quest "github.com/arfaghifari/guild-board/src/model/quest"
 	gomock "github.com/golang/mock/gomock"
@@ -34,6 +35,21 @@ func (m *MockUsecase) EXPECT() *MockUsecaseMockRecorder {
 	return m.recorder
 }
 
+// CancelQuest mocks base method.
+func (m *MockUsecase) CancelQuest(arg0 int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelQuest", arg0)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelQuest indicates an expected call of CancelQuest.
+func (mr *MockUsecaseMockRecorder) CancelQuest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelQuest", reflect.TypeOf((*MockUsecase)(nil).CancelQuest), arg0)
+}
+
 // CreateQuest mocks base method.
 func (m *MockUsecase) CreateQuest(arg0 quest.Quest) (quest.Quest, error) {
 	m.ctrl.T.Helper()
@@ -63,6 +79,37 @@ func (mr *MockUsecaseMockRecorder) DeleteQuest(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuest", reflect.TypeOf((*MockUsecase)(nil).DeleteQuest), arg0)
 }
 
+// ExpireOverdueQuests mocks base method.
+func (m *MockUsecase) ExpireOverdueQuests(arg0 time.Duration) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireOverdueQuests", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ExpireOverdueQuests indicates an expected call of ExpireOverdueQuests.
+func (mr *MockUsecaseMockRecorder) ExpireOverdueQuests(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireOverdueQuests", reflect.TypeOf((*MockUsecase)(nil).ExpireOverdueQuests), arg0)
+}
+
+// GetQuest mocks base method.
+func (m *MockUsecase) GetQuest(arg0 int64) (quest.Quest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuest", arg0)
+	ret0, _ := ret[0].(quest.Quest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuest indicates an expected call of GetQuest.
+func (mr *MockUsecaseMockRecorder) GetQuest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuest", reflect.TypeOf((*MockUsecase)(nil).GetQuest), arg0)
+}
+
 // GetQuestActiveAdventurer mocks base method.
 func (m *MockUsecase) GetQuestActiveAdventurer(arg0 int64) ([]quest.Quest, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +140,83 @@ func (mr *MockUsecaseMockRecorder) GetQuestByStatus(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestByStatus", reflect.TypeOf((*MockUsecase)(nil).GetQuestByStatus), arg0)
 }
 
+// GetTakers mocks base method.
+func (m *MockUsecase) GetTakers(arg0 int64) ([]quest.TakenBy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTakers", arg0)
+	ret0, _ := ret[0].([]quest.TakenBy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTakers indicates an expected call of GetTakers.
+func (mr *MockUsecaseMockRecorder) GetTakers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTakers", reflect.TypeOf((*MockUsecase)(nil).GetTakers), arg0)
+}
+
+// ListAssignments mocks base method.
+func (m *MockUsecase) ListAssignments(arg0 quest.AssignmentFilter) ([]quest.Assignment, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAssignments", arg0)
+	ret0, _ := ret[0].([]quest.Assignment)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAssignments indicates an expected call of ListAssignments.
+func (mr *MockUsecaseMockRecorder) ListAssignments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssignments", reflect.TypeOf((*MockUsecase)(nil).ListAssignments), arg0)
+}
+
+// ListPostedQuests mocks base method.
+func (m *MockUsecase) ListPostedQuests(arg0 quest.QuestFilter) ([]quest.PostedQuest, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPostedQuests", arg0)
+	ret0, _ := ret[0].([]quest.PostedQuest)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListPostedQuests indicates an expected call of ListPostedQuests.
+func (mr *MockUsecaseMockRecorder) ListPostedQuests(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostedQuests", reflect.TypeOf((*MockUsecase)(nil).ListPostedQuests), arg0)
+}
+
+// ListQuests mocks base method.
+func (m *MockUsecase) ListQuests(arg0 quest.QuestFilter) ([]quest.Quest, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQuests", arg0)
+	ret0, _ := ret[0].([]quest.Quest)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListQuests indicates an expected call of ListQuests.
+func (mr *MockUsecaseMockRecorder) ListQuests(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQuests", reflect.TypeOf((*MockUsecase)(nil).ListQuests), arg0)
+}
+
+// PatchQuest mocks base method.
+func (m *MockUsecase) PatchQuest(arg0 quest.Quest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchQuest", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchQuest indicates an expected call of PatchQuest.
+func (mr *MockUsecaseMockRecorder) PatchQuest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchQuest", reflect.TypeOf((*MockUsecase)(nil).PatchQuest), arg0)
+}
+
 // ReportQuest mocks base method.
 func (m *MockUsecase) ReportQuest(arg0, arg1 int64, arg2 bool) error {
 	m.ctrl.T.Helper()
@@ -8,15 +8,25 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/config"
 	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/handlers/http/auth"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	"github.com/arfaghifari/guild-board/src/logger"
 	modelAdv "github.com/arfaghifari/guild-board/src/model/adventurer"
+	modelAuth "github.com/arfaghifari/guild-board/src/model/auth"
 	model "github.com/arfaghifari/guild-board/src/model/quest"
+	qstUsecase "github.com/arfaghifari/guild-board/src/usecase/quest"
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 )
 
+var testLogger, _ = logger.NewLogger("error")
+
 var adv = modelAdv.Adventurer{
 	ID:             1,
 	Name:           "andi",
@@ -24,6 +34,13 @@ var adv = modelAdv.Adventurer{
 	CompletedQuest: 1,
 }
 
+// caller is the adventurer authenticated on requests to take or report quests,
+// staff on the other requests changing quests.
+var (
+	caller = modelAuth.Principal{KeyID: 2, Name: "andi", Role: constant.RoleAdventurer, AdventurerID: adv.ID}
+	staff  = modelAuth.Principal{KeyID: 1, Name: "front desk", Role: constant.RoleStaff}
+)
+
 var bulkQuest = []model.Quest{
 	{
 		ID:           1,
@@ -51,6 +68,12 @@ var bulkQuest = []model.Quest{
 	},
 }
 
+func withDeadline(quest model.Quest) model.Quest {
+	deadline := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	quest.Deadline = &deadline
+	return quest
+}
+
 var bulkQuestByStatus = []model.GetQuestByStatus{
 	{
 		ID:           1,
@@ -76,9 +99,21 @@ var bulkQuestByStatus = []model.GetQuestByStatus{
 }
 
 func TestNewHandlers(t *testing.T) {
-	res, err := NewHandlers()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	appLogger, _ := logger.NewLogger("info")
+
+	res, err := NewHandlers(NewMockUsecase(mockCtrl), appLogger, config.Default().HTTP)
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
+
+	res, err = NewHandlers(nil, appLogger, config.Default().HTTP)
+	assert.Error(t, err)
+	assert.Nil(t, res)
+
+	res, err = NewHandlers(NewMockUsecase(mockCtrl), nil, config.Default().HTTP)
+	assert.Error(t, err)
+	assert.Nil(t, res)
 }
 
 func TestGetHello(t *testing.T) {
@@ -96,6 +131,21 @@ func TestGetHello(t *testing.T) {
 
 }
 
+var workingQuestByStatus = []model.GetQuestByStatus{
+	{
+		ID:           2,
+		Name:         "membersihkan selokan",
+		MinimumRank:  11,
+		RewardNumber: 200000,
+		Status:       constant.WorkingQuest,
+		MinMembers:   1,
+		MaxMembers:   1,
+		RankRule:     constant.RankRuleAll,
+		Members:      1,
+		Takers:       []model.Taker{{AdventurerID: 1, Name: "andi", Rank: 11}},
+	},
+}
+
 func TestGetQuestByStatus(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -208,7 +258,7 @@ func TestGetQuestByStatus(t *testing.T) {
 			wantErr:        true,
 		},
 		{
-			name: "query not valid",
+			name: "success get working quest",
 			fields: fields{
 				u: NewMockUsecase(mockCtrl),
 			},
@@ -216,12 +266,31 @@ func TestGetQuestByStatus(t *testing.T) {
 				is:          true,
 				statusQuery: "1",
 			},
+			resp: responses{
+				body: workingQuestByStatus,
+			},
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetQuestByStatus(int32(constant.WorkingQuest)).Return(workingQuestByStatus, nil).Times(1)
+			},
+			wantStatusCode: http.StatusOK,
+			wantErr:        false,
+		},
+		{
+			name: "query not valid",
+			fields: fields{
+				u: NewMockUsecase(mockCtrl),
+			},
+			req: requests{
+				is:          true,
+				statusQuery: "7",
+			},
 			resp: responses{
 				body: []model.GetQuestByStatus{},
 			},
 			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetQuestByStatus(int32(7)).Return([]model.GetQuestByStatus{}, qstUsecase.ErrInvalidStatus).Times(1)
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 	}
@@ -231,8 +300,9 @@ func TestGetQuestByStatus(t *testing.T) {
 			router := mux.NewRouter()
 			h := &handlers{
 				usecase: tt.fields.u,
+				logger:  testLogger,
 			}
-			router.HandleFunc("/quest-status", h.GetQuestByStatus).Methods(http.MethodGet)
+			router.HandleFunc("/quest-status", response.Handle(testLogger, h.GetQuestByStatus)).Methods(http.MethodGet)
 			recorder := httptest.NewRecorder()
 			request, _ := http.NewRequest("GET", "/quest-status", strings.NewReader(``))
 			if tt.req.is {
@@ -328,7 +398,7 @@ func TestCreateQuest(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -345,7 +415,7 @@ func TestCreateQuest(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -362,7 +432,7 @@ func TestCreateQuest(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -379,7 +449,7 @@ func TestCreateQuest(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -396,7 +466,7 @@ func TestCreateQuest(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -413,7 +483,7 @@ func TestCreateQuest(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -435,15 +505,54 @@ func TestCreateQuest(t *testing.T) {
 			wantStatusCode: http.StatusInternalServerError,
 			wantErr:        true,
 		},
+		{
+			name: "success created a quest with deadline",
+			fields: fields{
+				u: NewMockUsecase(mockCtrl),
+			},
+			req: requests{
+				body: `{"name" : "menyelamatkan kucing",  "description" : "menyelamatkan kucing yang terjebak di atas pohon" , "minimum_rank" : 11, "reward_number" : 200000, "deadline" : "2030-01-01T00:00:00Z"}`,
+			},
+			resp: responses{
+				body: withDeadline(bulkQuest[0]),
+			},
+			mock: func(usecase *MockUsecase) {
+				quest := withDeadline(bulkQuest[0])
+				quest.ID = 0
+				usecase.EXPECT().CreateQuest(quest).Return(withDeadline(bulkQuest[0]), nil).Times(1)
+			},
+			wantStatusCode: http.StatusOK,
+			wantErr:        false,
+		},
+		{
+			name: "deadline in the past",
+			fields: fields{
+				u: NewMockUsecase(mockCtrl),
+			},
+			req: requests{
+				body: `{"name" : "menyelamatkan kucing",  "description" : "menyelamatkan kucing yang terjebak di atas pohon" , "minimum_rank" : 11, "reward_number" : 200000, "deadline" : "2030-01-01T00:00:00Z"}`,
+			},
+			resp: responses{
+				body: model.Quest{},
+			},
+			mock: func(usecase *MockUsecase) {
+				quest := withDeadline(bulkQuest[0])
+				quest.ID = 0
+				usecase.EXPECT().CreateQuest(quest).Return(model.Quest{}, qstUsecase.ErrPastDeadline).Times(1)
+			},
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantErr:        true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx := context.Background()
+			ctx := auth.WithPrincipal(context.Background(), staff)
 			router := mux.NewRouter()
 			h := &handlers{
 				usecase: tt.fields.u,
+				logger:  testLogger,
 			}
-			router.HandleFunc("/quest", h.CreateQuest).Methods(http.MethodPost)
+			router.HandleFunc("/quest", response.Handle(testLogger, h.CreateQuest)).Methods(http.MethodPost)
 			recorder := httptest.NewRecorder()
 			request, _ := http.NewRequest("POST", "/quest", strings.NewReader(tt.req.body))
 			request = request.WithContext(ctx)
@@ -462,6 +571,73 @@ func TestCreateQuest(t *testing.T) {
 	}
 }
 
+func TestCreateQuestInvalidFields(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	h := &handlers{usecase: NewMockUsecase(mockCtrl), logger: testLogger}
+	router := mux.NewRouter()
+	router.HandleFunc("/quest", response.Handle(testLogger, h.CreateQuest)).Methods(http.MethodPost)
+	tests := []struct {
+		name       string
+		body       string
+		wantFields []apperror.FieldError
+	}{
+		{
+			name: "every broken field",
+			body: `{"name": "", "description": "` + strings.Repeat("x", constant.MaxDescriptionLength+1) + `", "minimum_rank": 101, "reward_number": -5}`,
+			wantFields: []apperror.FieldError{
+				{Field: "name", Message: "is required"},
+				{Field: "description", Message: "must be at most 2000 characters"},
+				{Field: "minimum_rank", Message: "must be between 1 and 100"},
+				{Field: "reward_number", Message: "must be between 1 and 1000000000"},
+			},
+		},
+		{
+			name:       "unknown field",
+			body:       `{"name": "menyelamatkan kucing", "minimum_rank": 11, "reward_number": 200000, "giver": "andi"}`,
+			wantFields: []apperror.FieldError{{Field: "giver", Message: "is not a known field"}},
+		},
+		{
+			name:       "wrong type",
+			body:       `{"name": "menyelamatkan kucing", "minimum_rank": "S", "reward_number": 200000}`,
+			wantFields: []apperror.FieldError{{Field: "minimum_rank", Message: "must be a whole number"}},
+		},
+		{
+			name:       "server owned fields",
+			body:       `{"name": "menyelamatkan kucing", "minimum_rank": 11, "reward_number": 200000, "status": 2, "members": 5, "created_by": 7}`,
+			wantFields: []apperror.FieldError{{Field: "status", Message: "is not a known field"}},
+		},
+		{
+			name: "every broken party field",
+			body: `{"name": "menyelamatkan kucing", "minimum_rank": 11, "reward_number": 200000, "min_members": -1, "max_members": -2, "rank_rule": "leader", "deadline": "2020-01-01T00:00:00Z"}`,
+			wantFields: []apperror.FieldError{
+				{Field: "deadline", Message: "must be in the future"},
+				{Field: "min_members", Message: "must be at least 1"},
+				{Field: "max_members", Message: "must be at least 1"},
+				{Field: "rank_rule", Message: "must be one of all, average"},
+			},
+		},
+		{
+			name:       "party larger at least than at most",
+			body:       `{"name": "menyelamatkan kucing", "minimum_rank": 11, "reward_number": 200000, "min_members": 3, "max_members": 2}`,
+			wantFields: []apperror.FieldError{{Field: "max_members", Message: "must be at least 3"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/quest", strings.NewReader(tt.body))
+			router.ServeHTTP(recorder, request.WithContext(auth.WithPrincipal(request.Context(), staff)))
+			var resp QuestResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			assert.Equal(t, apperror.CodeInvalidFields, resp.Header.Error)
+			assert.Equal(t, tt.wantFields, resp.Header.Fields)
+		})
+	}
+}
+
 func TestDeleteQuest(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -535,7 +711,7 @@ func TestDeleteQuest(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -552,7 +728,7 @@ func TestDeleteQuest(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -575,12 +751,13 @@ func TestDeleteQuest(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx := context.Background()
+			ctx := auth.WithPrincipal(context.Background(), staff)
 			router := mux.NewRouter()
 			h := &handlers{
 				usecase: tt.fields.u,
+				logger:  testLogger,
 			}
-			router.HandleFunc("/quest", h.DeleteQuest).Methods(http.MethodDelete)
+			router.HandleFunc("/quest", response.Handle(testLogger, h.DeleteQuest)).Methods(http.MethodDelete)
 			recorder := httptest.NewRecorder()
 			request, _ := http.NewRequest("DELETE", "/quest", strings.NewReader(tt.req.body))
 			request = request.WithContext(ctx)
@@ -673,7 +850,7 @@ func TestUpdateQuestRank(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -690,7 +867,7 @@ func TestUpdateQuestRank(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -707,7 +884,7 @@ func TestUpdateQuestRank(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -724,7 +901,7 @@ func TestUpdateQuestRank(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -747,12 +924,13 @@ func TestUpdateQuestRank(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx := context.Background()
+			ctx := auth.WithPrincipal(context.Background(), staff)
 			router := mux.NewRouter()
 			h := &handlers{
 				usecase: tt.fields.u,
+				logger:  testLogger,
 			}
-			router.HandleFunc("/quest-rank", h.UpdateQuestRank).Methods(http.MethodPatch)
+			router.HandleFunc("/quest-rank", response.Handle(testLogger, h.UpdateQuestRank)).Methods(http.MethodPatch)
 			recorder := httptest.NewRecorder()
 			request, _ := http.NewRequest("PATCH", "/quest-rank", strings.NewReader(tt.req.body))
 			request = request.WithContext(ctx)
@@ -845,7 +1023,7 @@ func TestUpdateQuestReward(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -862,7 +1040,7 @@ func TestUpdateQuestReward(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -879,7 +1057,7 @@ func TestUpdateQuestReward(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -896,7 +1074,7 @@ func TestUpdateQuestReward(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -919,12 +1097,13 @@ func TestUpdateQuestReward(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx := context.Background()
+			ctx := auth.WithPrincipal(context.Background(), staff)
 			router := mux.NewRouter()
 			h := &handlers{
 				usecase: tt.fields.u,
+				logger:  testLogger,
 			}
-			router.HandleFunc("/quest-reward", h.UpdateQuestReward).Methods(http.MethodPatch)
+			router.HandleFunc("/quest-reward", response.Handle(testLogger, h.UpdateQuestReward)).Methods(http.MethodPatch)
 			recorder := httptest.NewRecorder()
 			request, _ := http.NewRequest("PATCH", "/quest-reward", strings.NewReader(tt.req.body))
 			request = request.WithContext(ctx)
@@ -1015,7 +1194,7 @@ func TestTakeQuest(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -1032,24 +1211,41 @@ func TestTakeQuest(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
-			name: "empty adv_id",
+			name: "success adv_id left to the caller",
 			fields: fields{
 				u: NewMockUsecase(mockCtrl),
 			},
 			req: requests{
 				body: `{"quest_id": 1}`,
 			},
+			resp: responses{
+				body: SuccesMessage{Success: true},
+			},
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().TakeQuest(bulkQuest[0].ID, adv.ID).Return(nil).Times(1)
+			},
+			wantStatusCode: http.StatusOK,
+			wantErr:        false,
+		},
+		{
+			name: "another adventurer",
+			fields: fields{
+				u: NewMockUsecase(mockCtrl),
+			},
+			req: requests{
+				body: `{"quest_id": 1, "adv_id" : 2}`,
+			},
 			resp: responses{
 				body: SuccesMessage{Success: false},
 			},
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusForbidden,
 			wantErr:        true,
 		},
 		{
@@ -1066,7 +1262,7 @@ func TestTakeQuest(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -1088,15 +1284,50 @@ func TestTakeQuest(t *testing.T) {
 			wantStatusCode: http.StatusInternalServerError,
 			wantErr:        true,
 		},
+		{
+			name: "quest expired",
+			fields: fields{
+				u: NewMockUsecase(mockCtrl),
+			},
+			req: requests{
+				body: `{"quest_id": 1, "adv_id" : 1}`,
+			},
+			resp: responses{
+				body: SuccesMessage{Success: false},
+			},
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().TakeQuest(bulkQuest[0].ID, adv.ID).Return(qstUsecase.ErrQuestExpired).Times(1)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantErr:        true,
+		},
+		{
+			name: "already in the party",
+			fields: fields{
+				u: NewMockUsecase(mockCtrl),
+			},
+			req: requests{
+				body: `{"quest_id": 1, "adv_id" : 1}`,
+			},
+			resp: responses{
+				body: SuccesMessage{Success: false},
+			},
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().TakeQuest(bulkQuest[0].ID, adv.ID).Return(qstUsecase.ErrAlreadyJoined).Times(1)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantErr:        true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx := context.Background()
+			ctx := auth.WithPrincipal(context.Background(), caller)
 			router := mux.NewRouter()
 			h := &handlers{
 				usecase: tt.fields.u,
+				logger:  testLogger,
 			}
-			router.HandleFunc("/take-quest", h.TakeQuest).Methods(http.MethodPost)
+			router.HandleFunc("/take-quest", response.Handle(testLogger, h.TakeQuest)).Methods(http.MethodPost)
 			recorder := httptest.NewRecorder()
 			request, _ := http.NewRequest("POST", "/take-quest", strings.NewReader(tt.req.body))
 			request = request.WithContext(ctx)
@@ -1206,7 +1437,7 @@ func TestReportQuest(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -1223,24 +1454,41 @@ func TestReportQuest(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
-			name: "empty adv_id",
+			name: "success adv_id left to the caller",
 			fields: fields{
 				u: NewMockUsecase(mockCtrl),
 			},
 			req: requests{
 				body: `{"quest_id": 1,  "is_completed" : true}`,
 			},
+			resp: responses{
+				body: SuccesMessage{Success: true},
+			},
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ReportQuest(bulkQuest[0].ID, adv.ID, true).Return(nil).Times(1)
+			},
+			wantStatusCode: http.StatusOK,
+			wantErr:        false,
+		},
+		{
+			name: "another adventurer",
+			fields: fields{
+				u: NewMockUsecase(mockCtrl),
+			},
+			req: requests{
+				body: `{"quest_id": 1, "adv_id" : 2, "is_completed" : true}`,
+			},
 			resp: responses{
 				body: SuccesMessage{Success: false},
 			},
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusForbidden,
 			wantErr:        true,
 		},
 		{
@@ -1257,7 +1505,7 @@ func TestReportQuest(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -1274,7 +1522,7 @@ func TestReportQuest(t *testing.T) {
 			mock: func(usecase *MockUsecase) {
 
 			},
-			wantStatusCode: http.StatusBadRequest,
+			wantStatusCode: http.StatusUnprocessableEntity,
 			wantErr:        true,
 		},
 		{
@@ -1299,12 +1547,13 @@ func TestReportQuest(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx := context.Background()
+			ctx := auth.WithPrincipal(context.Background(), caller)
 			router := mux.NewRouter()
 			h := &handlers{
 				usecase: tt.fields.u,
+				logger:  testLogger,
 			}
-			router.HandleFunc("/report-quest", h.ReportQuest).Methods(http.MethodPost)
+			router.HandleFunc("/report-quest", response.Handle(testLogger, h.ReportQuest)).Methods(http.MethodPost)
 			recorder := httptest.NewRecorder()
 			request, _ := http.NewRequest("POST", "/report-quest", strings.NewReader(tt.req.body))
 			request = request.WithContext(ctx)
@@ -1440,8 +1689,9 @@ func TestGetActiveAdventurer(t *testing.T) {
 			router := mux.NewRouter()
 			h := &handlers{
 				usecase: tt.fields.u,
+				logger:  testLogger,
 			}
-			router.HandleFunc("/quest-active-adv", h.GetQuestActiveAdventurer).Methods(http.MethodGet)
+			router.HandleFunc("/quest-active-adv", response.Handle(testLogger, h.GetQuestActiveAdventurer)).Methods(http.MethodGet)
 			recorder := httptest.NewRecorder()
 			request, _ := http.NewRequest("GET", "/quest-active-adv", strings.NewReader(``))
 			if tt.req.is {
@@ -1464,3 +1714,251 @@ func TestGetActiveAdventurer(t *testing.T) {
 		})
 	}
 }
+
+func TestCancelQuest(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	appLogger, _ := logger.NewLogger("error")
+	type fields struct {
+		u *MockUsecase
+	}
+	type responses struct {
+		body CancelMessage
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		path           string
+		resp           responses
+		mock           func(*MockUsecase)
+		wantStatusCode int
+		wantErr        bool
+	}{
+		{
+			name: "success cancelled a quest",
+			fields: fields{
+				u: NewMockUsecase(mockCtrl),
+			},
+			path: "/quest/4/cancel",
+			resp: responses{
+				body: CancelMessage{Success: true, ReleasedAdventurers: []int64{adv.ID}},
+			},
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().CancelQuest(int64(4)).Return([]int64{adv.ID}, nil).Times(1)
+			},
+			wantStatusCode: http.StatusOK,
+			wantErr:        false,
+		},
+		{
+			name: "id not int",
+			fields: fields{
+				u: NewMockUsecase(mockCtrl),
+			},
+			path: "/quest/a/cancel",
+			resp: responses{
+				body: CancelMessage{ReleasedAdventurers: []int64{}},
+			},
+			mock: func(usecase *MockUsecase) {
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantErr:        true,
+		},
+		{
+			name: "quest not found",
+			fields: fields{
+				u: NewMockUsecase(mockCtrl),
+			},
+			path: "/quest/9/cancel",
+			resp: responses{
+				body: CancelMessage{ReleasedAdventurers: []int64{}},
+			},
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().CancelQuest(int64(9)).Return(nil, qstUsecase.ErrQuestNotFound).Times(1)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantErr:        true,
+		},
+		{
+			name: "quest completed",
+			fields: fields{
+				u: NewMockUsecase(mockCtrl),
+			},
+			path: "/quest/3/cancel",
+			resp: responses{
+				body: CancelMessage{ReleasedAdventurers: []int64{}},
+			},
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().CancelQuest(int64(3)).Return(nil, qstUsecase.ErrQuestDone).Times(1)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantErr:        true,
+		},
+		{
+			name: "error at layer usecase",
+			fields: fields{
+				u: NewMockUsecase(mockCtrl),
+			},
+			path: "/quest/1/cancel",
+			resp: responses{
+				body: CancelMessage{ReleasedAdventurers: []int64{}},
+			},
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().CancelQuest(bulkQuest[0].ID).Return(nil, errors.New("any error")).Times(1)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := auth.WithPrincipal(context.Background(), staff)
+			router := mux.NewRouter()
+			h := &handlers{
+				usecase: tt.fields.u,
+				logger:  appLogger,
+			}
+			router.HandleFunc("/quest/{id}/cancel", response.Handle(testLogger, h.CancelQuest)).Methods(http.MethodPost)
+			recorder := httptest.NewRecorder()
+			request, _ := http.NewRequest("POST", tt.path, strings.NewReader(``))
+			request = request.WithContext(ctx)
+			tt.mock(tt.fields.u)
+			router.ServeHTTP(recorder, request)
+			var resp CancelResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code, "error code")
+			assert.Equal(t, tt.resp.body, resp.Data)
+			if tt.wantErr {
+				assert.NotEqual(t, "", resp.Header.Error, "error message")
+			} else {
+				assert.Equal(t, "", resp.Header.Error, "error message")
+			}
+		})
+	}
+}
+
+func TestPermissions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	giver := modelAuth.Principal{KeyID: 3, Name: "dewi", Role: constant.RoleGiver}
+	h := &handlers{usecase: NewMockUsecase(mockCtrl), logger: testLogger}
+	tests := []struct {
+		name      string
+		handler   response.Handler
+		principal modelAuth.Principal
+		body      string
+	}{
+		{name: "adventurer creates a quest", handler: h.CreateQuest, principal: caller, body: `{"name":"menyelamatkan kucing","minimum_rank":11,"reward_number":200000}`},
+		{name: "adventurer deletes a quest", handler: h.DeleteQuest, principal: caller, body: `{"quest_id":1}`},
+		{name: "quest giver deletes a quest", handler: h.RemoveQuest, principal: giver},
+		{name: "quest giver changes the rank", handler: h.UpdateQuestRank, principal: giver, body: `{"quest_id":1,"minimum_rank":12}`},
+		{name: "adventurer changes the reward", handler: h.UpdateQuestReward, principal: caller, body: `{"quest_id":1,"reward_number":1}`},
+		{name: "quest giver patches a quest", handler: h.PatchQuest, principal: giver, body: `{"reward_number":1}`},
+		{name: "adventurer cancels a quest", handler: h.CancelQuest, principal: caller},
+		{name: "staff takes a quest", handler: h.TakeQuest, principal: staff, body: `{"quest_id":1,"adv_id":1}`},
+		{name: "quest giver joins a party", handler: h.AddTaker, principal: giver, body: `{"adv_id":1}`},
+		{name: "staff reports a quest", handler: h.ReportQuest, principal: staff, body: `{"quest_id":1,"adv_id":1,"is_completed":true}`},
+		{name: "quest giver submits a report", handler: h.SubmitReport, principal: giver, body: `{"adv_id":1,"is_completed":true}`},
+		{name: "anonymous creates a quest", handler: h.CreateQuest, body: `{"name":"menyelamatkan kucing","minimum_rank":11,"reward_number":200000}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serveV2(tt.handler, tt.principal, http.MethodPost, "/quests/{id}", "/quests/1", tt.body)
+			var resp MessageResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, http.StatusForbidden, recorder.Code)
+			assert.Equal(t, "permission_denied", resp.Header.Error)
+		})
+	}
+}
+
+func TestOwnerPermissions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mayor := modelAuth.Principal{KeyID: 3, Name: "mayor", Role: constant.RoleGiver, GiverID: 1}
+	owned := model.Quest{ID: 1, Name: "menyelamatkan kucing", MinimumRank: 11, RewardNumber: 200000, CreatedBy: mayor.GiverID}
+	tests := []struct {
+		name       string
+		handler    func(*handlers) response.Handler
+		body       string
+		mock       func(*MockUsecase)
+		wantStatus int
+	}{
+		{
+			name:    "giver posts a quest of its own",
+			handler: func(h *handlers) response.Handler { return h.CreateQuest },
+			body:    `{"name":"menyelamatkan kucing","minimum_rank":11,"reward_number":200000}`,
+			mock: func(u *MockUsecase) {
+				u.EXPECT().CreateQuest(model.Quest{Name: owned.Name, MinimumRank: owned.MinimumRank, RewardNumber: owned.RewardNumber, CreatedBy: mayor.GiverID}).
+					Return(owned, nil).Times(1)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:    "giver patches its quest",
+			handler: func(h *handlers) response.Handler { return h.PatchQuest },
+			body:    `{"reward_number":300000}`,
+			mock: func(u *MockUsecase) {
+				u.EXPECT().GetQuest(owned.ID).Return(owned, nil).Times(1)
+				u.EXPECT().PatchQuest(model.Quest{ID: owned.ID, RewardNumber: 300000}).Return(nil).Times(1)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:    "giver changes the rank of its quest",
+			handler: func(h *handlers) response.Handler { return h.UpdateQuestRank },
+			body:    `{"quest_id":1,"minimum_rank":12}`,
+			mock: func(u *MockUsecase) {
+				u.EXPECT().GetQuest(owned.ID).Return(owned, nil).Times(1)
+				u.EXPECT().UpdateQuestRank(model.Quest{ID: owned.ID, MinimumRank: 12}).Return(nil).Times(1)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:    "giver cancels its quest",
+			handler: func(h *handlers) response.Handler { return h.CancelQuest },
+			mock: func(u *MockUsecase) {
+				u.EXPECT().GetQuest(owned.ID).Return(owned, nil).Times(1)
+				u.EXPECT().CancelQuest(owned.ID).Return([]int64{}, nil).Times(1)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:    "giver patches the quest of another giver",
+			handler: func(h *handlers) response.Handler { return h.PatchQuest },
+			body:    `{"reward_number":300000}`,
+			mock: func(u *MockUsecase) {
+				u.EXPECT().GetQuest(owned.ID).Return(model.Quest{ID: owned.ID, CreatedBy: 2}, nil).Times(1)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:    "giver cancels a quest of the guild",
+			handler: func(h *handlers) response.Handler { return h.CancelQuest },
+			mock: func(u *MockUsecase) {
+				u.EXPECT().GetQuest(owned.ID).Return(model.Quest{ID: owned.ID}, nil).Times(1)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:    "giver patches an unknown quest",
+			handler: func(h *handlers) response.Handler { return h.PatchQuest },
+			body:    `{"reward_number":300000}`,
+			mock: func(u *MockUsecase) {
+				u.EXPECT().GetQuest(owned.ID).Return(model.Quest{}, qstUsecase.ErrQuestNotFound).Times(1)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			tt.mock(u)
+			h := &handlers{usecase: u, logger: testLogger}
+			recorder := serveV2(tt.handler(h), mayor, http.MethodPost, "/quests/{id}", "/quests/1", tt.body)
+			assert.Equal(t, tt.wantStatus, recorder.Code, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package quest
+
+import (
+	"net/http"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/handlers/http/auth"
+	"github.com/arfaghifari/guild-board/src/handlers/http/request"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+	"github.com/arfaghifari/guild-board/src/policy"
+	"github.com/arfaghifari/guild-board/src/validation"
+)
+
+type TakersResponse struct {
+	response.Header `json:"header"`
+	Data            []model.TakenBy `json:"data"`
+}
+
+func (h *handlers) GetQuest(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	questID, ok := request.PathID(r)
+	if !ok {
+		return model.Quest{}, apperror.NewBadRequest("quest id must be valid")
+	}
+
+	res, err := h.usecase.GetQuest(questID)
+	if err != nil {
+		return model.Quest{}, err
+	}
+	return res, nil
+}
+
+func (h *handlers) RemoveQuest(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	if err := auth.Authorize(r.Context(), policy.DeleteQuest); err != nil {
+		return SuccesMessage{}, err
+	}
+	questID, ok := request.PathID(r)
+	if !ok {
+		return SuccesMessage{}, apperror.NewBadRequest("quest id must be valid")
+	}
+
+	if err := h.usecase.DeleteQuest(model.Quest{ID: questID}); err != nil {
+		return SuccesMessage{}, err
+	}
+	return SuccesMessage{Success: true}, nil
+}
+
+// PatchQuest changes the minimum rank, the reward or both.
+func (h *handlers) PatchQuest(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	var quest model.Quest
+	questID, ok := request.PathID(r)
+	if !ok {
+		return SuccesMessage{}, apperror.NewBadRequest("quest id must be valid")
+	}
+	if err := auth.AuthorizeOwner(r.Context(), policy.EditQuest, h.owner(questID)); err != nil {
+		return SuccesMessage{}, err
+	}
+	if err := h.decode(w, r, &quest); err != nil {
+		return SuccesMessage{}, err
+	}
+	if err := validation.Validate(
+		validation.Field("minimum_rank", quest.MinimumRank, validation.RequiredWithout("reward_number", quest.RewardNumber), validRank),
+		validation.Field("reward_number", quest.RewardNumber, validation.RequiredWithout("minimum_rank", quest.MinimumRank), validReward),
+	); err != nil {
+		return SuccesMessage{}, err
+	}
+	quest.ID = questID
+
+	if err := h.usecase.PatchQuest(quest); err != nil {
+		return SuccesMessage{}, err
+	}
+	return SuccesMessage{Success: true}, nil
+}
+
+func (h *handlers) GetTakers(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	questID, ok := request.PathID(r)
+	if !ok {
+		return []model.TakenBy{}, apperror.NewBadRequest("quest id must be valid")
+	}
+
+	res, err := h.usecase.GetTakers(questID)
+	if err != nil {
+		return []model.TakenBy{}, err
+	}
+	return res, nil
+}
+
+// AddTaker lets the calling adventurer join the quest's party.
+func (h *handlers) AddTaker(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	if err := auth.Authorize(r.Context(), policy.TakeQuest); err != nil {
+		return SuccesMessage{}, err
+	}
+	var taker model.TakenBy
+	questID, ok := request.PathID(r)
+	if !ok {
+		return SuccesMessage{}, apperror.NewBadRequest("quest id must be valid")
+	}
+	if err := h.decode(w, r, &taker); err != nil {
+		return SuccesMessage{}, err
+	}
+	if err := validation.Validate(
+		validation.Field("adv_id", taker.AdventurerID, validID),
+	); err != nil {
+		return SuccesMessage{}, err
+	}
+	advID, err := auth.Adventurer(r.Context(), taker.AdventurerID)
+	if err != nil {
+		return SuccesMessage{}, err
+	}
+
+	if err := h.usecase.TakeQuest(questID, advID); err != nil {
+		return SuccesMessage{}, err
+	}
+	return SuccesMessage{Success: true}, nil
+}
+
+// SubmitReport reports the quest completed or failed on behalf of the calling
+// adventurer.
+func (h *handlers) SubmitReport(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	if err := auth.Authorize(r.Context(), policy.ReportQuest); err != nil {
+		return SuccesMessage{}, err
+	}
+	var report model.ReportQuest
+	questID, ok := request.PathID(r)
+	if !ok {
+		return SuccesMessage{}, apperror.NewBadRequest("quest id must be valid")
+	}
+	if err := h.decode(w, r, &report); err != nil {
+		return SuccesMessage{}, err
+	}
+	if err := validation.Validate(
+		validation.Field("adv_id", report.AdventurerID, validID),
+		validation.Field("is_completed", report.IsCompleted, validation.Required),
+	); err != nil {
+		return SuccesMessage{}, err
+	}
+	advID, err := auth.Adventurer(r.Context(), report.AdventurerID)
+	if err != nil {
+		return SuccesMessage{}, err
+	}
+
+	if err := h.usecase.ReportQuest(questID, advID, *report.IsCompleted); err != nil {
+		return SuccesMessage{}, err
+	}
+	return SuccesMessage{Success: true}, nil
+}
+
+// GetAdventurerQuests lists the quests the adventurer is working on.
+func (h *handlers) GetAdventurerQuests(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+	advID, ok := request.PathID(r)
+	if !ok {
+		return []model.Quest{}, apperror.NewBadRequest("adventurer id must be valid")
+	}
+
+	res, err := h.usecase.GetQuestActiveAdventurer(advID)
+	if err != nil {
+		return []model.Quest{}, err
+	}
+	return res, nil
+}
@@ -0,0 +1,530 @@
+package quest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/handlers/http/auth"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	modelAuth "github.com/arfaghifari/guild-board/src/model/auth"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+	qstUsecase "github.com/arfaghifari/guild-board/src/usecase/quest"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// serveV2 routes a single request of principal to handler registered on
+// pattern.
+func serveV2(handler response.Handler, principal modelAuth.Principal, method, pattern, path, body string) *httptest.ResponseRecorder {
+	router := mux.NewRouter()
+	router.HandleFunc(pattern, response.Handle(testLogger, handler)).Methods(method)
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	router.ServeHTTP(recorder, request.WithContext(auth.WithPrincipal(request.Context(), principal)))
+	return recorder
+}
+
+func TestGetQuest(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name           string
+		path           string
+		mock           func(*MockUsecase)
+		outQuest       model.Quest
+		wantStatusCode int
+	}{
+		{
+			name: "success get a quest",
+			path: "/v2/quests/1",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetQuest(bulkQuest[0].ID).Return(bulkQuest[0], nil).Times(1)
+			},
+			outQuest:       bulkQuest[0],
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "quest not found",
+			path: "/v2/quests/1",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetQuest(bulkQuest[0].ID).Return(model.Quest{}, qstUsecase.ErrQuestNotFound).Times(1)
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "error at layer usecase",
+			path: "/v2/quests/1",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetQuest(bulkQuest[0].ID).Return(model.Quest{}, errors.New("any error")).Times(1)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "id not valid",
+			path:           "/v2/quests/0",
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			h := &handlers{usecase: u, logger: testLogger}
+			tt.mock(u)
+			recorder := serveV2(h.GetQuest, caller, http.MethodGet, "/v2/quests/{id}", tt.path, ``)
+			var resp QuestResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code, "error code")
+			assert.Equal(t, tt.outQuest, resp.Data)
+			assert.Equal(t, tt.wantStatusCode != http.StatusOK, resp.Header.Error != "", "error message")
+		})
+	}
+}
+
+func TestRemoveQuest(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name           string
+		path           string
+		mock           func(*MockUsecase)
+		wantStatusCode int
+	}{
+		{
+			name: "success removed a quest",
+			path: "/v2/quests/1",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().DeleteQuest(model.Quest{ID: 1}).Return(nil).Times(1)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "error at layer usecase",
+			path: "/v2/quests/1",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().DeleteQuest(model.Quest{ID: 1}).Return(errors.New("any error")).Times(1)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "id not int",
+			path:           "/v2/quests/a",
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			h := &handlers{usecase: u, logger: testLogger}
+			tt.mock(u)
+			recorder := serveV2(h.RemoveQuest, staff, http.MethodDelete, "/v2/quests/{id}", tt.path, ``)
+			var resp MessageResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code, "error code")
+			assert.Equal(t, tt.wantStatusCode == http.StatusOK, resp.Data.Success)
+		})
+	}
+}
+
+func TestPatchQuest(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name           string
+		body           string
+		mock           func(*MockUsecase)
+		wantStatusCode int
+	}{
+		{
+			name: "success update rank",
+			body: `{"minimum_rank": 12}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().PatchQuest(model.Quest{ID: 1, MinimumRank: 12}).Return(nil).Times(1)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "success update reward",
+			body: `{"reward_number": 300000}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().PatchQuest(model.Quest{ID: 1, RewardNumber: 300000}).Return(nil).Times(1)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "success update rank and reward",
+			body: `{"minimum_rank": 12, "reward_number": 300000}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().PatchQuest(model.Quest{ID: 1, MinimumRank: 12, RewardNumber: 300000}).Return(nil).Times(1)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "quest not found",
+			body: `{"minimum_rank": 12}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().PatchQuest(model.Quest{ID: 1, MinimumRank: 12}).Return(qstUsecase.ErrQuestNotFound).Times(1)
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "error update",
+			body: `{"minimum_rank": 12, "reward_number": 300000}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().PatchQuest(model.Quest{ID: 1, MinimumRank: 12, RewardNumber: 300000}).Return(errors.New("any error")).Times(1)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "nothing to update",
+			body:           `{}`,
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "negative reward",
+			body:           `{"minimum_rank": 12, "reward_number": -1}`,
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "json failed",
+			body:           `{`,
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			h := &handlers{usecase: u, logger: testLogger}
+			tt.mock(u)
+			recorder := serveV2(h.PatchQuest, staff, http.MethodPatch, "/v2/quests/{id}", "/v2/quests/1", tt.body)
+			var resp MessageResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code, "error code")
+			assert.Equal(t, tt.wantStatusCode == http.StatusOK, resp.Data.Success)
+		})
+	}
+}
+
+func TestGetTakers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	takers := []model.TakenBy{{QuestID: 1, AdventurerID: adv.ID}}
+	tests := []struct {
+		name           string
+		path           string
+		mock           func(*MockUsecase)
+		outTakers      []model.TakenBy
+		wantStatusCode int
+	}{
+		{
+			name: "success get takers",
+			path: "/v2/quests/1/takers",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetTakers(int64(1)).Return(takers, nil).Times(1)
+			},
+			outTakers:      takers,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "quest not found",
+			path: "/v2/quests/1/takers",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetTakers(int64(1)).Return(nil, qstUsecase.ErrQuestNotFound).Times(1)
+			},
+			outTakers:      []model.TakenBy{},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "error at layer usecase",
+			path: "/v2/quests/1/takers",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetTakers(int64(1)).Return(nil, errors.New("any error")).Times(1)
+			},
+			outTakers:      []model.TakenBy{},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "id not valid",
+			path:           "/v2/quests/-1/takers",
+			mock:           func(usecase *MockUsecase) {},
+			outTakers:      []model.TakenBy{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			h := &handlers{usecase: u, logger: testLogger}
+			tt.mock(u)
+			recorder := serveV2(h.GetTakers, caller, http.MethodGet, "/v2/quests/{id}/takers", tt.path, ``)
+			var resp TakersResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code, "error code")
+			assert.Equal(t, tt.outTakers, resp.Data)
+		})
+	}
+}
+
+func TestAddTaker(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		mock           func(*MockUsecase)
+		wantStatusCode int
+		wantErrorCode  string
+	}{
+		{
+			name: "success joined a quest",
+			path: "/v2/quests/1/takers",
+			body: `{"adv_id": 1}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().TakeQuest(int64(1), adv.ID).Return(nil).Times(1)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "already in the party",
+			path: "/v2/quests/1/takers",
+			body: `{"adv_id": 1}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().TakeQuest(int64(1), adv.ID).Return(qstUsecase.ErrAlreadyJoined).Times(1)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantErrorCode:  "already_joined",
+		},
+		{
+			name: "rank too low",
+			path: "/v2/quests/1/takers",
+			body: `{"adv_id": 1}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().TakeQuest(int64(1), adv.ID).Return(qstUsecase.ErrRankTooLow).Times(1)
+			},
+			wantStatusCode: http.StatusForbidden,
+			wantErrorCode:  "rank_too_low",
+		},
+		{
+			name: "quest not found",
+			path: "/v2/quests/9/takers",
+			body: `{"adv_id": 1}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().TakeQuest(int64(9), adv.ID).Return(qstUsecase.ErrQuestNotFound).Times(1)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantErrorCode:  "quest_not_found",
+		},
+		{
+			name: "error at layer usecase",
+			path: "/v2/quests/1/takers",
+			body: `{"adv_id": 1}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().TakeQuest(int64(1), adv.ID).Return(errors.New("any error")).Times(1)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantErrorCode:  apperror.CodeInternal,
+		},
+		{
+			name: "success adv_id left to the caller",
+			path: "/v2/quests/1/takers",
+			body: `{}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().TakeQuest(int64(1), adv.ID).Return(nil).Times(1)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "another adventurer",
+			path:           "/v2/quests/1/takers",
+			body:           `{"adv_id": 2}`,
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusForbidden,
+			wantErrorCode:  "other_adventurer",
+		},
+		{
+			name:           "invalid adv_id",
+			path:           "/v2/quests/1/takers",
+			body:           `{"adv_id": -1}`,
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantErrorCode:  apperror.CodeInvalidFields,
+		},
+		{
+			name:           "json failed",
+			path:           "/v2/quests/1/takers",
+			body:           `{`,
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "id not valid",
+			path:           "/v2/quests/a/takers",
+			body:           `{"adv_id": 1}`,
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			h := &handlers{usecase: u, logger: testLogger}
+			tt.mock(u)
+			recorder := serveV2(h.AddTaker, caller, http.MethodPost, "/v2/quests/{id}/takers", tt.path, tt.body)
+			var resp MessageResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code, "error code")
+			assert.Equal(t, tt.wantStatusCode == http.StatusOK, resp.Data.Success)
+			if tt.wantErrorCode != "" {
+				assert.Equal(t, tt.wantErrorCode, resp.Header.Error)
+			}
+		})
+	}
+}
+
+func TestSubmitReport(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		mock           func(*MockUsecase)
+		wantStatusCode int
+	}{
+		{
+			name: "success reported completed",
+			path: "/v2/quests/4/report",
+			body: `{"adv_id": 1, "is_completed": true}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ReportQuest(int64(4), adv.ID, true).Return(nil).Times(1)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "success reported failed",
+			path: "/v2/quests/4/report",
+			body: `{"adv_id": 1, "is_completed": false}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ReportQuest(int64(4), adv.ID, false).Return(nil).Times(1)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "error at layer usecase",
+			path: "/v2/quests/4/report",
+			body: `{"adv_id": 1, "is_completed": true}`,
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().ReportQuest(int64(4), adv.ID, true).Return(errors.New("any error")).Times(1)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "another adventurer",
+			path:           "/v2/quests/4/report",
+			body:           `{"adv_id": 2, "is_completed": true}`,
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "empty is_completed",
+			path:           "/v2/quests/4/report",
+			body:           `{"adv_id": 1}`,
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "json failed",
+			path:           "/v2/quests/4/report",
+			body:           `{`,
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "id not valid",
+			path:           "/v2/quests/0/report",
+			body:           `{"adv_id": 1, "is_completed": true}`,
+			mock:           func(usecase *MockUsecase) {},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			h := &handlers{usecase: u, logger: testLogger}
+			tt.mock(u)
+			recorder := serveV2(h.SubmitReport, caller, http.MethodPost, "/v2/quests/{id}/report", tt.path, tt.body)
+			var resp MessageResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code, "error code")
+			assert.Equal(t, tt.wantStatusCode == http.StatusOK, resp.Data.Success)
+		})
+	}
+}
+
+func TestGetAdventurerQuests(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name           string
+		path           string
+		mock           func(*MockUsecase)
+		outQuests      []model.Quest
+		wantStatusCode int
+	}{
+		{
+			name: "success get quests",
+			path: "/v2/adventurers/1/quests",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetQuestActiveAdventurer(adv.ID).Return(bulkQuest[:1], nil).Times(1)
+			},
+			outQuests:      bulkQuest[:1],
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "error at layer usecase",
+			path: "/v2/adventurers/1/quests",
+			mock: func(usecase *MockUsecase) {
+				usecase.EXPECT().GetQuestActiveAdventurer(adv.ID).Return(nil, errors.New("any error")).Times(1)
+			},
+			outQuests:      []model.Quest{},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "id not valid",
+			path:           "/v2/adventurers/a/quests",
+			mock:           func(usecase *MockUsecase) {},
+			outQuests:      []model.Quest{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewMockUsecase(mockCtrl)
+			h := &handlers{usecase: u, logger: testLogger}
+			tt.mock(u)
+			recorder := serveV2(h.GetAdventurerQuests, caller, http.MethodGet, "/v2/adventurers/{id}/quests", tt.path, ``)
+			var resp GetQuestActiveAdventurer
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code, "error code")
+			assert.Equal(t, tt.outQuests, resp.Data)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package request
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Page holds the paging and ordering query parameters every listing shares.
+type Page struct {
+	Limit  int
+	Offset int
+	Sort   string
+	Desc   bool
+}
+
+// QueryPage reads the limit, offset and sort query parameters, sort prefixed
+// with "-" to sort descending.
+func QueryPage(query url.Values) (page Page, err error) {
+	for _, param := range []struct {
+		name string
+		dst  *int
+	}{
+		{"limit", &page.Limit},
+		{"offset", &page.Offset},
+	} {
+		if value := query.Get(param.name); value != "" {
+			if *param.dst, err = strconv.Atoi(value); err != nil {
+				return page, fmt.Errorf("%s must be a number", param.name)
+			}
+		}
+	}
+
+	page.Sort = strings.TrimPrefix(query.Get("sort"), "-")
+	page.Desc = strings.HasPrefix(query.Get("sort"), "-")
+	return page, nil
+}
+
+// QueryInt32 reads the named query parameter into dst, leaving dst alone when
+// the parameter is not set.
+func QueryInt32(query url.Values, name string, dst *int32) error {
+	value := query.Get(name)
+	if value == "" {
+		return nil
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return fmt.Errorf("%s must be a number", name)
+	}
+	*dst = int32(n)
+	return nil
+}
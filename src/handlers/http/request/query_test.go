@@ -0,0 +1,82 @@
+package request
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryPage(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    Page
+		wantErr string
+	}{
+		{
+			name: "success empty",
+		},
+		{
+			name:  "success descending",
+			query: "limit=10&offset=20&sort=-rank",
+			want:  Page{Limit: 10, Offset: 20, Sort: "rank", Desc: true},
+		},
+		{
+			name:  "success ascending",
+			query: "sort=name",
+			want:  Page{Sort: "name"},
+		},
+		{
+			name:    "limit not a number",
+			query:   "limit=ten",
+			wantErr: "limit must be a number",
+		},
+		{
+			name:    "offset not a number",
+			query:   "offset=1.5",
+			wantErr: "offset must be a number",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			assert.NoError(t, err)
+			page, err := QueryPage(query)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, page)
+		})
+	}
+}
+
+func TestQueryInt32(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    int32
+		wantErr string
+	}{
+		{name: "success", query: "min_rank=7", want: 7},
+		{name: "not set", query: "max_rank=7", want: 3},
+		{name: "not a number", query: "min_rank=high", want: 3, wantErr: "min_rank must be a number"},
+		{name: "out of range", query: "min_rank=4294967296", want: 3, wantErr: "min_rank must be a number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			assert.NoError(t, err)
+			got := int32(3)
+			err = QueryInt32(query, "min_rank", &got)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Package request reads the bodies, path and query parameters of HTTP requests.
+package request
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/gorilla/mux"
+)
+
+// encoding/json has no error type for unknown fields, only this message.
+const unknownFieldPrefix = "json: unknown field "
+
+// Decode reads the JSON body of r into v, refusing bodies over limit bytes
+// when limit is positive. Unknown fields and values of the wrong type are
+// blamed on their field; any other unreadable body is a bad request.
+func Decode(w http.ResponseWriter, r *http.Request, limit int64, v interface{}) error {
+	body := r.Body
+	if limit > 0 {
+		body = http.MaxBytesReader(w, r.Body, limit)
+	}
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(v)
+	if err == nil {
+		return nil
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return apperror.NewInvalidFields([]apperror.FieldError{{Field: typeErr.Field, Message: typeMessage(typeErr.Type)}})
+	}
+	if strings.HasPrefix(err.Error(), unknownFieldPrefix) {
+		field := strings.Trim(strings.TrimPrefix(err.Error(), unknownFieldPrefix), `"`)
+		return apperror.NewInvalidFields([]apperror.FieldError{{Field: field, Message: "is not a known field"}})
+	}
+	return apperror.NewBadRequest(err.Error())
+}
+
+// PathID reads the positive {id} path parameter.
+func PathID(r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	return id, err == nil && id > 0
+}
+
+func typeMessage(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "must be a whole number"
+	case reflect.Bool:
+		return "must be true or false"
+	case reflect.String:
+		return "must be a string"
+	}
+	return "has the wrong type"
+}
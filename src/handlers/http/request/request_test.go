@@ -0,0 +1,103 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+type takeRequest struct {
+	QuestID     int64 `json:"quest_id"`
+	IsCompleted *bool `json:"is_completed"`
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		limit          int64
+		want           takeRequest
+		wantStatusCode int
+		wantFields     []apperror.FieldError
+	}{
+		{
+			name: "success",
+			body: `{"quest_id": 3}`,
+			want: takeRequest{QuestID: 3},
+		},
+		{
+			name:           "unknown field",
+			body:           `{"quest_id": 3, "adventurer": 1}`,
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantFields:     []apperror.FieldError{{Field: "adventurer", Message: "is not a known field"}},
+		},
+		{
+			name:           "wrong type",
+			body:           `{"quest_id": "3"}`,
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantFields:     []apperror.FieldError{{Field: "quest_id", Message: "must be a whole number"}},
+		},
+		{
+			name:           "wrong type behind a pointer",
+			body:           `{"is_completed": "yes"}`,
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantFields:     []apperror.FieldError{{Field: "is_completed", Message: "must be true or false"}},
+		},
+		{
+			name:           "malformed",
+			body:           `{"quest_id": `,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "over the limit",
+			body:           `{"quest_id": 3}`,
+			limit:          4,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got takeRequest
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			err := Decode(httptest.NewRecorder(), r, tt.limit, &got)
+			if tt.wantStatusCode == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				return
+			}
+			statusCode, _, _ := apperror.Describe(err)
+			assert.Equal(t, tt.wantStatusCode, statusCode)
+			assert.Equal(t, tt.wantFields, apperror.Fields(err))
+		})
+	}
+}
+
+func TestPathID(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     string
+		want   int64
+		wantOK bool
+	}{
+		{name: "success", id: "3", want: 3, wantOK: true},
+		{name: "zero", id: "0"},
+		{name: "negative", id: "-1", want: -1},
+		{name: "not a number", id: "abc"},
+		{name: "missing", id: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/", nil), map[string]string{"id": tt.id})
+			id, ok := PathID(r)
+			assert.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.want, id)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package response
+
+import (
+	"net/http"
+	"runtime/debug"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/logger"
+)
+
+// Recover answers a request whose handler panicked with a 500 and logs the
+// panic, so one bad request does not take the server down.
+func Recover(appLogger logger.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if v := recover(); v != nil {
+					if v == http.ErrAbortHandler {
+						panic(v)
+					}
+					appLogger.Errorf("[HTTP] %s %s panicked: %v\n%s", r.Method, r.URL.Path, v, debug.Stack())
+					Write(w, appLogger, Header{
+						Error:      apperror.CodeInternal,
+						Message:    http.StatusText(http.StatusInternalServerError),
+						StatusCode: http.StatusInternalServerError,
+					}, nil)
+				}
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
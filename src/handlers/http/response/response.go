@@ -0,0 +1,58 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/logger"
+)
+
+// Header opens every response; Error holds the error code of a failure and
+// Fields the request fields it blames.
+type Header struct {
+	Error      string                `json:"error_code"`
+	Message    string                `json:"message,omitempty"`
+	Fields     []apperror.FieldError `json:"fields,omitempty"`
+	StatusCode int                   `json:"status_code"`
+}
+
+type envelope struct {
+	Header Header      `json:"header"`
+	Data   interface{} `json:"data"`
+}
+
+// Handler serves a request and returns the data of the response. The data is
+// sent along a failure too, so clients always find the same shape.
+type Handler func(http.ResponseWriter, *http.Request) (interface{}, error)
+
+// Handle adapts h to net/http. A failure is answered with the status code and
+// error code of apperror.Describe; unexpected failures are logged.
+func Handle(appLogger logger.Logger, h Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := h(w, r)
+		header := Header{StatusCode: http.StatusOK}
+		if err != nil {
+			header.StatusCode, header.Error, header.Message = apperror.Describe(err)
+			header.Fields = apperror.Fields(err)
+			if header.StatusCode == http.StatusInternalServerError {
+				appLogger.Errorf("[HTTP] %s %s, err: %v", r.Method, r.URL.Path, err)
+			}
+		}
+		Write(w, appLogger, header, data)
+	}
+}
+
+// Write sends header and data as JSON with the status code in header. When
+// data cannot be encoded the client gets a bare 500 instead.
+func Write(w http.ResponseWriter, appLogger logger.Logger, header Header, data interface{}) {
+	body, err := json.Marshal(envelope{header, data})
+	if err != nil {
+		appLogger.Errorf("[HTTP] failed build response, err: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(header.StatusCode)
+	w.Write(body)
+}
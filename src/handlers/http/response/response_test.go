@@ -0,0 +1,110 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+var testLogger, _ = logger.NewLogger("error")
+
+type testEnvelope struct {
+	Header Header            `json:"header"`
+	Data   map[string]string `json:"data"`
+}
+
+func TestHandle(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        Handler
+		wantStatusCode int
+		wantHeader     Header
+		wantData       map[string]string
+	}{
+		{
+			name: "success",
+			handler: func(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+				return map[string]string{"name": "andi"}, nil
+			},
+			wantStatusCode: http.StatusOK,
+			wantHeader:     Header{StatusCode: http.StatusOK},
+			wantData:       map[string]string{"name": "andi"},
+		},
+		{
+			name: "domain error keeps the data",
+			handler: func(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+				return map[string]string{}, apperror.NewNotFound("quest_not_found", "quest not found")
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantHeader:     Header{Error: "quest_not_found", Message: "quest not found", StatusCode: http.StatusNotFound},
+			wantData:       map[string]string{},
+		},
+		{
+			name: "invalid fields are listed",
+			handler: func(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+				return map[string]string{}, apperror.NewInvalidFields([]apperror.FieldError{{Field: "name", Message: "is required"}})
+			},
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantHeader: Header{
+				Error:      apperror.CodeInvalidFields,
+				Message:    "invalid request: name is required",
+				Fields:     []apperror.FieldError{{Field: "name", Message: "is required"}},
+				StatusCode: http.StatusUnprocessableEntity,
+			},
+			wantData: map[string]string{},
+		},
+		{
+			name: "unexpected error is not disclosed",
+			handler: func(w http.ResponseWriter, r *http.Request) (interface{}, error) {
+				return nil, errors.New("connection refused")
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantHeader:     Header{Error: apperror.CodeInternal, Message: "Internal Server Error", StatusCode: http.StatusInternalServerError},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			Handle(testLogger, tt.handler)(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+			var resp testEnvelope
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			assert.Equal(t, tt.wantHeader, resp.Header)
+			assert.Equal(t, tt.wantData, resp.Data)
+		})
+	}
+}
+
+func TestWriteUnencodableData(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	Write(recorder, testLogger, Header{StatusCode: http.StatusOK}, math.Inf(1))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
+
+func TestRecover(t *testing.T) {
+	panicking := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("nil map")
+	})
+	recorder := httptest.NewRecorder()
+	assert.NotPanics(t, func() {
+		Recover(testLogger)(panicking).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	var resp testEnvelope
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, apperror.CodeInternal, resp.Header.Error)
+
+	recorder = httptest.NewRecorder()
+	Recover(testLogger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+}
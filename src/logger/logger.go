@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+const (
+	LevelDebug = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levels = map[string]int{
+	"debug": LevelDebug,
+	"info":  LevelInfo,
+	"warn":  LevelWarn,
+	"error": LevelError,
+}
+
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warnf(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+type logger struct {
+	level int
+	out   *log.Logger
+}
+
+func NewLogger(level string) (Logger, error) {
+	lvl, ok := levels[level]
+	if !ok {
+		return nil, fmt.Errorf("unknown log level %q", level)
+	}
+
+	return &logger{lvl, log.New(os.Stderr, "", log.LstdFlags)}, nil
+}
+
+func (l *logger) Debugf(format string, args ...interface{}) {
+	l.printf(LevelDebug, "[DEBUG] ", format, args...)
+}
+
+func (l *logger) Infof(format string, args ...interface{}) {
+	l.printf(LevelInfo, "[INFO] ", format, args...)
+}
+
+func (l *logger) Warnf(format string, args ...interface{}) {
+	l.printf(LevelWarn, "[WARN] ", format, args...)
+}
+
+func (l *logger) Errorf(format string, args ...interface{}) {
+	l.printf(LevelError, "[ERROR] ", format, args...)
+}
+
+func (l *logger) printf(level int, prefix, format string, args ...interface{}) {
+	if level < l.level {
+		return
+	}
+	l.out.Printf(prefix+format, args...)
+}
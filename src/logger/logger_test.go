@@ -0,0 +1,34 @@
+package logger
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLogger(t *testing.T) {
+	res, err := NewLogger("info")
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+
+	res, err = NewLogger("verbose")
+	assert.Error(t, err)
+	assert.Nil(t, res)
+}
+
+func TestLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := &logger{
+		level: LevelWarn,
+		out:   log.New(&buf, "", 0),
+	}
+
+	l.Debugf("debug %d", 1)
+	l.Infof("info %d", 2)
+	l.Warnf("warn %d", 3)
+	l.Errorf("error %d", 4)
+
+	assert.Equal(t, "[WARN] warn 3\n[ERROR] error 4\n", buf.String())
+}
@@ -0,0 +1,69 @@
+package src
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/arfaghifari/guild-board/src/config"
+	"github.com/arfaghifari/guild-board/src/database"
+	"github.com/arfaghifari/guild-board/src/database/migration"
+)
+
+var errMigrateUsage = errors.New("usage: migrate up|down|version")
+
+// Migrate runs the "migrate" subcommand of the service binary.
+func Migrate(args []string) error {
+	if len(args) != 1 {
+		return errMigrateUsage
+	}
+	if args[0] != "up" && args[0] != "down" && args[0] != "version" {
+		return errMigrateUsage
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.Database.Driver == config.DriverMemory {
+		return errors.New("the memory driver has no schema to migrate")
+	}
+	db, err := database.Open(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	migrator, err := migration.NewMigrator(db, database.DialectOf(cfg.Database.Driver))
+	if err != nil {
+		return err
+	}
+
+	var version int
+	switch args[0] {
+	case "up":
+		version, err = migrator.Up()
+	case "down":
+		version, err = migrator.Down()
+	case "version":
+		version, err = migrator.Version()
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Println("[Migration] schema version ", version)
+	return nil
+}
+
+func migrateUp(db *sql.DB, dialect database.Dialect) error {
+	migrator, err := migration.NewMigrator(db, dialect)
+	if err != nil {
+		return err
+	}
+	version, err := migrator.Up()
+	if err != nil {
+		return err
+	}
+	log.Println("[Migration] schema version ", version)
+	return nil
+}
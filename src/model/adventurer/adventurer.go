@@ -5,4 +5,32 @@ type Adventurer struct {
 	Name           string `json:"name"`
 	Rank           int32  `json:"rank"`
 	CompletedQuest int32  `json:"completed_quest"`
+	FailedQuest    int32  `json:"failed_quest"`
+	RankPoints     int32  `json:"rank_points"`
+	FailedStreak   int32  `json:"failed_streak"`
+}
+
+// Progress shows how far an adventurer is from being promoted or demoted.
+// NextRank equals Rank at the highest rank, and FailuresToDemotion is 0 when
+// failures never cost a rank.
+type Progress struct {
+	AdventurerID       int64 `json:"adv_id"`
+	Rank               int32 `json:"rank"`
+	NextRank           int32 `json:"next_rank"`
+	RankPoints         int32 `json:"rank_points"`
+	PointsToNextRank   int32 `json:"points_to_next_rank"`
+	FailedStreak       int32 `json:"failed_streak"`
+	FailuresToDemotion int32 `json:"failures_to_demotion"`
+}
+
+// AdventurerFilter selects one page of adventurers. Zero values do not filter;
+// adventurers are ordered by Sort with ties broken by id.
+type AdventurerFilter struct {
+	NamePrefix string
+	MinRank    int32
+	MaxRank    int32
+	Sort       string
+	Desc       bool
+	Limit      int
+	Offset     int
 }
@@ -0,0 +1,40 @@
+package auth
+
+import "time"
+
+// APIKey lets its holder call the API with the given role. Only the SHA-256
+// hash of the key is stored; AdventurerID is set for adventurer keys and
+// GiverID for quest giver keys.
+type APIKey struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	Role         string    `json:"role"`
+	AdventurerID int64     `json:"adv_id,omitempty"`
+	GiverID      int64     `json:"giver_id,omitempty"`
+	Hash         string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Principal is who an authenticated request is sent by.
+type Principal struct {
+	KeyID        int64
+	Name         string
+	Role         string
+	AdventurerID int64
+	GiverID      int64
+}
+
+// NewAPIKey asks for a key. AdventurerID names the adventurer of an adventurer
+// key and GiverID the quest giver of a quest giver key.
+type NewAPIKey struct {
+	Name         string `json:"name"`
+	Role         string `json:"role"`
+	AdventurerID int64  `json:"adv_id,omitempty"`
+	GiverID      int64  `json:"giver_id,omitempty"`
+}
+
+// IssuedAPIKey is a key as it is handed out, the only time it can be read.
+type IssuedAPIKey struct {
+	Key string `json:"key"`
+	APIKey
+}
@@ -0,0 +1,10 @@
+package giver
+
+import "time"
+
+// Giver is a client of the guild who posts quests and pays their rewards.
+type Giver struct {
+	ID        int64     `json:"giver_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,58 @@
+package ledger
+
+import (
+	"fmt"
+	"time"
+)
+
+// Account names where money is held: the guild itself, a quest giver, an
+// adventurer or the escrow of a quest.
+type Account string
+
+const Guild Account = "guild"
+
+func GiverAccount(id int64) Account {
+	return Account(fmt.Sprintf("giver:%d", id))
+}
+
+func AdventurerAccount(id int64) Account {
+	return Account(fmt.Sprintf("adventurer:%d", id))
+}
+
+func EscrowAccount(questID int64) Account {
+	return Account(fmt.Sprintf("escrow:%d", questID))
+}
+
+// Entry moves Amount into Account, or out of it when negative.
+type Entry struct {
+	Account Account `json:"account"`
+	Amount  int64   `json:"amount"`
+}
+
+// Transaction is a set of entries posted together. Its entries add up to zero,
+// so money only ever moves between accounts.
+type Transaction struct {
+	ID        int64     `json:"transaction_id"`
+	QuestID   int64     `json:"quest_id,omitempty"`
+	Kind      string    `json:"kind"`
+	Entries   []Entry   `json:"entries"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Balance is the sum of every entry of an account. A quest giver's balance is
+// negative by what its quests have cost.
+type Balance struct {
+	Account Account `json:"account"`
+	Balance int64   `json:"balance"`
+}
+
+// Payout splits the reward of a completed quest between the guild, which keeps
+// GuildFee, and the party, which shares Net.
+type Payout struct {
+	QuestID    int64     `json:"quest_id"`
+	Gross      int64     `json:"gross"`
+	GuildFee   int64     `json:"guild_fee"`
+	Net        int64     `json:"adventurer_net"`
+	FeePercent int32     `json:"fee_percent"`
+	CreatedAt  time.Time `json:"created_at"`
+}
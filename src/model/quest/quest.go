@@ -1,25 +1,108 @@
 package quest
 
+import "time"
+
 type Quest struct {
-	ID           int64  `json:"quest_id"`
-	Name         string `json:"name"`
-	Description  string `json:"description"`
-	MinimumRank  int32  `json:"minimum_rank"`
-	RewardNumber int32  `json:"reward_number"`
-	Status       int32  `json:"status"`
+	ID           int64      `json:"quest_id"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	MinimumRank  int32      `json:"minimum_rank"`
+	RewardNumber int32      `json:"reward_number"`
+	Status       int32      `json:"status"`
+	CreatedAt    time.Time  `json:"created_at"`
+	Deadline     *time.Time `json:"deadline,omitempty"`
+	TakenAt      *time.Time `json:"taken_at,omitempty"`
+	MinMembers   int32      `json:"min_members"`
+	MaxMembers   int32      `json:"max_members"`
+	RankRule     string     `json:"rank_rule"`
+	Members      int32      `json:"members"`
+	CreatedBy    int64      `json:"created_by,omitempty"`
+}
+
+// NewQuest holds what a client may set when it makes a quest; the rest of a
+// Quest belongs to the server.
+type NewQuest struct {
+	Name         string     `json:"name"`
+	Description  string     `json:"description,omitempty"`
+	MinimumRank  int32      `json:"minimum_rank"`
+	RewardNumber int32      `json:"reward_number"`
+	Deadline     *time.Time `json:"deadline,omitempty"`
+	MinMembers   int32      `json:"min_members,omitempty"`
+	MaxMembers   int32      `json:"max_members,omitempty"`
+	RankRule     string     `json:"rank_rule,omitempty"`
 }
 
 type GetQuestByStatus struct {
-	ID           int64  `json:"quest_id"`
+	ID           int64      `json:"quest_id"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	MinimumRank  int32      `json:"minimum_rank"`
+	RewardNumber int32      `json:"reward_number"`
+	Status       int32      `json:"status"`
+	Deadline     *time.Time `json:"deadline,omitempty"`
+	TakenAt      *time.Time `json:"taken_at,omitempty"`
+	MinMembers   int32      `json:"min_members"`
+	MaxMembers   int32      `json:"max_members"`
+	RankRule     string     `json:"rank_rule"`
+	Members      int32      `json:"members"`
+	Takers       []Taker    `json:"takers,omitempty"`
+}
+
+// Taker is an adventurer in the party of a quest.
+type Taker struct {
+	AdventurerID int64  `json:"adv_id"`
 	Name         string `json:"name"`
-	Description  string `json:"description"`
-	MinimumRank  int32  `json:"minimum_rank"`
-	RewardNumber int32  `json:"reward_number"`
+	Rank         int32  `json:"rank"`
+	Reward       int32  `json:"reward"`
+}
+
+// QuestFilter selects one page of quests. Zero values do not filter; quests are
+// ordered by Sort with ties broken by quest id.
+type QuestFilter struct {
+	Statuses  []int32
+	MinReward int32
+	MaxReward int32
+	MinRank   int32
+	MaxRank   int32
+	Name      string
+	CreatedBy int64
+	Sort      string
+	Desc      bool
+	Limit     int
+	Offset    int
+}
+
+// PostedQuest is a quest as its giver follows it, with the adventurers
+// assigned to it.
+type PostedQuest struct {
+	Quest
+	Takers []Taker `json:"takers"`
 }
 
 type TakenBy struct {
 	QuestID      int64 `json:"quest_id"`
 	AdventurerID int64 `json:"adv_id"`
+	Reward       int32 `json:"reward"`
+}
+
+// Assignment records an adventurer holding a quest from TakenAt until
+// FinishedAt with an Outcome. Both are empty while the quest is still held.
+type Assignment struct {
+	ID           int64      `json:"id"`
+	QuestID      int64      `json:"quest_id"`
+	AdventurerID int64      `json:"adv_id"`
+	TakenAt      time.Time  `json:"taken_at"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+	Outcome      string     `json:"outcome,omitempty"`
+}
+
+// AssignmentFilter selects one page of the history of a quest, an adventurer
+// or both, newest first.
+type AssignmentFilter struct {
+	QuestID      int64
+	AdventurerID int64
+	Limit        int
+	Offset       int
 }
 
 type ReportQuest struct {
@@ -0,0 +1,353 @@
+package src
+
+import (
+	"net/http"
+
+	advHandlers "github.com/arfaghifari/guild-board/src/handlers/http/adventurer"
+	"github.com/arfaghifari/guild-board/src/handlers/http/auth"
+	giverHandlers "github.com/arfaghifari/guild-board/src/handlers/http/giver"
+	ledgerHandlers "github.com/arfaghifari/guild-board/src/handlers/http/ledger"
+	"github.com/arfaghifari/guild-board/src/handlers/http/openapi"
+	qstHandlers "github.com/arfaghifari/guild-board/src/handlers/http/quest"
+	modelAdv "github.com/arfaghifari/guild-board/src/model/adventurer"
+	modelAuth "github.com/arfaghifari/guild-board/src/model/auth"
+	modelGiver "github.com/arfaghifari/guild-board/src/model/giver"
+	modelQuest "github.com/arfaghifari/guild-board/src/model/quest"
+)
+
+const (
+	specPath = "/openapi.json"
+	docsPath = "/docs"
+	apiTitle = "Guild Board API"
+)
+
+// newSpec documents every route of newRouter; TestSpecCoversRoutes keeps the
+// two in step.
+func newSpec() *openapi.Document {
+	spec := openapi.New(apiTitle, "2.0.0")
+	const (
+		badRequest = http.StatusBadRequest
+		forbidden  = http.StatusForbidden
+		notFound   = http.StatusNotFound
+		conflict   = http.StatusConflict
+		invalid    = http.StatusUnprocessableEntity
+		internal   = http.StatusInternalServerError
+	)
+	legacy := []string{"v1"}
+	quests := []string{"quests"}
+	adventurers := []string{"adventurers"}
+	givers := []string{"givers"}
+	ledger := []string{"ledger"}
+	apiKeys := []string{"api keys"}
+
+	id := func(owner string) openapi.Parameter {
+		return spec.PathParam("id", owner+" id", int64(0))
+	}
+	page := []openapi.Parameter{
+		spec.Query("limit", "page size, 20 by default and 100 at most", 0),
+		spec.Query("offset", "number of items to skip", 0),
+	}
+	questFilter := []openapi.Parameter{
+		spec.Query("status", "comma separated statuses, may repeat", ""),
+		spec.Query("min_reward", "lowest reward", int32(0)),
+		spec.Query("max_reward", "highest reward", int32(0)),
+		spec.Query("min_rank", "lowest minimum rank", int32(0)),
+		spec.Query("max_rank", "highest minimum rank", int32(0)),
+		spec.Query("name", "part of the name", ""),
+		spec.Query("sort", "created_at, reward or rank, prefixed with - to sort descending", ""),
+	}
+	paged := func(responses map[string]openapi.Response) map[string]openapi.Response {
+		ok := responses["200"]
+		ok.Headers = map[string]openapi.Header{
+			qstHandlers.TotalCountHeader: {Description: "number of matching items across all pages", Schema: spec.Schema(0)},
+		}
+		responses["200"] = ok
+		return responses
+	}
+	text := func(contentType string) map[string]openapi.Response {
+		return map[string]openapi.Response{"200": {
+			Description: http.StatusText(http.StatusOK),
+			Content:     map[string]openapi.MediaType{contentType: {Schema: spec.Schema("")}},
+		}}
+	}
+
+	spec.Add(http.MethodGet, specPath, openapi.Operation{
+		Summary:   "This document",
+		Tags:      []string{"docs"},
+		Responses: spec.Responses(map[string]interface{}{}),
+	})
+	spec.Add(http.MethodGet, docsPath, openapi.Operation{
+		Summary:   "Browse this document with Swagger UI",
+		Tags:      []string{"docs"},
+		Responses: text("text/html"),
+	})
+	spec.Add(http.MethodGet, "/hello", openapi.Operation{
+		Summary:   "Say hello",
+		Tags:      legacy,
+		Responses: text("text/plain"),
+	})
+
+	spec.Add(http.MethodGet, "/quest-status", openapi.Operation{
+		Summary:    "List the quests in a status with their takers",
+		Tags:       legacy,
+		Parameters: []openapi.Parameter{spec.Query("status", "0 available, 1 working, 2 completed, 3 expired, 4 cancelled", int32(0))},
+		Responses:  spec.Responses(qstHandlers.GetQuestByStatusResponse{}, badRequest, invalid, internal),
+	})
+	spec.Add(http.MethodPost, "/quest", openapi.Operation{
+		Summary:     "Create a quest",
+		Tags:        legacy,
+		RequestBody: spec.Body(modelQuest.NewQuest{}),
+		Responses:   spec.Responses(qstHandlers.QuestResponse{}, badRequest, invalid, internal),
+	})
+	spec.Add(http.MethodDelete, "/quest", openapi.Operation{
+		Summary:     "Delete the quest of quest_id",
+		Tags:        legacy,
+		RequestBody: spec.Body(modelQuest.Quest{}),
+		Responses:   spec.Responses(qstHandlers.MessageResponse{}, badRequest, notFound, invalid, internal),
+	})
+	spec.Add(http.MethodPatch, "/quest-rank", openapi.Operation{
+		Summary:     "Change the minimum rank of the quest of quest_id",
+		Tags:        legacy,
+		RequestBody: spec.Body(modelQuest.Quest{}),
+		Responses:   spec.Responses(qstHandlers.MessageResponse{}, badRequest, notFound, invalid, internal),
+	})
+	spec.Add(http.MethodPatch, "/quest-reward", openapi.Operation{
+		Summary:     "Change the reward of the quest of quest_id",
+		Tags:        legacy,
+		RequestBody: spec.Body(modelQuest.Quest{}),
+		Responses:   spec.Responses(qstHandlers.MessageResponse{}, badRequest, notFound, invalid, internal),
+	})
+	spec.Add(http.MethodPost, "/quest/{id}/cancel", openapi.Operation{
+		Summary:    "Cancel a quest and release its party",
+		Tags:       legacy,
+		Parameters: []openapi.Parameter{id("quest")},
+		Responses:  spec.Responses(qstHandlers.CancelResponse{}, badRequest, notFound, conflict, internal),
+	})
+	spec.Add(http.MethodPost, "/adventurer", openapi.Operation{
+		Summary:     "Register an adventurer",
+		Tags:        legacy,
+		RequestBody: spec.Body(modelAdv.Adventurer{}),
+		Responses:   spec.Responses(advHandlers.AdvResponse{}, badRequest, invalid, internal),
+	})
+	spec.Add(http.MethodGet, "/adventurer", openapi.Operation{
+		Summary:    "Get an adventurer",
+		Tags:       legacy,
+		Parameters: []openapi.Parameter{spec.Query("adv_id", "adventurer id", int64(0))},
+		Responses:  spec.Responses(advHandlers.AdvResponse{}, badRequest, notFound, internal),
+	})
+	spec.Add(http.MethodPatch, "/adventurer-rank", openapi.Operation{
+		Summary:     "Change the rank of the adventurer of id",
+		Tags:        legacy,
+		RequestBody: spec.Body(modelAdv.Adventurer{}),
+		Responses:   spec.Responses(advHandlers.MessageResponse{}, badRequest, notFound, invalid, internal),
+	})
+	spec.Add(http.MethodGet, "/adventurer/{id}/progress", openapi.Operation{
+		Summary:    "Show how far an adventurer is from the next rank",
+		Tags:       legacy,
+		Parameters: []openapi.Parameter{id("adventurer")},
+		Responses:  spec.Responses(advHandlers.ProgressResponse{}, badRequest, notFound, internal),
+	})
+	spec.Add(http.MethodGet, "/quest-active-adv", openapi.Operation{
+		Summary:    "List the quests an adventurer is working on",
+		Tags:       legacy,
+		Parameters: []openapi.Parameter{spec.Query("adv_id", "adventurer id", int64(0))},
+		Responses:  spec.Responses(qstHandlers.GetQuestActiveAdventurer{}, badRequest, notFound, internal),
+	})
+	spec.Add(http.MethodPost, "/take-quest", openapi.Operation{
+		Summary:     "Let an adventurer take a quest",
+		Tags:        legacy,
+		RequestBody: spec.Body(modelQuest.TakenBy{}),
+		Responses:   spec.Responses(qstHandlers.MessageResponse{}, badRequest, forbidden, notFound, conflict, invalid, internal),
+	})
+	spec.Add(http.MethodPost, "/done-quest", openapi.Operation{
+		Summary:     "Report a quest completed or failed",
+		Tags:        legacy,
+		RequestBody: spec.Body(modelQuest.ReportQuest{}),
+		Responses:   spec.Responses(qstHandlers.MessageResponse{}, badRequest, forbidden, notFound, conflict, invalid, internal),
+	})
+
+	spec.Add(http.MethodGet, "/v2/quests", openapi.Operation{
+		Summary:    "List quests",
+		Tags:       quests,
+		Parameters: append(append([]openapi.Parameter{}, questFilter...), page...),
+		Responses:  paged(spec.Responses(qstHandlers.QuestListResponse{}, badRequest, invalid, internal)),
+	})
+	spec.Add(http.MethodPost, "/v2/quests", openapi.Operation{
+		Summary:     "Create a quest, owned by the quest giver of the key",
+		Tags:        quests,
+		RequestBody: spec.Body(modelQuest.NewQuest{}),
+		Responses:   spec.Responses(qstHandlers.QuestResponse{}, badRequest, invalid, internal),
+	})
+	spec.Add(http.MethodGet, "/v2/quests/{id}", openapi.Operation{
+		Summary:    "Get a quest",
+		Tags:       quests,
+		Parameters: []openapi.Parameter{id("quest")},
+		Responses:  spec.Responses(qstHandlers.QuestResponse{}, badRequest, notFound, internal),
+	})
+	spec.Add(http.MethodPatch, "/v2/quests/{id}", openapi.Operation{
+		Summary:     "Change the minimum rank, the reward or both",
+		Tags:        quests,
+		Parameters:  []openapi.Parameter{id("quest")},
+		RequestBody: spec.Body(modelQuest.Quest{}),
+		Responses:   spec.Responses(qstHandlers.MessageResponse{}, badRequest, notFound, invalid, internal),
+	})
+	spec.Add(http.MethodDelete, "/v2/quests/{id}", openapi.Operation{
+		Summary:    "Delete a quest",
+		Tags:       quests,
+		Parameters: []openapi.Parameter{id("quest")},
+		Responses:  spec.Responses(qstHandlers.MessageResponse{}, badRequest, notFound, internal),
+	})
+	spec.Add(http.MethodGet, "/v2/quests/{id}/takers", openapi.Operation{
+		Summary:    "List the party of a quest",
+		Tags:       quests,
+		Parameters: []openapi.Parameter{id("quest")},
+		Responses:  spec.Responses(qstHandlers.TakersResponse{}, badRequest, notFound, internal),
+	})
+	spec.Add(http.MethodPost, "/v2/quests/{id}/takers", openapi.Operation{
+		Summary:     "Let the adventurer of adv_id join the party",
+		Tags:        quests,
+		Parameters:  []openapi.Parameter{id("quest")},
+		RequestBody: spec.Body(modelQuest.TakenBy{}),
+		Responses:   spec.Responses(qstHandlers.MessageResponse{}, badRequest, forbidden, notFound, conflict, invalid, internal),
+	})
+	spec.Add(http.MethodPost, "/v2/quests/{id}/report", openapi.Operation{
+		Summary:     "Report the quest completed or failed",
+		Tags:        quests,
+		Parameters:  []openapi.Parameter{id("quest")},
+		RequestBody: spec.Body(modelQuest.ReportQuest{}),
+		Responses:   spec.Responses(qstHandlers.MessageResponse{}, badRequest, forbidden, notFound, conflict, invalid, internal),
+	})
+	spec.Add(http.MethodPost, "/v2/quests/{id}/cancel", openapi.Operation{
+		Summary:    "Cancel a quest and release its party",
+		Tags:       quests,
+		Parameters: []openapi.Parameter{id("quest")},
+		Responses:  spec.Responses(qstHandlers.CancelResponse{}, badRequest, notFound, conflict, internal),
+	})
+	spec.Add(http.MethodGet, "/v2/quests/{id}/history", openapi.Operation{
+		Summary:    "List the adventurers who held the quest, newest first",
+		Tags:       quests,
+		Parameters: append([]openapi.Parameter{id("quest")}, page...),
+		Responses:  paged(spec.Responses(qstHandlers.AssignmentListResponse{}, badRequest, notFound, invalid, internal)),
+	})
+
+	spec.Add(http.MethodGet, "/v2/adventurers", openapi.Operation{
+		Summary: "List adventurers",
+		Tags:    adventurers,
+		Parameters: append([]openapi.Parameter{
+			spec.Query("name", "start of the name", ""),
+			spec.Query("min_rank", "lowest rank", int32(0)),
+			spec.Query("max_rank", "highest rank", int32(0)),
+			spec.Query("sort", "id, rank or completed_quest, prefixed with - to sort descending", ""),
+		}, page...),
+		Responses: paged(spec.Responses(advHandlers.AdvListResponse{}, badRequest, invalid, internal)),
+	})
+	spec.Add(http.MethodPost, "/v2/adventurers", openapi.Operation{
+		Summary:     "Register an adventurer",
+		Tags:        adventurers,
+		RequestBody: spec.Body(modelAdv.Adventurer{}),
+		Responses:   spec.Responses(advHandlers.AdvResponse{}, badRequest, invalid, internal),
+	})
+	spec.Add(http.MethodGet, "/v2/adventurers/{id}", openapi.Operation{
+		Summary:    "Get an adventurer",
+		Tags:       adventurers,
+		Parameters: []openapi.Parameter{id("adventurer")},
+		Responses:  spec.Responses(advHandlers.AdvResponse{}, badRequest, notFound, internal),
+	})
+	spec.Add(http.MethodPatch, "/v2/adventurers/{id}", openapi.Operation{
+		Summary:     "Change the rank of an adventurer",
+		Tags:        adventurers,
+		Parameters:  []openapi.Parameter{id("adventurer")},
+		RequestBody: spec.Body(modelAdv.Adventurer{}),
+		Responses:   spec.Responses(advHandlers.MessageResponse{}, badRequest, notFound, invalid, internal),
+	})
+	spec.Add(http.MethodGet, "/v2/adventurers/{id}/quests", openapi.Operation{
+		Summary:    "List the quests an adventurer is working on",
+		Tags:       adventurers,
+		Parameters: []openapi.Parameter{id("adventurer")},
+		Responses:  spec.Responses(qstHandlers.GetQuestActiveAdventurer{}, badRequest, notFound, internal),
+	})
+	spec.Add(http.MethodGet, "/v2/adventurers/{id}/progress", openapi.Operation{
+		Summary:    "Show how far an adventurer is from the next rank",
+		Tags:       adventurers,
+		Parameters: []openapi.Parameter{id("adventurer")},
+		Responses:  spec.Responses(advHandlers.ProgressResponse{}, badRequest, notFound, internal),
+	})
+	spec.Add(http.MethodGet, "/v2/adventurers/{id}/history", openapi.Operation{
+		Summary:    "List the quests the adventurer held, newest first",
+		Tags:       adventurers,
+		Parameters: append([]openapi.Parameter{id("adventurer")}, page...),
+		Responses:  paged(spec.Responses(qstHandlers.AssignmentListResponse{}, badRequest, notFound, invalid, internal)),
+	})
+
+	spec.Add(http.MethodPost, "/v2/givers", openapi.Operation{
+		Summary:     "Register a quest giver",
+		Tags:        givers,
+		RequestBody: spec.Body(modelGiver.Giver{}),
+		Responses:   spec.Responses(giverHandlers.GiverResponse{}, badRequest, invalid, internal),
+	})
+	spec.Add(http.MethodGet, "/v2/givers/{id}", openapi.Operation{
+		Summary:    "Get a quest giver",
+		Tags:       givers,
+		Parameters: []openapi.Parameter{id("quest giver")},
+		Responses:  spec.Responses(giverHandlers.GiverResponse{}, badRequest, notFound, internal),
+	})
+	spec.Add(http.MethodGet, "/v2/givers/{id}/quests", openapi.Operation{
+		Summary:    "List the quests a giver posted with their status and party",
+		Tags:       givers,
+		Parameters: append(append([]openapi.Parameter{id("quest giver")}, questFilter...), page...),
+		Responses:  paged(spec.Responses(giverHandlers.PostedQuestListResponse{}, badRequest, notFound, invalid, internal)),
+	})
+
+	spec.Add(http.MethodGet, "/v2/guild/balance", openapi.Operation{
+		Summary:   "Get the balance of the guild, which pays the rewards of quests posted by the staff",
+		Tags:      ledger,
+		Responses: spec.Responses(ledgerHandlers.BalanceResponse{}, forbidden, internal),
+	})
+	spec.Add(http.MethodGet, "/v2/givers/{id}/balance", openapi.Operation{
+		Summary:    "Get the balance of a quest giver, negative by the rewards it paid or escrowed",
+		Tags:       ledger,
+		Parameters: []openapi.Parameter{id("quest giver")},
+		Responses:  spec.Responses(ledgerHandlers.BalanceResponse{}, badRequest, forbidden, notFound, internal),
+	})
+	spec.Add(http.MethodGet, "/v2/adventurers/{id}/balance", openapi.Operation{
+		Summary:    "Get the rewards paid to an adventurer",
+		Tags:       ledger,
+		Parameters: []openapi.Parameter{id("adventurer")},
+		Responses:  spec.Responses(ledgerHandlers.BalanceResponse{}, badRequest, forbidden, notFound, internal),
+	})
+	spec.Add(http.MethodGet, "/v2/quests/{id}/escrow", openapi.Operation{
+		Summary:    "Get what the escrow of a quest holds",
+		Tags:       ledger,
+		Parameters: []openapi.Parameter{id("quest")},
+		Responses:  spec.Responses(ledgerHandlers.BalanceResponse{}, badRequest, notFound, internal),
+	})
+	spec.Add(http.MethodGet, "/v2/quests/{id}/payout", openapi.Operation{
+		Summary:    "Get how the reward of a completed quest was split between the guild fee and the party",
+		Tags:       ledger,
+		Parameters: []openapi.Parameter{id("quest")},
+		Responses:  spec.Responses(ledgerHandlers.PayoutResponse{}, badRequest, notFound, internal),
+	})
+
+	spec.Add(http.MethodPost, "/v2/api-keys", openapi.Operation{
+		Summary:     "Issue an API key; the key is shown only in this response",
+		Tags:        apiKeys,
+		RequestBody: spec.Body(modelAuth.NewAPIKey{}),
+		Responses:   spec.Responses(auth.APIKeyResponse{}, badRequest, forbidden, notFound, invalid, internal),
+	})
+
+	bearer := spec.Bearer("apiKey", "an API key made with `apikey create` or `POST /v2/api-keys`")
+	for p, item := range spec.Paths {
+		if p == specPath || p == docsPath || p == "/hello" {
+			continue
+		}
+		for method, op := range item {
+			op.Security = bearer
+			op.Responses["401"] = openapi.Response{Description: http.StatusText(http.StatusUnauthorized), Content: op.Responses["200"].Content}
+			// changes are checked against the role of the key
+			if method != "get" {
+				op.Responses["403"] = openapi.Response{Description: http.StatusText(forbidden), Content: op.Responses["200"].Content}
+			}
+		}
+	}
+	return spec
+}
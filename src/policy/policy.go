@@ -0,0 +1,104 @@
+// Package policy decides which actions the role of a caller permits. Reading
+// the board is open to every caller and needs no action; reading the ledger is
+// not.
+package policy
+
+import (
+	"github.com/arfaghifari/guild-board/src/apperror"
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	model "github.com/arfaghifari/guild-board/src/model/auth"
+	modelLedger "github.com/arfaghifari/guild-board/src/model/ledger"
+)
+
+type Action string
+
+const (
+	CreateQuest        Action = "quest:create"
+	EditQuest          Action = "quest:edit"
+	CancelQuest        Action = "quest:cancel"
+	DeleteQuest        Action = "quest:delete"
+	TakeQuest          Action = "quest:take"
+	ReportQuest        Action = "quest:report"
+	RegisterAdventurer Action = "adventurer:register"
+	RankAdventurer     Action = "adventurer:rank"
+	RegisterGiver      Action = "giver:register"
+	ViewBalance        Action = "ledger:view"
+	IssueAPIKey        Action = "apikey:issue"
+)
+
+var ErrPermissionDenied = apperror.NewForbidden("permission_denied", "the role of the api key does not permit this")
+
+// permissions lists the actions of each role. Adventurers take and report
+// quests only as themselves, and nobody else does it for them.
+var permissions = map[string]map[Action]bool{
+	constant.RoleStaff: {
+		CreateQuest:        true,
+		EditQuest:          true,
+		CancelQuest:        true,
+		DeleteQuest:        true,
+		RegisterAdventurer: true,
+		RankAdventurer:     true,
+		RegisterGiver:      true,
+		ViewBalance:        true,
+		IssueAPIKey:        true,
+	},
+	constant.RoleGiver: {
+		CreateQuest: true,
+	},
+	constant.RoleAdventurer: {
+		TakeQuest:   true,
+		ReportQuest: true,
+	},
+}
+
+// ownerPermissions lists what quest givers may do to the quests they posted,
+// on top of the actions of their role.
+var ownerPermissions = map[Action]bool{
+	EditQuest:   true,
+	CancelQuest: true,
+}
+
+// holderPermissions lists what quest givers and adventurers may do to their
+// own ledger account, on top of the actions of their role.
+var holderPermissions = map[Action]bool{
+	ViewBalance: true,
+}
+
+// Authorize fails with ErrPermissionDenied unless the role of principal
+// permits action.
+func Authorize(principal model.Principal, action Action) error {
+	if !permissions[principal.Role][action] {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// OwnerMay reports whether principal could do action to a quest it posted, so
+// that callers only look up the owner of a quest when it matters.
+func OwnerMay(principal model.Principal, action Action) bool {
+	return principal.Role == constant.RoleGiver && principal.GiverID > 0 && ownerPermissions[action]
+}
+
+// AuthorizeOwner is Authorize for an action on a quest posted by the quest
+// giver ownerID, 0 for quests posted by the guild.
+func AuthorizeOwner(principal model.Principal, action Action, ownerID int64) error {
+	if err := Authorize(principal, action); err == nil || !OwnerMay(principal, action) || ownerID != principal.GiverID {
+		return err
+	}
+	return nil
+}
+
+// AuthorizeAccount is Authorize for an action on a ledger account. The quest
+// giver or adventurer a key names holds its account.
+func AuthorizeAccount(principal model.Principal, action Action, account modelLedger.Account) error {
+	if err := Authorize(principal, action); err == nil || !holderPermissions[action] {
+		return err
+	}
+	switch {
+	case principal.Role == constant.RoleGiver && principal.GiverID > 0 && account == modelLedger.GiverAccount(principal.GiverID):
+		return nil
+	case principal.Role == constant.RoleAdventurer && principal.AdventurerID > 0 && account == modelLedger.AdventurerAccount(principal.AdventurerID):
+		return nil
+	}
+	return ErrPermissionDenied
+}
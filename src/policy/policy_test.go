@@ -0,0 +1,119 @@
+package policy
+
+import (
+	"testing"
+
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	model "github.com/arfaghifari/guild-board/src/model/auth"
+	modelLedger "github.com/arfaghifari/guild-board/src/model/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorize(t *testing.T) {
+	staff := model.Principal{KeyID: 1, Role: constant.RoleStaff}
+	giver := model.Principal{KeyID: 2, Role: constant.RoleGiver}
+	andi := model.Principal{KeyID: 3, Role: constant.RoleAdventurer, AdventurerID: 1}
+	tests := []struct {
+		name      string
+		principal model.Principal
+		allowed   []Action
+	}{
+		{
+			name:      "staff",
+			principal: staff,
+			allowed:   []Action{CreateQuest, EditQuest, CancelQuest, DeleteQuest, RegisterAdventurer, RankAdventurer, RegisterGiver, ViewBalance, IssueAPIKey},
+		},
+		{
+			name:      "quest giver",
+			principal: giver,
+			allowed:   []Action{CreateQuest},
+		},
+		{
+			name:      "adventurer",
+			principal: andi,
+			allowed:   []Action{TakeQuest, ReportQuest},
+		},
+		{
+			name:      "unknown role",
+			principal: model.Principal{KeyID: 4, Role: "guildmaster"},
+		},
+		{
+			name: "anonymous",
+		},
+	}
+	actions := []Action{CreateQuest, EditQuest, CancelQuest, DeleteQuest, TakeQuest, ReportQuest, RegisterAdventurer, RankAdventurer, RegisterGiver,
+		ViewBalance, IssueAPIKey}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed := map[Action]bool{}
+			for _, action := range tt.allowed {
+				allowed[action] = true
+			}
+			for _, action := range actions {
+				err := Authorize(tt.principal, action)
+				if allowed[action] {
+					assert.NoError(t, err, action)
+				} else {
+					assert.Equal(t, ErrPermissionDenied, err, action)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthorizeOwner(t *testing.T) {
+	staff := model.Principal{KeyID: 1, Role: constant.RoleStaff}
+	mayor := model.Principal{KeyID: 2, Role: constant.RoleGiver, GiverID: 1}
+	andi := model.Principal{KeyID: 3, Role: constant.RoleAdventurer, AdventurerID: 1}
+	tests := []struct {
+		name      string
+		principal model.Principal
+		action    Action
+		ownerID   int64
+		wantErr   error
+	}{
+		{name: "staff edits any quest", principal: staff, action: EditQuest, ownerID: 1},
+		{name: "staff edits a guild quest", principal: staff, action: EditQuest},
+		{name: "giver edits its quest", principal: mayor, action: EditQuest, ownerID: 1},
+		{name: "giver cancels its quest", principal: mayor, action: CancelQuest, ownerID: 1},
+		{name: "giver edits another quest", principal: mayor, action: EditQuest, ownerID: 2, wantErr: ErrPermissionDenied},
+		{name: "giver edits a guild quest", principal: mayor, action: EditQuest, wantErr: ErrPermissionDenied},
+		{name: "giver deletes its quest", principal: mayor, action: DeleteQuest, ownerID: 1, wantErr: ErrPermissionDenied},
+		{name: "giver without a giver", principal: model.Principal{KeyID: 4, Role: constant.RoleGiver}, action: EditQuest, wantErr: ErrPermissionDenied},
+		{name: "adventurer", principal: andi, action: EditQuest, ownerID: 1, wantErr: ErrPermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, AuthorizeOwner(tt.principal, tt.action, tt.ownerID))
+		})
+	}
+}
+
+func TestAuthorizeAccount(t *testing.T) {
+	staff := model.Principal{KeyID: 1, Role: constant.RoleStaff}
+	mayor := model.Principal{KeyID: 2, Role: constant.RoleGiver, GiverID: 1}
+	andi := model.Principal{KeyID: 3, Role: constant.RoleAdventurer, AdventurerID: 1}
+	tests := []struct {
+		name      string
+		principal model.Principal
+		action    Action
+		account   modelLedger.Account
+		wantErr   error
+	}{
+		{name: "staff views the guild", principal: staff, action: ViewBalance, account: modelLedger.Guild},
+		{name: "staff views a giver", principal: staff, action: ViewBalance, account: modelLedger.GiverAccount(2)},
+		{name: "giver views its account", principal: mayor, action: ViewBalance, account: modelLedger.GiverAccount(1)},
+		{name: "giver views another giver", principal: mayor, action: ViewBalance, account: modelLedger.GiverAccount(2), wantErr: ErrPermissionDenied},
+		{name: "giver views the adventurer of its id", principal: mayor, action: ViewBalance, account: modelLedger.AdventurerAccount(1), wantErr: ErrPermissionDenied},
+		{name: "giver views the guild", principal: mayor, action: ViewBalance, account: modelLedger.Guild, wantErr: ErrPermissionDenied},
+		{name: "adventurer views its account", principal: andi, action: ViewBalance, account: modelLedger.AdventurerAccount(1)},
+		{name: "adventurer views another adventurer", principal: andi, action: ViewBalance, account: modelLedger.AdventurerAccount(2), wantErr: ErrPermissionDenied},
+		{name: "adventurer deletes a quest", principal: andi, action: DeleteQuest, account: modelLedger.AdventurerAccount(1), wantErr: ErrPermissionDenied},
+		{name: "anonymous", action: ViewBalance, account: modelLedger.AdventurerAccount(0), wantErr: ErrPermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, AuthorizeAccount(tt.principal, tt.action, tt.account))
+		})
+	}
+}
@@ -13,42 +13,66 @@ type Repository interface {
 	UpdateAdventurerRank(model.Adventurer) error
 	GetAdventurer(int64) (model.Adventurer, error)
 	AddCompletedQuest(int64) error
+	AddFailedQuest(int64) error
+	UpdateAdventurerProgress(model.Adventurer) error
+	ListAdventurers(model.AdventurerFilter) ([]model.Adventurer, int, error)
 }
 
 type repository struct {
-	db *sql.DB
+	db      *sql.DB
+	tx      *sql.Tx
+	dialect database.Dialect
 }
 
-func NewRepository() (Repository, error) {
-	db := database.GetDB()
+func NewRepository(db *sql.DB, dialect database.Dialect) (Repository, error) {
+	if db == nil {
+		return nil, database.ErrNilDB
+	}
+
+	return &repository{db: db, dialect: dialect}, nil
+}
 
-	return &repository{db}, nil
+// NewTxRepository returns a repository whose queries run inside tx.
+func NewTxRepository(tx *sql.Tx, dialect database.Dialect) Repository {
+	return &repository{tx: tx, dialect: dialect}
 }
 
 func (r *repository) Close() {
-	r.db.Close()
+	if r.db != nil {
+		r.db.Close()
+	}
+}
+
+func (r *repository) conn() database.Querier {
+	if r.tx != nil {
+		return database.Bind(r.tx, r.dialect)
+	}
+	return database.Bind(r.db, r.dialect)
 }
 
 func (r *repository) CreateAdventurer(adventurer model.Adventurer) (adv model.Adventurer, err error) {
-	db := r.db
+	db := r.conn()
 	adv = adventurer
 	query := `INSERT INTO adventurer(name, rank)
-	VALUES($1, $2) RETURNING id`
+	VALUES($1, $2)` + r.dialect.Returning("id")
 	createForm, err := db.Prepare(query)
 	if err != nil {
 		return model.Adventurer{}, err
 	}
-	err = createForm.QueryRow(adventurer.Name, adventurer.Rank).Scan(&adv.ID)
+	adv.ID, err = r.dialect.InsertID(createForm, adventurer.Name, adventurer.Rank)
 	if err != nil {
 		return model.Adventurer{}, err
 	}
 	adv.CompletedQuest = 0
+	adv.FailedQuest = 0
+	adv.RankPoints = 0
+	adv.FailedStreak = 0
 	defer createForm.Close()
 	return
 }
 
 func (r *repository) UpdateAdventurerRank(adventurer model.Adventurer) error {
-	db := r.db
+	db := r.conn()
 	query := `UPDATE adventurer
 	SET rank = $1
 	WHERE id = $2`
@@ -56,23 +80,24 @@ func (r *repository) UpdateAdventurerRank(adventurer model.Adventurer) error {
 	if err != nil {
 		return err
 	}
-	updateForm.Exec(adventurer.Rank, adventurer.ID)
+	_, err = updateForm.Exec(adventurer.Rank, adventurer.ID)
 	defer updateForm.Close()
 	return err
 }
 
 func (r *repository) GetAdventurer(id int64) (adventurer model.Adventurer, err error) {
-	db := r.db
-	query := `SELECT name, rank, completed_quest
+	db := r.conn()
+	query := `SELECT name, rank, completed_quest, failed_quest, rank_points, failed_streak
 	FROM adventurer
 	WHERE id = $1`
 	adventurer.ID = id
-	err = db.QueryRow(query, id).Scan(&adventurer.Name, &adventurer.Rank, &adventurer.CompletedQuest)
+	err = db.QueryRow(query, id).Scan(&adventurer.Name, &adventurer.Rank, &adventurer.CompletedQuest, &adventurer.FailedQuest,
+		&adventurer.RankPoints, &adventurer.FailedStreak)
 	return
 }
 
 func (r *repository) AddCompletedQuest(id int64) error {
-	db := r.db
+	db := r.conn()
 	query := `UPDATE adventurer
 		SET completed_quest = completed_quest + 1
 		WHERE id = $1`
@@ -80,7 +105,57 @@ func (r *repository) AddCompletedQuest(id int64) error {
 	if err != nil {
 		return err
 	}
-	addForm.Exec(id)
+	_, err = addForm.Exec(id)
 	defer addForm.Close()
 	return err
 }
+
+func (r *repository) AddFailedQuest(id int64) error {
+	db := r.conn()
+	query := `UPDATE adventurer
+		SET failed_quest = failed_quest + 1
+		WHERE id = $1`
+	_, err := db.Exec(query, id)
+	return err
+}
+
+// UpdateAdventurerProgress saves the rank, rank points and failed streak the
+// promotion policy computed.
+func (r *repository) UpdateAdventurerProgress(adventurer model.Adventurer) error {
+	db := r.conn()
+	query := `UPDATE adventurer
+		SET rank = $1, rank_points = $2, failed_streak = $3
+		WHERE id = $4`
+	_, err := db.Exec(query, adventurer.Rank, adventurer.RankPoints, adventurer.FailedStreak, adventurer.ID)
+	return err
+}
+
+// ListAdventurers returns the page of adventurers selected by filter and the
+// number of adventurers matching it across all pages.
+func (r *repository) ListAdventurers(filter model.AdventurerFilter) (adventurers []model.Adventurer, total int, err error) {
+	db := r.conn()
+	c := adventurerConditions(filter)
+
+	query, args := countAdventurersQuery(c)
+	if err = db.QueryRow(query, args...).Scan(&total); err != nil {
+		return
+	}
+
+	adventurers = []model.Adventurer{}
+	query, args = listAdventurersQuery(c, filter)
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var adv model.Adventurer
+		if err = rows.Scan(&adv.ID, &adv.Name, &adv.Rank, &adv.CompletedQuest, &adv.FailedQuest, &adv.RankPoints, &adv.FailedStreak); err != nil {
+			return
+		}
+		adventurers = append(adventurers, adv)
+	}
+
+	return
+}
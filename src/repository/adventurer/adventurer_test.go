@@ -2,11 +2,14 @@ package adventurer
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"log"
 	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/database"
 	model "github.com/arfaghifari/guild-board/src/model/adventurer"
 	"github.com/stretchr/testify/assert"
 )
@@ -28,9 +31,16 @@ var adv = model.Adventurer{
 }
 
 func TestNewRepository(t *testing.T) {
-	res, err := NewRepository()
+	db, _ := NewMock()
+	defer db.Close()
+
+	res, err := NewRepository(db, database.Postgres)
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
+
+	res, err = NewRepository(nil, database.Postgres)
+	assert.Equal(t, database.ErrNilDB, err)
+	assert.Nil(t, res)
 }
 
 func TestClose(t *testing.T) {
@@ -254,12 +264,99 @@ func TestAddCompletedQuest(t *testing.T) {
 	}
 }
 
+func TestAddFailedQuest(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	query := regexp.QuoteMeta("UPDATE adventurer SET failed_quest = failed_quest + 1 WHERE id = $1")
+	tests := []struct {
+		name    string
+		mock    func()
+		wantErr bool
+	}{
+		{
+			name: "success added failed quest an adventurer",
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(adv.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed added failed quest an adventurer",
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(adv.ID).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repository{
+				db: db,
+			}
+			tt.mock()
+			err := r.AddFailedQuest(adv.ID)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateAdventurerProgress(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	query := regexp.QuoteMeta("UPDATE adventurer SET rank = $1, rank_points = $2, failed_streak = $3 WHERE id = $4")
+	progressed := model.Adventurer{ID: adv.ID, Rank: 12, RankPoints: 3, FailedStreak: 1}
+	tests := []struct {
+		name    string
+		mock    func()
+		wantErr bool
+	}{
+		{
+			name: "success update progress of an adventurer",
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(progressed.Rank, progressed.RankPoints, progressed.FailedStreak, progressed.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed update progress of an adventurer",
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(progressed.Rank, progressed.RankPoints, progressed.FailedStreak, progressed.ID).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repository{
+				db: db,
+			}
+			tt.mock()
+			err := r.UpdateAdventurerProgress(progressed)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestGetAdventurer(t *testing.T) {
 	db, mock := NewMock()
 	defer func() {
 		db.Close()
 	}()
-	query := regexp.QuoteMeta("SELECT name, rank, completed_quest FROM adventurer WHERE id = $1")
+	query := regexp.QuoteMeta("SELECT name, rank, completed_quest, failed_quest, rank_points, failed_streak FROM adventurer WHERE id = $1")
 	type fields struct {
 		db *sql.DB
 	}
@@ -283,8 +380,8 @@ func TestGetAdventurer(t *testing.T) {
 				ID: adv.ID,
 			},
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"name", "rank", "completed_quest"}).
-					AddRow(adv.Name, adv.Rank, adv.CompletedQuest)
+				rows := sqlmock.NewRows([]string{"name", "rank", "completed_quest", "failed_quest", "rank_points", "failed_streak"}).
+					AddRow(adv.Name, adv.Rank, adv.CompletedQuest, adv.FailedQuest, adv.RankPoints, adv.FailedStreak)
 
 				mock.ExpectQuery(query).WithArgs(adv.ID).WillReturnRows(rows)
 			},
@@ -300,7 +397,7 @@ func TestGetAdventurer(t *testing.T) {
 				ID: adv.ID,
 			},
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"name", "rank", "completed_quest"})
+				rows := sqlmock.NewRows([]string{"name", "rank", "completed_quest", "failed_quest", "rank_points", "failed_streak"})
 
 				mock.ExpectQuery(query).WithArgs(adv.ID).WillReturnRows(rows)
 			},
@@ -325,3 +422,98 @@ func TestGetAdventurer(t *testing.T) {
 		})
 	}
 }
+
+func TestListAdventurers(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	filter := model.AdventurerFilter{NamePrefix: "An_", MinRank: 11, MaxRank: 13, Sort: constant.SortCompletedQuest, Desc: true, Limit: 20, Offset: 40}
+	where := ` WHERE LOWER(name) LIKE $1 ESCAPE '\' AND rank >= $2 AND rank <= $3`
+	countQuery := regexp.QuoteMeta("SELECT COUNT(*) FROM adventurer" + where)
+	query := regexp.QuoteMeta("SELECT id, name, rank, completed_quest, failed_quest, rank_points, failed_streak FROM adventurer" +
+		where + " ORDER BY completed_quest DESC, id DESC LIMIT $4 OFFSET $5")
+	args := []driver.Value{`an\_%`, 11, 13}
+	columns := []string{"id", "name", "rank", "completed_quest", "failed_quest", "rank_points", "failed_streak"}
+	tests := []struct {
+		name    string
+		mock    func()
+		out     []model.Adventurer
+		total   int
+		wantErr bool
+	}{
+		{
+			name: "success list adventurers",
+			mock: func() {
+				mock.ExpectQuery(countQuery).WithArgs(args...).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(41))
+				rows := sqlmock.NewRows(columns).AddRow(adv.ID, adv.Name, adv.Rank, adv.CompletedQuest, 0, 0, 0)
+				mock.ExpectQuery(query).WithArgs(append(args, 20, 40)...).WillReturnRows(rows)
+			},
+			out:     []model.Adventurer{adv},
+			total:   41,
+			wantErr: false,
+		},
+		{
+			name: "failed count query",
+			mock: func() {
+				mock.ExpectQuery(countQuery).WithArgs(args...).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed query",
+			mock: func() {
+				mock.ExpectQuery(countQuery).WithArgs(args...).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(41))
+				mock.ExpectQuery(query).WithArgs(append(args, 20, 40)...).WillReturnError(sql.ErrConnDone)
+			},
+			out:     []model.Adventurer{},
+			total:   41,
+			wantErr: true,
+		},
+		{
+			name: "failed scan query",
+			mock: func() {
+				mock.ExpectQuery(countQuery).WithArgs(args...).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(41))
+				rows := sqlmock.NewRows(columns).AddRow(adv.ID, nil, adv.Rank, adv.CompletedQuest, 0, 0, 0)
+				mock.ExpectQuery(query).WithArgs(append(args, 20, 40)...).WillReturnRows(rows)
+			},
+			out:     []model.Adventurer{},
+			total:   41,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repository{
+				db: db,
+			}
+			tt.mock()
+			res, total, err := r.ListAdventurers(filter)
+			assert.Equal(t, tt.out, res)
+			assert.Equal(t, tt.total, total)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListAdventurersWithoutFilter(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM adventurer")).WithArgs().WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM adventurer ORDER BY id ASC LIMIT $1 OFFSET $2")).WithArgs(20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "rank", "completed_quest", "failed_quest", "rank_points", "failed_streak"}))
+
+	r := &repository{db: db}
+	res, total, err := r.ListAdventurers(model.AdventurerFilter{Limit: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Adventurer{}, res)
+	assert.Equal(t, 0, total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,193 @@
+package adventurer
+
+import (
+	"database/sql"
+	"testing"
+
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/database"
+	"github.com/arfaghifari/guild-board/src/database/databasetest"
+	"github.com/arfaghifari/guild-board/src/database/memory"
+	model "github.com/arfaghifari/guild-board/src/model/adventurer"
+	"github.com/stretchr/testify/assert"
+)
+
+// backends run the same suite against every Repository implementation,
+// each starting without any adventurer.
+var backends = []struct {
+	name string
+	new  func(t *testing.T) Repository
+}{
+	{
+		name: "memory",
+		new: func(t *testing.T) Repository {
+			return NewMemoryRepository(memory.NewStore())
+		},
+	},
+	{
+		name: "sqlite",
+		new: func(t *testing.T) Repository {
+			return &repository{db: databasetest.NewSQLite(t), dialect: database.SQLite}
+		},
+	},
+}
+
+func TestNewMemoryRepository(t *testing.T) {
+	res := NewMemoryRepository(memory.NewStore())
+	assert.NotNil(t, res)
+	res.Close()
+}
+
+func TestBackendCreateAdventurer(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+
+			res, err := r.CreateAdventurer(model.Adventurer{Name: adv.Name, Rank: adv.Rank, CompletedQuest: 5, FailedQuest: 2, RankPoints: 4, FailedStreak: 1})
+			assert.NoError(t, err)
+			assert.Equal(t, adv, res)
+
+			res, err = r.CreateAdventurer(model.Adventurer{Name: "budi", Rank: 12})
+			assert.NoError(t, err)
+			assert.Equal(t, int64(2), res.ID)
+		})
+	}
+}
+
+func TestBackendGetAdventurer(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+			created, _ := r.CreateAdventurer(adv)
+
+			res, err := r.GetAdventurer(created.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, adv, res)
+
+			res, err = r.GetAdventurer(99)
+			assert.Equal(t, sql.ErrNoRows, err)
+			assert.Equal(t, model.Adventurer{ID: 99}, res)
+		})
+	}
+}
+
+func TestBackendUpdateAdventurer(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+			created, _ := r.CreateAdventurer(adv)
+
+			assert.NoError(t, r.UpdateAdventurerRank(model.Adventurer{ID: created.ID, Rank: 14}))
+			assert.NoError(t, r.AddCompletedQuest(created.ID))
+			assert.NoError(t, r.AddCompletedQuest(created.ID))
+			assert.NoError(t, r.UpdateAdventurerRank(model.Adventurer{ID: 99, Rank: 14}))
+			assert.NoError(t, r.AddCompletedQuest(99))
+			assert.NoError(t, r.AddFailedQuest(created.ID))
+			assert.NoError(t, r.AddFailedQuest(99))
+
+			res, err := r.GetAdventurer(created.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, int32(14), res.Rank)
+			assert.Equal(t, int32(2), res.CompletedQuest)
+			assert.Equal(t, int32(1), res.FailedQuest)
+		})
+	}
+}
+
+func TestBackendUpdateAdventurerProgress(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+			created, _ := r.CreateAdventurer(adv)
+			assert.NoError(t, r.AddCompletedQuest(created.ID))
+
+			assert.NoError(t, r.UpdateAdventurerProgress(model.Adventurer{ID: created.ID, Rank: 12, RankPoints: 3, FailedStreak: 1}))
+			assert.NoError(t, r.UpdateAdventurerProgress(model.Adventurer{ID: 99, Rank: 12}))
+
+			res, err := r.GetAdventurer(created.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, model.Adventurer{ID: created.ID, Name: adv.Name, Rank: 12, CompletedQuest: 1, RankPoints: 3, FailedStreak: 1}, res)
+		})
+	}
+}
+
+func TestBackendListAdventurers(t *testing.T) {
+	seed := func(r Repository) []model.Adventurer {
+		created := []model.Adventurer{}
+		for i, a := range []model.Adventurer{{Name: "andi", Rank: 11}, {Name: "budi", Rank: 12}, {Name: "Andika", Rank: 13}, {Name: "cici", Rank: 14}} {
+			res, _ := r.CreateAdventurer(a)
+			for n := 0; n < []int{2, 0, 2, 1}[i]; n++ {
+				r.AddCompletedQuest(res.ID)
+			}
+			res, _ = r.GetAdventurer(res.ID)
+			created = append(created, res)
+		}
+		return created
+	}
+	tests := []struct {
+		name   string
+		filter model.AdventurerFilter
+		out    []int
+		total  int
+	}{
+		{
+			name:   "no filter",
+			filter: model.AdventurerFilter{Limit: 10},
+			out:    []int{0, 1, 2, 3},
+			total:  4,
+		},
+		{
+			name:   "name prefix ignores case",
+			filter: model.AdventurerFilter{NamePrefix: "AND", Limit: 10},
+			out:    []int{0, 2},
+			total:  2,
+		},
+		{
+			name:   "name is matched by prefix only",
+			filter: model.AdventurerFilter{NamePrefix: "di", Limit: 10},
+			out:    []int{},
+			total:  0,
+		},
+		{
+			name:   "rank range",
+			filter: model.AdventurerFilter{MinRank: 12, MaxRank: 13, Limit: 10},
+			out:    []int{1, 2},
+			total:  2,
+		},
+		{
+			name:   "most completed quests first",
+			filter: model.AdventurerFilter{Sort: constant.SortCompletedQuest, Desc: true, Limit: 10},
+			out:    []int{2, 0, 3, 1},
+			total:  4,
+		},
+		{
+			name:   "sort by rank descending",
+			filter: model.AdventurerFilter{Sort: constant.SortRank, Desc: true, Limit: 2},
+			out:    []int{3, 2},
+			total:  4,
+		},
+		{
+			name:   "second page",
+			filter: model.AdventurerFilter{Limit: 3, Offset: 3},
+			out:    []int{3},
+			total:  4,
+		},
+	}
+	for _, b := range backends {
+		for _, tt := range tests {
+			t.Run(b.name+"/"+tt.name, func(t *testing.T) {
+				r := b.new(t)
+				created := seed(r)
+				want := []model.Adventurer{}
+				for _, i := range tt.out {
+					want = append(want, created[i])
+				}
+
+				res, total, err := r.ListAdventurers(tt.filter)
+				assert.NoError(t, err)
+				assert.Equal(t, want, res)
+				assert.Equal(t, tt.total, total)
+			})
+		}
+	}
+}
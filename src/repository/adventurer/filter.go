@@ -0,0 +1,54 @@
+package adventurer
+
+import (
+	"fmt"
+	"strings"
+
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/database"
+	model "github.com/arfaghifari/guild-board/src/model/adventurer"
+)
+
+var sortColumns = map[string]string{
+	constant.SortID:             "id",
+	constant.SortRank:           "rank",
+	constant.SortCompletedQuest: "completed_quest",
+}
+
+func adventurerConditions(filter model.AdventurerFilter) *database.Conditions {
+	c := &database.Conditions{}
+	if filter.NamePrefix != "" {
+		c.Add(`LOWER(name) LIKE %s ESCAPE '\'`, database.EscapeLike(strings.ToLower(filter.NamePrefix))+"%")
+	}
+	if filter.MinRank > 0 {
+		c.Add("rank >= %s", filter.MinRank)
+	}
+	if filter.MaxRank > 0 {
+		c.Add("rank <= %s", filter.MaxRank)
+	}
+	return c
+}
+
+func countAdventurersQuery(c *database.Conditions) (string, []interface{}) {
+	return "SELECT COUNT(*) FROM adventurer" + c.Where(), c.Args()
+}
+
+func listAdventurersQuery(c *database.Conditions, filter model.AdventurerFilter) (string, []interface{}) {
+	column, ok := sortColumns[filter.Sort]
+	if !ok {
+		column = sortColumns[constant.SortID]
+	}
+	direction := "ASC"
+	if filter.Desc {
+		direction = "DESC"
+	}
+
+	order := fmt.Sprintf("%s %s", column, direction)
+	if column != "id" {
+		order += ", id " + direction
+	}
+
+	query := "SELECT id, name, rank, completed_quest, failed_quest, rank_points, failed_streak FROM adventurer" + c.Where() +
+		fmt.Sprintf(" ORDER BY %s LIMIT %s OFFSET %s", order, c.Next(1), c.Next(2))
+	return query, c.Args(filter.Limit, filter.Offset)
+}
@@ -0,0 +1,144 @@
+package adventurer
+
+import (
+	"database/sql"
+	"sort"
+	"strings"
+
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/database/memory"
+	model "github.com/arfaghifari/guild-board/src/model/adventurer"
+)
+
+type memoryRepository struct {
+	store *memory.Store
+}
+
+// NewMemoryRepository returns a Repository backed by store, with the same
+// semantics as the SQL repository.
+func NewMemoryRepository(store *memory.Store) Repository {
+	return &memoryRepository{store}
+}
+
+func (r *memoryRepository) Close() {}
+
+func (r *memoryRepository) CreateAdventurer(adventurer model.Adventurer) (adv model.Adventurer, err error) {
+	err = r.store.Write(func(d *memory.Data) error {
+		d.LastAdvID++
+		adv = adventurer
+		adv.ID = d.LastAdvID
+		adv.CompletedQuest = 0
+		adv.FailedQuest = 0
+		adv.RankPoints = 0
+		adv.FailedStreak = 0
+		d.Adventurers[adv.ID] = adv
+		return nil
+	})
+	return
+}
+
+func (r *memoryRepository) UpdateAdventurerRank(adventurer model.Adventurer) error {
+	return r.store.Write(func(d *memory.Data) error {
+		if stored, ok := d.Adventurers[adventurer.ID]; ok {
+			stored.Rank = adventurer.Rank
+			d.Adventurers[adventurer.ID] = stored
+		}
+		return nil
+	})
+}
+
+func (r *memoryRepository) GetAdventurer(id int64) (adventurer model.Adventurer, err error) {
+	adventurer.ID = id
+	r.store.Read(func(d *memory.Data) {
+		stored, ok := d.Adventurers[id]
+		if !ok {
+			err = sql.ErrNoRows
+			return
+		}
+		adventurer = stored
+	})
+	return
+}
+
+func (r *memoryRepository) AddCompletedQuest(id int64) error {
+	return r.store.Write(func(d *memory.Data) error {
+		if stored, ok := d.Adventurers[id]; ok {
+			stored.CompletedQuest++
+			d.Adventurers[id] = stored
+		}
+		return nil
+	})
+}
+
+func (r *memoryRepository) AddFailedQuest(id int64) error {
+	return r.store.Write(func(d *memory.Data) error {
+		if stored, ok := d.Adventurers[id]; ok {
+			stored.FailedQuest++
+			d.Adventurers[id] = stored
+		}
+		return nil
+	})
+}
+
+func (r *memoryRepository) UpdateAdventurerProgress(adventurer model.Adventurer) error {
+	return r.store.Write(func(d *memory.Data) error {
+		if stored, ok := d.Adventurers[adventurer.ID]; ok {
+			stored.Rank = adventurer.Rank
+			stored.RankPoints = adventurer.RankPoints
+			stored.FailedStreak = adventurer.FailedStreak
+			d.Adventurers[adventurer.ID] = stored
+		}
+		return nil
+	})
+}
+
+func (r *memoryRepository) ListAdventurers(filter model.AdventurerFilter) ([]model.Adventurer, int, error) {
+	adventurers := []model.Adventurer{}
+	r.store.Read(func(d *memory.Data) {
+		for _, adv := range d.Adventurers {
+			if matchAdventurer(adv, filter) {
+				adventurers = append(adventurers, adv)
+			}
+		}
+	})
+
+	key := func(adv model.Adventurer) int64 {
+		switch filter.Sort {
+		case constant.SortRank:
+			return int64(adv.Rank)
+		case constant.SortCompletedQuest:
+			return int64(adv.CompletedQuest)
+		}
+		return adv.ID
+	}
+	sort.Slice(adventurers, func(i, j int) bool {
+		a, b := adventurers[i], adventurers[j]
+		if filter.Desc {
+			a, b = b, a
+		}
+		if key(a) != key(b) {
+			return key(a) < key(b)
+		}
+		return a.ID < b.ID
+	})
+
+	total := len(adventurers)
+	start, end := filter.Offset, filter.Offset+filter.Limit
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+	return adventurers[start:end], total, nil
+}
+
+func matchAdventurer(adv model.Adventurer, filter model.AdventurerFilter) bool {
+	if filter.MinRank > 0 && adv.Rank < filter.MinRank {
+		return false
+	}
+	if filter.MaxRank > 0 && adv.Rank > filter.MaxRank {
+		return false
+	}
+	return strings.HasPrefix(strings.ToLower(adv.Name), strings.ToLower(filter.NamePrefix))
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"database/sql"
+
+	"github.com/arfaghifari/guild-board/src/database"
+	model "github.com/arfaghifari/guild-board/src/model/auth"
+)
+
+type Repository interface {
+	Close()
+	CreateAPIKey(model.APIKey) (model.APIKey, error)
+	GetAPIKeyByHash(string) (model.APIKey, error)
+}
+
+type repository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+func NewRepository(db *sql.DB, dialect database.Dialect) (Repository, error) {
+	if db == nil {
+		return nil, database.ErrNilDB
+	}
+
+	return &repository{db: db, dialect: dialect}, nil
+}
+
+func (r *repository) Close() {
+	r.db.Close()
+}
+
+func (r *repository) CreateAPIKey(key model.APIKey) (model.APIKey, error) {
+	db := database.Bind(r.db, r.dialect)
+	query := `INSERT INTO api_key(name, role, adv_id, giver_id, key_hash, created_at)
+	VALUES($1, $2, $3, $4, $5, $6)` + r.dialect.Returning("id")
+	createForm, err := db.Prepare(query)
+	if err != nil {
+		return model.APIKey{}, err
+	}
+	defer createForm.Close()
+	advID := sql.NullInt64{Int64: key.AdventurerID, Valid: key.AdventurerID > 0}
+	giverID := sql.NullInt64{Int64: key.GiverID, Valid: key.GiverID > 0}
+	key.ID, err = r.dialect.InsertID(createForm, key.Name, key.Role, advID, giverID, key.Hash, key.CreatedAt.UTC())
+	if err != nil {
+		return model.APIKey{}, err
+	}
+	return key, nil
+}
+
+// GetAPIKeyByHash finds the key whose SHA-256 hash is hash.
+func (r *repository) GetAPIKeyByHash(hash string) (key model.APIKey, err error) {
+	db := database.Bind(r.db, r.dialect)
+	query := `SELECT id, name, role, adv_id, giver_id, created_at
+	FROM api_key
+	WHERE key_hash = $1`
+	var advID, giverID sql.NullInt64
+	err = db.QueryRow(query, hash).Scan(&key.ID, &key.Name, &key.Role, &advID, &giverID, &key.CreatedAt)
+	if err != nil {
+		return model.APIKey{}, err
+	}
+	key.AdventurerID = advID.Int64
+	key.GiverID = giverID.Int64
+	key.Hash = hash
+	return key, nil
+}
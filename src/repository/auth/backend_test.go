@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/database"
+	"github.com/arfaghifari/guild-board/src/database/databasetest"
+	"github.com/arfaghifari/guild-board/src/database/memory"
+	model "github.com/arfaghifari/guild-board/src/model/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+// backends run the same suite against every Repository implementation, each
+// starting without any key and with adventurer 1 and quest giver 1.
+var backends = []struct {
+	name string
+	new  func(t *testing.T) Repository
+}{
+	{
+		name: "memory",
+		new: func(t *testing.T) Repository {
+			return NewMemoryRepository(memory.NewStore())
+		},
+	},
+	{
+		name: "sqlite",
+		new: func(t *testing.T) Repository {
+			db := databasetest.NewSQLite(t)
+			if _, err := db.Exec(`INSERT INTO adventurer(name, rank) VALUES('andi', 11)`); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := db.Exec(`INSERT INTO quest_giver(name, created_at) VALUES('mayor of riverwood', CURRENT_TIMESTAMP)`); err != nil {
+				t.Fatal(err)
+			}
+			return &repository{db: db, dialect: database.SQLite}
+		},
+	},
+}
+
+func TestNewRepository(t *testing.T) {
+	_, err := NewRepository(nil, database.Postgres)
+	assert.Equal(t, database.ErrNilDB, err)
+}
+
+func TestBackendAPIKey(t *testing.T) {
+	createdAt := time.Date(2023, time.July, 1, 9, 0, 0, 0, time.UTC)
+	staff := model.APIKey{Name: "front desk", Role: constant.RoleStaff, Hash: "staff-hash", CreatedAt: createdAt}
+	andi := model.APIKey{Name: "andi", Role: constant.RoleAdventurer, AdventurerID: 1, Hash: "andi-hash", CreatedAt: createdAt}
+	mayor := model.APIKey{Name: "mayor", Role: constant.RoleGiver, GiverID: 1, Hash: "mayor-hash", CreatedAt: createdAt}
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+
+			res, err := r.CreateAPIKey(staff)
+			assert.NoError(t, err)
+			staff.ID = res.ID
+			assert.Equal(t, staff, res)
+			res, err = r.CreateAPIKey(andi)
+			assert.NoError(t, err)
+			andi.ID = res.ID
+			assert.Equal(t, int64(2), res.ID)
+			res, err = r.CreateAPIKey(mayor)
+			assert.NoError(t, err)
+			mayor.ID = res.ID
+
+			_, err = r.CreateAPIKey(model.APIKey{Name: "copy", Role: constant.RoleStaff, Hash: "staff-hash", CreatedAt: createdAt})
+			assert.Error(t, err)
+
+			res, err = r.GetAPIKeyByHash("andi-hash")
+			assert.NoError(t, err)
+			assert.Equal(t, andi, res)
+			res, err = r.GetAPIKeyByHash("mayor-hash")
+			assert.NoError(t, err)
+			assert.Equal(t, mayor, res)
+			res, err = r.GetAPIKeyByHash("staff-hash")
+			assert.NoError(t, err)
+			assert.Equal(t, staff, res)
+
+			_, err = r.GetAPIKeyByHash("unknown")
+			assert.Equal(t, sql.ErrNoRows, err)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/arfaghifari/guild-board/src/database/memory"
+	model "github.com/arfaghifari/guild-board/src/model/auth"
+)
+
+// errDuplicateHash stands for the unique constraint on api_key.key_hash.
+var errDuplicateHash = errors.New("api key hash already exists")
+
+type memoryRepository struct {
+	store *memory.Store
+}
+
+// NewMemoryRepository returns a Repository backed by store, with the same
+// semantics as the SQL repository.
+func NewMemoryRepository(store *memory.Store) Repository {
+	return &memoryRepository{store}
+}
+
+func (r *memoryRepository) Close() {}
+
+func (r *memoryRepository) CreateAPIKey(key model.APIKey) (model.APIKey, error) {
+	err := r.store.Write(func(d *memory.Data) error {
+		for _, stored := range d.APIKeys {
+			if stored.Hash == key.Hash {
+				return errDuplicateHash
+			}
+		}
+		d.LastAPIKeyID++
+		key.ID = d.LastAPIKeyID
+		d.APIKeys[key.ID] = key
+		return nil
+	})
+	if err != nil {
+		return model.APIKey{}, err
+	}
+	return key, nil
+}
+
+func (r *memoryRepository) GetAPIKeyByHash(hash string) (key model.APIKey, err error) {
+	err = sql.ErrNoRows
+	r.store.Read(func(d *memory.Data) {
+		for _, stored := range d.APIKeys {
+			if stored.Hash == hash {
+				key, err = stored, nil
+				return
+			}
+		}
+	})
+	return
+}
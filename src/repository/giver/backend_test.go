@@ -0,0 +1,71 @@
+package giver
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/arfaghifari/guild-board/src/database"
+	"github.com/arfaghifari/guild-board/src/database/databasetest"
+	"github.com/arfaghifari/guild-board/src/database/memory"
+	model "github.com/arfaghifari/guild-board/src/model/giver"
+	"github.com/stretchr/testify/assert"
+)
+
+// backends run the same suite against every Repository implementation, each
+// starting without any quest giver.
+var backends = []struct {
+	name string
+	new  func(t *testing.T) Repository
+}{
+	{
+		name: "memory",
+		new: func(t *testing.T) Repository {
+			return NewMemoryRepository(memory.NewStore())
+		},
+	},
+	{
+		name: "sqlite",
+		new: func(t *testing.T) Repository {
+			return &repository{db: databasetest.NewSQLite(t), dialect: database.SQLite}
+		},
+	},
+}
+
+func TestNewRepository(t *testing.T) {
+	_, err := NewRepository(nil, database.Postgres)
+	assert.Equal(t, database.ErrNilDB, err)
+}
+
+func TestNewMemoryRepository(t *testing.T) {
+	res := NewMemoryRepository(memory.NewStore())
+	assert.NotNil(t, res)
+	res.Close()
+}
+
+func TestBackendGiver(t *testing.T) {
+	createdAt := time.Date(2023, time.July, 1, 9, 0, 0, 0, time.UTC)
+	mayor := model.Giver{Name: "mayor of riverwood", CreatedAt: createdAt}
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+
+			res, err := r.CreateGiver(mayor)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), res.ID)
+			mayor.ID = res.ID
+			assert.Equal(t, mayor, res)
+			res, err = r.CreateGiver(model.Giver{Name: "merchant guild", CreatedAt: createdAt})
+			assert.NoError(t, err)
+			assert.Equal(t, int64(2), res.ID)
+
+			res, err = r.GetGiver(1)
+			assert.NoError(t, err)
+			assert.Equal(t, mayor, res)
+
+			res, err = r.GetGiver(99)
+			assert.Equal(t, sql.ErrNoRows, err)
+			assert.Equal(t, model.Giver{ID: 99}, res)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package giver
+
+import (
+	"database/sql"
+
+	"github.com/arfaghifari/guild-board/src/database"
+	model "github.com/arfaghifari/guild-board/src/model/giver"
+)
+
+type Repository interface {
+	Close()
+	CreateGiver(model.Giver) (model.Giver, error)
+	GetGiver(int64) (model.Giver, error)
+}
+
+type repository struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+func NewRepository(db *sql.DB, dialect database.Dialect) (Repository, error) {
+	if db == nil {
+		return nil, database.ErrNilDB
+	}
+
+	return &repository{db: db, dialect: dialect}, nil
+}
+
+func (r *repository) Close() {
+	r.db.Close()
+}
+
+func (r *repository) CreateGiver(giver model.Giver) (model.Giver, error) {
+	db := database.Bind(r.db, r.dialect)
+	query := `INSERT INTO quest_giver(name, created_at)
+	VALUES($1, $2)` + r.dialect.Returning("id")
+	createForm, err := db.Prepare(query)
+	if err != nil {
+		return model.Giver{}, err
+	}
+	defer createForm.Close()
+	giver.ID, err = r.dialect.InsertID(createForm, giver.Name, giver.CreatedAt.UTC())
+	if err != nil {
+		return model.Giver{}, err
+	}
+	return giver, nil
+}
+
+func (r *repository) GetGiver(id int64) (giver model.Giver, err error) {
+	db := database.Bind(r.db, r.dialect)
+	query := `SELECT id, name, created_at
+	FROM quest_giver
+	WHERE id = $1`
+	err = db.QueryRow(query, id).Scan(&giver.ID, &giver.Name, &giver.CreatedAt)
+	if err != nil {
+		return model.Giver{ID: id}, err
+	}
+	return giver, nil
+}
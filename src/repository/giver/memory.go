@@ -0,0 +1,42 @@
+package giver
+
+import (
+	"database/sql"
+
+	"github.com/arfaghifari/guild-board/src/database/memory"
+	model "github.com/arfaghifari/guild-board/src/model/giver"
+)
+
+type memoryRepository struct {
+	store *memory.Store
+}
+
+// NewMemoryRepository returns a Repository backed by store, with the same
+// semantics as the SQL repository.
+func NewMemoryRepository(store *memory.Store) Repository {
+	return &memoryRepository{store}
+}
+
+func (r *memoryRepository) Close() {}
+
+func (r *memoryRepository) CreateGiver(giver model.Giver) (model.Giver, error) {
+	r.store.Write(func(d *memory.Data) error {
+		d.LastGiverID++
+		giver.ID = d.LastGiverID
+		d.Givers[giver.ID] = giver
+		return nil
+	})
+	return giver, nil
+}
+
+func (r *memoryRepository) GetGiver(id int64) (giver model.Giver, err error) {
+	r.store.Read(func(d *memory.Data) {
+		stored, ok := d.Givers[id]
+		if !ok {
+			giver, err = model.Giver{ID: id}, sql.ErrNoRows
+			return
+		}
+		giver = stored
+	})
+	return
+}
@@ -0,0 +1,127 @@
+package ledger
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/database"
+	"github.com/arfaghifari/guild-board/src/database/databasetest"
+	"github.com/arfaghifari/guild-board/src/database/memory"
+	model "github.com/arfaghifari/guild-board/src/model/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+// backends run the same suite against every Repository implementation, each
+// starting with an empty ledger.
+var backends = []struct {
+	name string
+	new  func(t *testing.T) Repository
+}{
+	{
+		name: "memory",
+		new: func(t *testing.T) Repository {
+			return NewMemoryRepository(memory.NewStore())
+		},
+	},
+	{
+		name: "sqlite",
+		new: func(t *testing.T) Repository {
+			return &repository{db: databasetest.NewSQLite(t), dialect: database.SQLite}
+		},
+	},
+}
+
+func TestNewRepository(t *testing.T) {
+	_, err := NewRepository(nil, database.Postgres)
+	assert.Equal(t, database.ErrNilDB, err)
+}
+
+func TestNewMemoryRepository(t *testing.T) {
+	res := NewMemoryRepository(memory.NewStore())
+	assert.NotNil(t, res)
+	res.Close()
+}
+
+func TestBackendLedger(t *testing.T) {
+	createdAt := time.Date(2023, time.July, 1, 9, 0, 0, 0, time.UTC)
+	escrow := model.Transaction{
+		QuestID: 1,
+		Kind:    constant.LedgerEscrow,
+		Entries: []model.Entry{
+			{Account: model.GiverAccount(1), Amount: -300},
+			{Account: model.EscrowAccount(1), Amount: 300},
+		},
+		CreatedAt: createdAt,
+	}
+	release := model.Transaction{
+		QuestID: 1,
+		Kind:    constant.LedgerRelease,
+		Entries: []model.Entry{
+			{Account: model.EscrowAccount(1), Amount: -300},
+			{Account: model.AdventurerAccount(1), Amount: 150},
+			{Account: model.AdventurerAccount(2), Amount: 150},
+		},
+		CreatedAt: createdAt,
+	}
+	guild := model.Transaction{
+		Kind: constant.LedgerEscrow,
+		Entries: []model.Entry{
+			{Account: model.Guild, Amount: -100},
+			{Account: model.GiverAccount(1), Amount: 100},
+		},
+		CreatedAt: createdAt,
+	}
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+
+			for i, tx := range []model.Transaction{escrow, release, guild} {
+				res, err := r.PostTransaction(tx)
+				assert.NoError(t, err)
+				tx.ID = int64(i + 1)
+				assert.Equal(t, tx, res)
+			}
+
+			balances := map[model.Account]int64{
+				model.Guild:                -100,
+				model.GiverAccount(1):      -200,
+				model.EscrowAccount(1):     0,
+				model.AdventurerAccount(1): 150,
+				model.AdventurerAccount(2): 150,
+				model.AdventurerAccount(3): 0,
+			}
+			for account, want := range balances {
+				res, err := r.GetBalance(account)
+				assert.NoError(t, err)
+				assert.Equal(t, model.Balance{Account: account, Balance: want}, res, account)
+			}
+		})
+	}
+}
+
+func TestBackendPayout(t *testing.T) {
+	payout := model.Payout{
+		QuestID:    1,
+		Gross:      200000,
+		GuildFee:   20000,
+		Net:        180000,
+		FeePercent: 10,
+		CreatedAt:  time.Date(2023, time.July, 1, 9, 0, 0, 0, time.UTC),
+	}
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+
+			_, err := r.GetPayout(1)
+			assert.Equal(t, sql.ErrNoRows, err)
+			assert.NoError(t, r.SavePayout(payout))
+			assert.Error(t, r.SavePayout(payout))
+
+			res, err := r.GetPayout(1)
+			assert.NoError(t, err)
+			assert.Equal(t, payout, res)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package ledger
+
+import (
+	"database/sql"
+
+	"github.com/arfaghifari/guild-board/src/database"
+	model "github.com/arfaghifari/guild-board/src/model/ledger"
+)
+
+type Repository interface {
+	Close()
+	PostTransaction(model.Transaction) (model.Transaction, error)
+	GetBalance(model.Account) (model.Balance, error)
+	SavePayout(model.Payout) error
+	GetPayout(questID int64) (model.Payout, error)
+}
+
+type repository struct {
+	db      *sql.DB
+	tx      *sql.Tx
+	dialect database.Dialect
+}
+
+func NewRepository(db *sql.DB, dialect database.Dialect) (Repository, error) {
+	if db == nil {
+		return nil, database.ErrNilDB
+	}
+
+	return &repository{db: db, dialect: dialect}, nil
+}
+
+// NewTxRepository returns a repository whose queries run inside tx.
+func NewTxRepository(tx *sql.Tx, dialect database.Dialect) Repository {
+	return &repository{tx: tx, dialect: dialect}
+}
+
+func (r *repository) Close() {
+	if r.db != nil {
+		r.db.Close()
+	}
+}
+
+func (r *repository) conn() database.Querier {
+	if r.tx != nil {
+		return database.Bind(r.tx, r.dialect)
+	}
+	return database.Bind(r.db, r.dialect)
+}
+
+// PostTransaction records tx and its entries. It does not check that they
+// balance; posting from outside a unit of work may leave a transaction without
+// some of its entries.
+func (r *repository) PostTransaction(tx model.Transaction) (model.Transaction, error) {
+	db := r.conn()
+	query := `INSERT INTO ledger_transaction(quest_id, kind, created_at)
+	VALUES($1, $2, $3)` + r.dialect.Returning("id")
+	createForm, err := db.Prepare(query)
+	if err != nil {
+		return model.Transaction{}, err
+	}
+	defer createForm.Close()
+	tx.ID, err = r.dialect.InsertID(createForm, nullID(tx.QuestID), tx.Kind, tx.CreatedAt.UTC())
+	if err != nil {
+		return model.Transaction{}, err
+	}
+
+	for _, entry := range tx.Entries {
+		query = `INSERT INTO ledger_entry(transaction_id, account, amount)
+		VALUES($1, $2, $3)`
+		if _, err = db.Exec(query, tx.ID, entry.Account, entry.Amount); err != nil {
+			return model.Transaction{}, err
+		}
+	}
+	return tx, nil
+}
+
+// GetBalance sums the entries of account; an account without entries holds
+// nothing.
+func (r *repository) GetBalance(account model.Account) (balance model.Balance, err error) {
+	db := r.conn()
+	query := `SELECT COALESCE(SUM(amount), 0)
+	FROM ledger_entry
+	WHERE account = $1`
+	balance.Account = account
+	err = db.QueryRow(query, account).Scan(&balance.Balance)
+	return
+}
+
+// SavePayout records the payout of a completed quest, at most one per quest.
+func (r *repository) SavePayout(payout model.Payout) error {
+	query := `INSERT INTO quest_payout(quest_id, gross, guild_fee, adventurer_net, fee_percent, created_at)
+	VALUES($1, $2, $3, $4, $5, $6)`
+	_, err := r.conn().Exec(query, payout.QuestID, payout.Gross, payout.GuildFee, payout.Net, payout.FeePercent, payout.CreatedAt.UTC())
+	return err
+}
+
+func (r *repository) GetPayout(questID int64) (payout model.Payout, err error) {
+	db := r.conn()
+	query := `SELECT quest_id, gross, guild_fee, adventurer_net, fee_percent, created_at
+	FROM quest_payout
+	WHERE quest_id = $1`
+	err = db.QueryRow(query, questID).Scan(&payout.QuestID, &payout.Gross, &payout.GuildFee, &payout.Net, &payout.FeePercent, &payout.CreatedAt)
+	if err != nil {
+		return model.Payout{QuestID: questID}, err
+	}
+	payout.CreatedAt = payout.CreatedAt.UTC()
+	return payout, nil
+}
+
+// nullID stores 0, the id of nothing, as NULL.
+func nullID(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
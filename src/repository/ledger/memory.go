@@ -0,0 +1,70 @@
+package ledger
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/arfaghifari/guild-board/src/database/memory"
+	model "github.com/arfaghifari/guild-board/src/model/ledger"
+)
+
+// errDuplicatePayout stands for the primary key on quest_payout.quest_id.
+var errDuplicatePayout = errors.New("quest payout already exists")
+
+type memoryRepository struct {
+	store *memory.Store
+}
+
+// NewMemoryRepository returns a Repository backed by store, with the same
+// semantics as the SQL repository.
+func NewMemoryRepository(store *memory.Store) Repository {
+	return &memoryRepository{store}
+}
+
+func (r *memoryRepository) Close() {}
+
+func (r *memoryRepository) PostTransaction(tx model.Transaction) (model.Transaction, error) {
+	tx.Entries = append([]model.Entry{}, tx.Entries...)
+	r.store.Write(func(d *memory.Data) error {
+		d.LastLedgerTransactionID++
+		tx.ID = d.LastLedgerTransactionID
+		d.LedgerTransactions = append(d.LedgerTransactions, tx)
+		return nil
+	})
+	return tx, nil
+}
+
+func (r *memoryRepository) GetBalance(account model.Account) (balance model.Balance, err error) {
+	balance.Account = account
+	r.store.Read(func(d *memory.Data) {
+		for _, tx := range d.LedgerTransactions {
+			for _, entry := range tx.Entries {
+				if entry.Account == account {
+					balance.Balance += entry.Amount
+				}
+			}
+		}
+	})
+	return
+}
+
+func (r *memoryRepository) SavePayout(payout model.Payout) error {
+	payout.CreatedAt = payout.CreatedAt.UTC()
+	return r.store.Write(func(d *memory.Data) error {
+		if _, ok := d.Payouts[payout.QuestID]; ok {
+			return errDuplicatePayout
+		}
+		d.Payouts[payout.QuestID] = payout
+		return nil
+	})
+}
+
+func (r *memoryRepository) GetPayout(questID int64) (payout model.Payout, err error) {
+	r.store.Read(func(d *memory.Data) {
+		var ok bool
+		if payout, ok = d.Payouts[questID]; !ok {
+			payout, err = model.Payout{QuestID: questID}, sql.ErrNoRows
+		}
+	})
+	return
+}
@@ -0,0 +1,78 @@
+package quest
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/arfaghifari/guild-board/src/database"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+)
+
+// CreateAssignment opens the history entry of an adventurer joining a quest.
+// The quest is looked up here since the history keeps no reference to it.
+func (r *repository) CreateAssignment(assignment model.Assignment) error {
+	db := r.conn()
+	var one int
+	query := `SELECT 1 FROM quest WHERE quest_id = $1`
+	err := db.QueryRow(query, assignment.QuestID).Scan(&one)
+	if err == sql.ErrNoRows {
+		return errAssignmentReference
+	}
+	if err != nil {
+		return err
+	}
+	query = `INSERT INTO assignment(quest_id, adv_id, taken_at)
+	VALUES($1, $2, $3)`
+	_, err = db.Exec(query, assignment.QuestID, assignment.AdventurerID, assignment.TakenAt.UTC())
+	return err
+}
+
+// FinishAssignment closes the open history entry of the adventurer on the
+// quest with the assignment's outcome.
+func (r *repository) FinishAssignment(assignment model.Assignment) error {
+	db := r.conn()
+	query := `UPDATE assignment
+	SET finished_at = $1, outcome = $2
+	WHERE quest_id = $3 AND adv_id = $4 AND outcome = ''`
+	_, err := db.Exec(query, nullTime(assignment.FinishedAt), assignment.Outcome, assignment.QuestID, assignment.AdventurerID)
+	return err
+}
+
+// ListAssignments returns the page of history entries selected by filter,
+// newest first, and the number of entries matching it across all pages.
+func (r *repository) ListAssignments(filter model.AssignmentFilter) (assignments []model.Assignment, total int, err error) {
+	db := r.conn()
+	c := &database.Conditions{}
+	if filter.QuestID > 0 {
+		c.Add("quest_id = %s", filter.QuestID)
+	}
+	if filter.AdventurerID > 0 {
+		c.Add("adv_id = %s", filter.AdventurerID)
+	}
+
+	if err = db.QueryRow("SELECT COUNT(*) FROM assignment"+c.Where(), c.Args()...).Scan(&total); err != nil {
+		return
+	}
+
+	assignments = []model.Assignment{}
+	query := "SELECT id, quest_id, adv_id, taken_at, finished_at, outcome FROM assignment" + c.Where() +
+		fmt.Sprintf(" ORDER BY taken_at DESC, id DESC LIMIT %s OFFSET %s", c.Next(1), c.Next(2))
+	rows, err := db.Query(query, c.Args(filter.Limit, filter.Offset)...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var assignment model.Assignment
+		var finishedAt sql.NullTime
+		if err = rows.Scan(&assignment.ID, &assignment.QuestID, &assignment.AdventurerID, &assignment.TakenAt, &finishedAt,
+			&assignment.Outcome); err != nil {
+			return
+		}
+		assignment.FinishedAt = timePtr(finishedAt)
+		assignments = append(assignments, assignment)
+	}
+
+	return
+}
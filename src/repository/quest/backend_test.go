@@ -0,0 +1,716 @@
+package quest
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/database"
+	"github.com/arfaghifari/guild-board/src/database/databasetest"
+	"github.com/arfaghifari/guild-board/src/database/memory"
+	modelAdv "github.com/arfaghifari/guild-board/src/model/adventurer"
+	modelGiver "github.com/arfaghifari/guild-board/src/model/giver"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+	"github.com/stretchr/testify/assert"
+)
+
+// other is a second adventurer that has not taken any quest.
+var other = modelAdv.Adventurer{ID: 2, Name: "budi", Rank: 13}
+
+// backends run the same suite against every Repository implementation. Each
+// one is seeded with bulkQuest, its giver, adv and other, with adv taking
+// bulkQuest[1].
+var backends = []struct {
+	name string
+	new  func(t *testing.T) Repository
+}{
+	{
+		name: "memory",
+		new: func(t *testing.T) Repository {
+			return NewMemoryRepository(newMemoryStore())
+		},
+	},
+	{
+		name: "sqlite",
+		new:  newSQLiteRepository,
+	},
+}
+
+func newMemoryStore() *memory.Store {
+	store := memory.NewStore()
+	store.Write(func(d *memory.Data) error {
+		d.Givers[giverID] = modelGiver.Giver{ID: giverID, Name: "mayor of riverwood", CreatedAt: createdAt}
+		d.LastGiverID = giverID
+		for _, quest := range bulkQuest {
+			d.Quests[quest.ID] = quest
+			d.LastQuestID = quest.ID
+		}
+		d.Adventurers[adv.ID] = adv
+		d.Adventurers[other.ID] = other
+		d.LastAdvID = other.ID
+		d.TakenBy = append(d.TakenBy, model.TakenBy{QuestID: bulkQuest[1].ID, AdventurerID: adv.ID})
+		return nil
+	})
+	return store
+}
+
+func newSQLiteRepository(t *testing.T) Repository {
+	db := databasetest.NewSQLite(t)
+	if _, err := db.Exec(`INSERT INTO quest_giver(id, name, created_at) VALUES(?, ?, ?)`, giverID, "mayor of riverwood", createdAt); err != nil {
+		t.Fatal(err)
+	}
+	for _, quest := range bulkQuest {
+		_, err := db.Exec(`INSERT INTO quest(quest_id, name, description, minimum_rank, reward_number, status, created_at, deadline, taken_at,
+			min_members, max_members, rank_rule, members, created_by)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, quest.ID, quest.Name, quest.Description, quest.MinimumRank, quest.RewardNumber, quest.Status,
+			quest.CreatedAt, nullable(quest.Deadline), nullable(quest.TakenAt), quest.MinMembers, quest.MaxMembers, quest.RankRule, quest.Members,
+			nullableID(quest.CreatedBy))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, a := range []modelAdv.Adventurer{adv, other} {
+		_, err := db.Exec(`INSERT INTO adventurer(id, name, rank, completed_quest) VALUES(?, ?, ?, ?)`,
+			a.ID, a.Name, a.Rank, a.CompletedQuest)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := db.Exec(`INSERT INTO taken_by(quest_id, adv_id) VALUES(?, ?)`, bulkQuest[1].ID, adv.ID); err != nil {
+		t.Fatal(err)
+	}
+	return &repository{db: db, dialect: database.SQLite}
+}
+
+func TestNewMemoryRepository(t *testing.T) {
+	res := NewMemoryRepository(memory.NewStore())
+	assert.NotNil(t, res)
+	res.Close()
+}
+
+func TestBackendGetQuestsByStatus(t *testing.T) {
+	working := bulkQuestByStatus[1]
+	working.Takers = []model.Taker{{AdventurerID: adv.ID, Name: adv.Name, Rank: adv.Rank}}
+	tests := []struct {
+		name   string
+		status int32
+		out    []model.GetQuestByStatus
+	}{
+		{
+			name:   "available",
+			status: constant.AvailableQuest,
+			out:    bulkQuestByStatus[0:1],
+		},
+		{
+			name:   "working with takers",
+			status: constant.WorkingQuest,
+			out:    []model.GetQuestByStatus{working, bulkQuestByStatus[2]},
+		},
+		{
+			name:   "completed",
+			status: constant.CompletedQuest,
+			out:    []model.GetQuestByStatus{},
+		},
+		{
+			name:   "cancelled",
+			status: constant.CancelledQuest,
+			out:    []model.GetQuestByStatus{},
+		},
+	}
+	for _, b := range backends {
+		for _, tt := range tests {
+			t.Run(b.name+"/"+tt.name, func(t *testing.T) {
+				r := b.new(t)
+				res, err := r.GetQuestsByStatus(tt.status)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.out, res)
+			})
+		}
+	}
+}
+
+func TestBackendGetQuestsByStatusKeepsCompletedParty(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+			assert.NoError(t, r.UpdateTakenByReward(model.TakenBy{QuestID: bulkQuest[1].ID, AdventurerID: adv.ID, Reward: 200000}))
+			assert.NoError(t, r.UpdateQuestStatus(model.Quest{ID: bulkQuest[1].ID, Status: constant.CompletedQuest}))
+
+			res, err := r.GetQuestsByStatus(constant.CompletedQuest)
+			assert.NoError(t, err)
+			assert.Len(t, res, 1)
+			assert.Equal(t, []model.Taker{{AdventurerID: adv.ID, Name: adv.Name, Rank: adv.Rank, Reward: 200000}}, res[0].Takers)
+		})
+	}
+}
+
+func TestBackendCreateQuest(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+			quest := bulkQuest[2]
+			quest.ID = 0
+
+			res, err := r.CreateQuest(quest)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(4), res.ID)
+			assert.Equal(t, int32(constant.AvailableQuest), res.Status)
+
+			stored, err := r.GetQuest(res.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, res, stored)
+		})
+	}
+}
+
+func TestBackendUpdateQuest(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(Repository) error
+		id     int64
+		check  func(*testing.T, model.Quest)
+	}{
+		{
+			name: "update rank",
+			update: func(r Repository) error {
+				return r.UpdateQuestRank(model.Quest{ID: 1, MinimumRank: 15})
+			},
+			id: 1,
+			check: func(t *testing.T, quest model.Quest) {
+				assert.Equal(t, int32(15), quest.MinimumRank)
+				assert.Equal(t, bulkQuest[0].RewardNumber, quest.RewardNumber)
+			},
+		},
+		{
+			name: "update reward",
+			update: func(r Repository) error {
+				return r.UpdateQuestReward(model.Quest{ID: 1, RewardNumber: 5})
+			},
+			id: 1,
+			check: func(t *testing.T, quest model.Quest) {
+				assert.Equal(t, int32(5), quest.RewardNumber)
+				assert.Equal(t, bulkQuest[0].MinimumRank, quest.MinimumRank)
+			},
+		},
+		{
+			name: "update status",
+			update: func(r Repository) error {
+				return r.UpdateQuestStatus(model.Quest{ID: 1, Status: constant.CompletedQuest})
+			},
+			id: 1,
+			check: func(t *testing.T, quest model.Quest) {
+				assert.Equal(t, int32(constant.CompletedQuest), quest.Status)
+			},
+		},
+		{
+			name: "update missing quest",
+			update: func(r Repository) error {
+				return r.UpdateQuestRank(model.Quest{ID: 99, MinimumRank: 15})
+			},
+			id: 1,
+			check: func(t *testing.T, quest model.Quest) {
+				assert.Equal(t, bulkQuest[0], quest)
+			},
+		},
+	}
+	for _, b := range backends {
+		for _, tt := range tests {
+			t.Run(b.name+"/"+tt.name, func(t *testing.T) {
+				r := b.new(t)
+				assert.NoError(t, tt.update(r), tt.name)
+				quest, err := r.GetQuest(tt.id)
+				assert.NoError(t, err)
+				tt.check(t, quest)
+			})
+		}
+	}
+}
+
+func TestBackendUpdateQuestStatusIf(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+			working := model.Quest{ID: bulkQuest[0].ID, Status: constant.WorkingQuest}
+
+			updated, err := r.UpdateQuestStatusIf(working, constant.AvailableQuest)
+			assert.NoError(t, err)
+			assert.True(t, updated)
+
+			updated, err = r.UpdateQuestStatusIf(working, constant.AvailableQuest)
+			assert.NoError(t, err)
+			assert.False(t, updated)
+
+			updated, err = r.UpdateQuestStatusIf(model.Quest{ID: 99}, constant.AvailableQuest)
+			assert.NoError(t, err)
+			assert.False(t, updated)
+		})
+	}
+}
+
+func TestBackendDeleteQuest(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+
+			assert.NoError(t, r.DeleteQuest(bulkQuest[1]))
+			_, err := r.GetQuest(bulkQuest[1].ID)
+			assert.Equal(t, sql.ErrNoRows, err)
+			assert.Equal(t, sql.ErrNoRows, r.IsExistTakenBy(bulkQuest[1].ID, adv.ID))
+		})
+	}
+}
+
+func TestBackendGetQuest(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+
+			res, err := r.GetQuest(bulkQuest[2].ID)
+			assert.NoError(t, err)
+			assert.Equal(t, bulkQuest[2], res)
+
+			res, err = r.GetQuest(99)
+			assert.Equal(t, sql.ErrNoRows, err)
+			assert.Equal(t, model.Quest{ID: 99}, res)
+		})
+	}
+}
+
+func TestBackendTakenBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		quest_id int64
+		adv_id   int64
+		wantErr  bool
+	}{
+		{
+			name:     "success took a quest",
+			quest_id: bulkQuest[0].ID,
+			adv_id:   adv.ID,
+			wantErr:  false,
+		},
+		{
+			name:     "already taken by the adventurer",
+			quest_id: bulkQuest[1].ID,
+			adv_id:   adv.ID,
+			wantErr:  true,
+		},
+		{
+			name:     "missing quest",
+			quest_id: 99,
+			adv_id:   adv.ID,
+			wantErr:  true,
+		},
+		{
+			name:     "missing adventurer",
+			quest_id: bulkQuest[0].ID,
+			adv_id:   99,
+			wantErr:  true,
+		},
+	}
+	for _, b := range backends {
+		for _, tt := range tests {
+			t.Run(b.name+"/"+tt.name, func(t *testing.T) {
+				r := b.new(t)
+				err := r.CreateTakenBy(tt.quest_id, tt.adv_id)
+				if tt.wantErr {
+					assert.Error(t, err, tt.name)
+					return
+				}
+				assert.NoError(t, err, tt.name)
+				assert.NoError(t, r.IsExistTakenBy(tt.quest_id, tt.adv_id))
+				assert.NoError(t, r.DeleteTakenBy(tt.quest_id, tt.adv_id))
+				assert.Equal(t, sql.ErrNoRows, r.IsExistTakenBy(tt.quest_id, tt.adv_id))
+			})
+		}
+	}
+}
+
+func TestBackendListTakers(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+			assert.NoError(t, r.CreateTakenBy(bulkQuest[1].ID, other.ID))
+
+			res, err := r.ListTakers([]int64{bulkQuest[0].ID, bulkQuest[1].ID})
+			assert.NoError(t, err)
+			assert.Equal(t, map[int64][]model.Taker{bulkQuest[1].ID: {
+				{AdventurerID: adv.ID, Name: adv.Name, Rank: adv.Rank},
+				{AdventurerID: other.ID, Name: other.Name, Rank: other.Rank},
+			}}, res)
+		})
+	}
+}
+
+func TestBackendGetQuestActiveAdventurer(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+			assert.NoError(t, r.CreateTakenBy(bulkQuest[2].ID, other.ID))
+
+			res, err := r.GetQuestActiveAdventurer(adv.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, bulkQuest[1:2], res)
+
+			res, err = r.GetQuestActiveAdventurer(other.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, bulkQuest[2:3], res)
+
+			res, err = r.GetQuestActiveAdventurer(99)
+			assert.NoError(t, err)
+			assert.Equal(t, []model.Quest{}, res)
+		})
+	}
+}
+
+func TestBackendUpdateQuestTakenAt(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+			at := takenAt.Add(90 * time.Minute)
+
+			assert.NoError(t, r.UpdateQuestTakenAt(model.Quest{ID: bulkQuest[0].ID, TakenAt: &at}))
+			quest, err := r.GetQuest(bulkQuest[0].ID)
+			assert.NoError(t, err)
+			assert.Equal(t, &at, quest.TakenAt)
+
+			assert.NoError(t, r.UpdateQuestTakenAt(model.Quest{ID: bulkQuest[0].ID}))
+			quest, err = r.GetQuest(bulkQuest[0].ID)
+			assert.NoError(t, err)
+			assert.Nil(t, quest.TakenAt)
+		})
+	}
+}
+
+func TestBackendGetTakenBy(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+			assert.NoError(t, r.CreateTakenBy(bulkQuest[1].ID, other.ID))
+
+			res, err := r.GetTakenBy(bulkQuest[1].ID)
+			assert.NoError(t, err)
+			assert.Equal(t, []model.TakenBy{
+				{QuestID: bulkQuest[1].ID, AdventurerID: adv.ID},
+				{QuestID: bulkQuest[1].ID, AdventurerID: other.ID},
+			}, res)
+
+			res, err = r.GetTakenBy(bulkQuest[0].ID)
+			assert.NoError(t, err)
+			assert.Equal(t, []model.TakenBy{}, res)
+		})
+	}
+}
+
+func TestBackendGetOverdueQuests(t *testing.T) {
+	tests := []struct {
+		name        string
+		now         time.Time
+		takenBefore time.Time
+		out         []model.Quest
+	}{
+		{
+			name:        "nothing overdue",
+			now:         deadline.Add(-time.Hour),
+			takenBefore: takenAt,
+			out:         []model.Quest{},
+		},
+		{
+			name:        "past deadline",
+			now:         deadline.Add(time.Millisecond),
+			takenBefore: time.Time{},
+			out:         bulkQuest[0:1],
+		},
+		{
+			name:        "working too long",
+			now:         deadline,
+			takenBefore: takenAt.Add(500 * time.Millisecond),
+			out:         bulkQuest[1:3],
+		},
+		{
+			name:        "both",
+			now:         deadline.Add(time.Hour),
+			takenBefore: takenAt.Add(time.Hour),
+			out:         bulkQuest,
+		},
+	}
+	for _, b := range backends {
+		for _, tt := range tests {
+			t.Run(b.name+"/"+tt.name, func(t *testing.T) {
+				r := b.new(t)
+				res, err := r.GetOverdueQuests(tt.now, tt.takenBefore)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.out, res)
+			})
+		}
+	}
+}
+
+func TestBackendGetOverdueQuestsSkipsClosed(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+			assert.NoError(t, r.UpdateQuestStatus(model.Quest{ID: bulkQuest[0].ID, Status: constant.CompletedQuest}))
+			assert.NoError(t, r.UpdateQuestStatus(model.Quest{ID: bulkQuest[1].ID, Status: constant.ExpiredQuest}))
+
+			res, err := r.GetOverdueQuests(deadline.Add(time.Hour), takenAt.Add(time.Hour))
+			assert.NoError(t, err)
+			assert.Equal(t, bulkQuest[2:3], res)
+		})
+	}
+}
+
+func TestBackendJoinQuest(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+			at := takenAt.Add(time.Hour)
+
+			joined, err := r.JoinQuest(model.Quest{ID: bulkQuest[0].ID, TakenAt: &at})
+			assert.NoError(t, err)
+			assert.True(t, joined)
+			quest, err := r.GetQuest(bulkQuest[0].ID)
+			assert.NoError(t, err)
+			assert.Equal(t, int32(constant.WorkingQuest), quest.Status)
+			assert.Equal(t, int32(1), quest.Members)
+			assert.Equal(t, &at, quest.TakenAt)
+
+			joined, err = r.JoinQuest(model.Quest{ID: bulkQuest[0].ID, TakenAt: &at})
+			assert.NoError(t, err)
+			assert.False(t, joined, "party is full")
+
+			// a late member of a working party keeps the time it was taken
+			joined, err = r.JoinQuest(model.Quest{ID: bulkQuest[2].ID, TakenAt: &at})
+			assert.NoError(t, err)
+			assert.True(t, joined)
+			quest, err = r.GetQuest(bulkQuest[2].ID)
+			assert.NoError(t, err)
+			assert.Equal(t, int32(3), quest.Members)
+			assert.Equal(t, bulkQuest[2].TakenAt, quest.TakenAt)
+
+			assert.NoError(t, r.UpdateQuestStatus(model.Quest{ID: bulkQuest[1].ID, Status: constant.CompletedQuest}))
+			joined, err = r.JoinQuest(model.Quest{ID: bulkQuest[1].ID, TakenAt: &at})
+			assert.NoError(t, err)
+			assert.False(t, joined, "quest is closed")
+
+			joined, err = r.JoinQuest(model.Quest{ID: 99, TakenAt: &at})
+			assert.NoError(t, err)
+			assert.False(t, joined)
+		})
+	}
+}
+
+func TestBackendJoinQuestFormsParty(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+			quest := bulkQuest[2]
+			quest.ID = 0
+			created, err := r.CreateQuest(quest)
+			assert.NoError(t, err)
+			first, second := takenAt, takenAt.Add(time.Hour)
+
+			joined, err := r.JoinQuest(model.Quest{ID: created.ID, TakenAt: &first})
+			assert.NoError(t, err)
+			assert.True(t, joined)
+			stored, err := r.GetQuest(created.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, int32(constant.AvailableQuest), stored.Status, "party below its minimum size")
+			assert.Nil(t, stored.TakenAt)
+
+			joined, err = r.JoinQuest(model.Quest{ID: created.ID, TakenAt: &second})
+			assert.NoError(t, err)
+			assert.True(t, joined)
+			stored, err = r.GetQuest(created.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, int32(constant.WorkingQuest), stored.Status)
+			assert.Equal(t, &second, stored.TakenAt)
+		})
+	}
+}
+
+func TestBackendDeleteTakenByFreesPlace(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+
+			assert.NoError(t, r.DeleteTakenBy(bulkQuest[1].ID, adv.ID))
+			quest, err := r.GetQuest(bulkQuest[1].ID)
+			assert.NoError(t, err)
+			assert.Equal(t, int32(0), quest.Members)
+
+			assert.NoError(t, r.DeleteTakenBy(bulkQuest[1].ID, adv.ID))
+			quest, err = r.GetQuest(bulkQuest[1].ID)
+			assert.NoError(t, err)
+			assert.Equal(t, int32(0), quest.Members)
+		})
+	}
+}
+
+func TestBackendUpdateTakenByReward(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+			taken := model.TakenBy{QuestID: bulkQuest[1].ID, AdventurerID: adv.ID, Reward: 150000}
+
+			assert.NoError(t, r.UpdateTakenByReward(taken))
+			res, err := r.GetTakenBy(bulkQuest[1].ID)
+			assert.NoError(t, err)
+			assert.Equal(t, []model.TakenBy{taken}, res)
+		})
+	}
+}
+
+func TestBackendListQuests(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter model.QuestFilter
+		out    []model.Quest
+		total  int
+	}{
+		{
+			name:   "no filter",
+			filter: model.QuestFilter{Limit: 10},
+			out:    bulkQuest,
+			total:  3,
+		},
+		{
+			name:   "status set",
+			filter: model.QuestFilter{Statuses: []int32{constant.WorkingQuest, constant.CompletedQuest}, Limit: 10},
+			out:    bulkQuest[1:3],
+			total:  2,
+		},
+		{
+			name:   "reward range",
+			filter: model.QuestFilter{MinReward: 100000, MaxReward: 200000, Limit: 10},
+			out:    bulkQuest[0:2],
+			total:  2,
+		},
+		{
+			name:   "rank range",
+			filter: model.QuestFilter{MinRank: 12, MaxRank: 13, Limit: 10},
+			out:    bulkQuest[2:3],
+			total:  1,
+		},
+		{
+			name:   "name substring ignores case",
+			filter: model.QuestFilter{Name: "SUPIR", Limit: 10},
+			out:    bulkQuest[2:3],
+			total:  1,
+		},
+		{
+			name:   "posted by a giver",
+			filter: model.QuestFilter{CreatedBy: giverID, Limit: 10},
+			out:    []model.Quest{bulkQuest[0], bulkQuest[2]},
+			total:  2,
+		},
+		{
+			name:   "name wildcards are literal",
+			filter: model.QuestFilter{Name: "%", Limit: 10},
+			out:    []model.Quest{},
+			total:  0,
+		},
+		{
+			name:   "sort by reward descending",
+			filter: model.QuestFilter{Sort: constant.SortReward, Desc: true, Limit: 2},
+			out:    []model.Quest{bulkQuest[2], bulkQuest[1]},
+			total:  3,
+		},
+		{
+			name:   "sort by rank",
+			filter: model.QuestFilter{Sort: constant.SortRank, Limit: 10},
+			out:    bulkQuest,
+			total:  3,
+		},
+		{
+			name:   "second page",
+			filter: model.QuestFilter{Limit: 2, Offset: 2},
+			out:    bulkQuest[2:3],
+			total:  3,
+		},
+		{
+			name:   "past the last page",
+			filter: model.QuestFilter{Limit: 2, Offset: 5},
+			out:    []model.Quest{},
+			total:  3,
+		},
+	}
+	for _, b := range backends {
+		for _, tt := range tests {
+			t.Run(b.name+"/"+tt.name, func(t *testing.T) {
+				r := b.new(t)
+				res, total, err := r.ListQuests(tt.filter)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.out, res)
+				assert.Equal(t, tt.total, total)
+			})
+		}
+	}
+}
+
+func TestBackendAssignments(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+			first, second, third := takenAt, takenAt.Add(time.Hour), takenAt.Add(2*time.Hour)
+			finished := takenAt.Add(30 * time.Minute)
+
+			assert.NoError(t, r.CreateAssignment(model.Assignment{QuestID: bulkQuest[1].ID, AdventurerID: adv.ID, TakenAt: first}))
+			assert.NoError(t, r.CreateAssignment(model.Assignment{QuestID: bulkQuest[2].ID, AdventurerID: adv.ID, TakenAt: second}))
+			assert.NoError(t, r.CreateAssignment(model.Assignment{QuestID: bulkQuest[2].ID, AdventurerID: other.ID, TakenAt: second}))
+			assert.Error(t, r.CreateAssignment(model.Assignment{QuestID: 99, AdventurerID: adv.ID, TakenAt: first}))
+
+			assert.NoError(t, r.FinishAssignment(model.Assignment{QuestID: bulkQuest[1].ID, AdventurerID: adv.ID, FinishedAt: &finished, Outcome: constant.OutcomeFailed}))
+			// a closed assignment keeps its outcome
+			assert.NoError(t, r.FinishAssignment(model.Assignment{QuestID: bulkQuest[1].ID, AdventurerID: adv.ID, FinishedAt: &third, Outcome: constant.OutcomeCompleted}))
+			assert.NoError(t, r.CreateAssignment(model.Assignment{QuestID: bulkQuest[1].ID, AdventurerID: adv.ID, TakenAt: third}))
+
+			failed := model.Assignment{ID: 1, QuestID: bulkQuest[1].ID, AdventurerID: adv.ID, TakenAt: first, FinishedAt: &finished, Outcome: constant.OutcomeFailed}
+			advOnQuest3 := model.Assignment{ID: 2, QuestID: bulkQuest[2].ID, AdventurerID: adv.ID, TakenAt: second}
+			otherOnQuest3 := model.Assignment{ID: 3, QuestID: bulkQuest[2].ID, AdventurerID: other.ID, TakenAt: second}
+			retaken := model.Assignment{ID: 4, QuestID: bulkQuest[1].ID, AdventurerID: adv.ID, TakenAt: third}
+
+			res, total, err := r.ListAssignments(model.AssignmentFilter{AdventurerID: adv.ID, Limit: 10})
+			assert.NoError(t, err)
+			assert.Equal(t, []model.Assignment{retaken, advOnQuest3, failed}, res)
+			assert.Equal(t, 3, total)
+
+			res, total, err = r.ListAssignments(model.AssignmentFilter{QuestID: bulkQuest[2].ID, Limit: 10})
+			assert.NoError(t, err)
+			assert.Equal(t, []model.Assignment{otherOnQuest3, advOnQuest3}, res)
+			assert.Equal(t, 2, total)
+
+			res, total, err = r.ListAssignments(model.AssignmentFilter{QuestID: bulkQuest[1].ID, AdventurerID: adv.ID, Limit: 1, Offset: 1})
+			assert.NoError(t, err)
+			assert.Equal(t, []model.Assignment{failed}, res)
+			assert.Equal(t, 2, total)
+
+		})
+	}
+}
+
+func TestBackendAssignmentsOutliveDeletedQuest(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+			finished := takenAt.Add(30 * time.Minute)
+
+			assert.NoError(t, r.CreateAssignment(model.Assignment{QuestID: bulkQuest[2].ID, AdventurerID: adv.ID, TakenAt: takenAt}))
+			assert.NoError(t, r.CreateAssignment(model.Assignment{QuestID: bulkQuest[2].ID, AdventurerID: other.ID, TakenAt: takenAt}))
+			assert.NoError(t, r.FinishAssignment(model.Assignment{QuestID: bulkQuest[2].ID, AdventurerID: other.ID, FinishedAt: &finished, Outcome: constant.OutcomeDeleted}))
+			assert.NoError(t, r.DeleteQuest(model.Quest{ID: bulkQuest[2].ID}))
+
+			res, total, err := r.ListAssignments(model.AssignmentFilter{QuestID: bulkQuest[2].ID, Limit: 10})
+			assert.NoError(t, err)
+			assert.Equal(t, []model.Assignment{
+				{ID: 2, QuestID: bulkQuest[2].ID, AdventurerID: other.ID, TakenAt: takenAt, FinishedAt: &finished, Outcome: constant.OutcomeDeleted},
+				{ID: 1, QuestID: bulkQuest[2].ID, AdventurerID: adv.ID, TakenAt: takenAt},
+			}, res)
+			assert.Equal(t, 2, total)
+
+			// a deleted quest takes no new history
+			assert.Error(t, r.CreateAssignment(model.Assignment{QuestID: bulkQuest[2].ID, AdventurerID: adv.ID, TakenAt: finished}))
+		})
+	}
+}
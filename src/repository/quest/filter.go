@@ -0,0 +1,66 @@
+package quest
+
+import (
+	"fmt"
+	"strings"
+
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/database"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+)
+
+var sortColumns = map[string]string{
+	constant.SortCreatedAt: "created_at",
+	constant.SortReward:    "reward_number",
+	constant.SortRank:      "minimum_rank",
+}
+
+func questConditions(filter model.QuestFilter) *database.Conditions {
+	c := &database.Conditions{}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]interface{}, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = status
+		}
+		c.In("status", statuses...)
+	}
+	if filter.MinReward > 0 {
+		c.Add("reward_number >= %s", filter.MinReward)
+	}
+	if filter.MaxReward > 0 {
+		c.Add("reward_number <= %s", filter.MaxReward)
+	}
+	if filter.MinRank > 0 {
+		c.Add("minimum_rank >= %s", filter.MinRank)
+	}
+	if filter.MaxRank > 0 {
+		c.Add("minimum_rank <= %s", filter.MaxRank)
+	}
+	if filter.CreatedBy > 0 {
+		c.Add("created_by = %s", filter.CreatedBy)
+	}
+	if filter.Name != "" {
+		c.Add(`LOWER(name) LIKE %s ESCAPE '\'`, "%"+database.EscapeLike(strings.ToLower(filter.Name))+"%")
+	}
+	return c
+}
+
+func countQuestsQuery(c *database.Conditions) (string, []interface{}) {
+	return "SELECT COUNT(*) FROM quest" + c.Where(), c.Args()
+}
+
+func listQuestsQuery(c *database.Conditions, filter model.QuestFilter) (string, []interface{}) {
+	column, ok := sortColumns[filter.Sort]
+	if !ok {
+		column = sortColumns[constant.SortCreatedAt]
+	}
+	direction := "ASC"
+	if filter.Desc {
+		direction = "DESC"
+	}
+
+	query := "SELECT quest_id, name, description, minimum_rank, reward_number, status, created_at, deadline, taken_at, " +
+		"min_members, max_members, rank_rule, members, created_by FROM quest" + c.Where() +
+		fmt.Sprintf(" ORDER BY %s %s, quest_id %s LIMIT %s OFFSET %s", column, direction, direction, c.Next(1), c.Next(2))
+	return query, c.Args(filter.Limit, filter.Offset)
+}
@@ -0,0 +1,443 @@
+package quest
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/database/memory"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+)
+
+var (
+	errDuplicateTakenBy    = errors.New("taken_by already exists")
+	errTakenByReference    = errors.New("taken_by references a missing quest or adventurer")
+	errAssignmentReference = errors.New("assignment references a missing quest or adventurer")
+)
+
+type memoryRepository struct {
+	store *memory.Store
+}
+
+// NewMemoryRepository returns a Repository backed by store, with the same
+// semantics as the SQL repository.
+func NewMemoryRepository(store *memory.Store) Repository {
+	return &memoryRepository{store}
+}
+
+func (r *memoryRepository) Close() {}
+
+func (r *memoryRepository) GetQuestsByStatus(status int32) ([]model.GetQuestByStatus, error) {
+	quests := []model.GetQuestByStatus{}
+	r.store.Read(func(d *memory.Data) {
+		for _, quest := range sortedQuests(d) {
+			if quest.Status != status {
+				continue
+			}
+			quests = append(quests, model.GetQuestByStatus{
+				ID:           quest.ID,
+				Name:         quest.Name,
+				Description:  quest.Description,
+				MinimumRank:  quest.MinimumRank,
+				RewardNumber: quest.RewardNumber,
+				Status:       quest.Status,
+				Deadline:     quest.Deadline,
+				TakenAt:      quest.TakenAt,
+				MinMembers:   quest.MinMembers,
+				MaxMembers:   quest.MaxMembers,
+				RankRule:     quest.RankRule,
+				Members:      quest.Members,
+				Takers:       questTakers(d, quest.ID),
+			})
+		}
+	})
+	return quests, nil
+}
+
+func questTakers(d *memory.Data, quest_id int64) (takers []model.Taker) {
+	for _, taken := range d.TakenBy {
+		if adv, ok := d.Adventurers[taken.AdventurerID]; ok && taken.QuestID == quest_id {
+			takers = append(takers, model.Taker{AdventurerID: adv.ID, Name: adv.Name, Rank: adv.Rank, Reward: taken.Reward})
+		}
+	}
+	sort.Slice(takers, func(i, j int) bool {
+		return takers[i].AdventurerID < takers[j].AdventurerID
+	})
+	return takers
+}
+
+func (r *memoryRepository) CreateQuest(quest model.Quest) (qst model.Quest, err error) {
+	err = r.store.Write(func(d *memory.Data) error {
+		d.LastQuestID++
+		qst = quest
+		qst.ID = d.LastQuestID
+		qst.Status = constant.AvailableQuest
+		qst.TakenAt = nil
+		qst.Members = 0
+		d.Quests[qst.ID] = qst
+		return nil
+	})
+	return
+}
+
+func (r *memoryRepository) UpdateQuestRank(quest model.Quest) error {
+	return r.update(quest.ID, func(stored *model.Quest) {
+		stored.MinimumRank = quest.MinimumRank
+	})
+}
+
+func (r *memoryRepository) UpdateQuestReward(quest model.Quest) error {
+	return r.update(quest.ID, func(stored *model.Quest) {
+		stored.RewardNumber = quest.RewardNumber
+	})
+}
+
+func (r *memoryRepository) UpdateQuestStatus(quest model.Quest) error {
+	return r.update(quest.ID, func(stored *model.Quest) {
+		stored.Status = quest.Status
+	})
+}
+
+// update applies fn to the stored quest; like an UPDATE matching no row, a
+// missing quest is not an error.
+func (r *memoryRepository) update(id int64, fn func(*model.Quest)) error {
+	return r.store.Write(func(d *memory.Data) error {
+		stored, ok := d.Quests[id]
+		if !ok {
+			return nil
+		}
+		fn(&stored)
+		d.Quests[id] = stored
+		return nil
+	})
+}
+
+func (r *memoryRepository) UpdateQuestStatusIf(quest model.Quest, current int32) (updated bool, err error) {
+	err = r.store.Write(func(d *memory.Data) error {
+		stored, ok := d.Quests[quest.ID]
+		if !ok || stored.Status != current {
+			return nil
+		}
+		stored.Status = quest.Status
+		d.Quests[quest.ID] = stored
+		updated = true
+		return nil
+	})
+	return
+}
+
+func (r *memoryRepository) DeleteQuest(quest model.Quest) error {
+	return r.store.Write(func(d *memory.Data) error {
+		delete(d.Quests, quest.ID)
+		// taken_by rows cascade with their quest
+		takenBy := d.TakenBy[:0]
+		for _, taken := range d.TakenBy {
+			if taken.QuestID != quest.ID {
+				takenBy = append(takenBy, taken)
+			}
+		}
+		d.TakenBy = takenBy
+		// assignments are history and outlive the quest
+		return nil
+	})
+}
+
+func (r *memoryRepository) GetQuest(id int64) (quest model.Quest, err error) {
+	quest.ID = id
+	r.store.Read(func(d *memory.Data) {
+		stored, ok := d.Quests[id]
+		if !ok {
+			err = sql.ErrNoRows
+			return
+		}
+		quest = stored
+	})
+	return
+}
+
+func (r *memoryRepository) IsExistTakenBy(quest_id, adv_id int64) (err error) {
+	err = sql.ErrNoRows
+	r.store.Read(func(d *memory.Data) {
+		if indexTakenBy(d, quest_id, adv_id) >= 0 {
+			err = nil
+		}
+	})
+	return
+}
+
+func (r *memoryRepository) CreateTakenBy(quest_id, adventurer_id int64) error {
+	return r.store.Write(func(d *memory.Data) error {
+		if _, ok := d.Quests[quest_id]; !ok {
+			return errTakenByReference
+		}
+		if _, ok := d.Adventurers[adventurer_id]; !ok {
+			return errTakenByReference
+		}
+		if indexTakenBy(d, quest_id, adventurer_id) >= 0 {
+			return errDuplicateTakenBy
+		}
+		d.TakenBy = append(d.TakenBy, model.TakenBy{QuestID: quest_id, AdventurerID: adventurer_id})
+		return nil
+	})
+}
+
+func (r *memoryRepository) DeleteTakenBy(quest_id, adventurer_id int64) error {
+	return r.store.Write(func(d *memory.Data) error {
+		i := indexTakenBy(d, quest_id, adventurer_id)
+		if i < 0 {
+			return nil
+		}
+		d.TakenBy = append(d.TakenBy[:i], d.TakenBy[i+1:]...)
+		if stored, ok := d.Quests[quest_id]; ok && stored.Members > 0 {
+			stored.Members--
+			d.Quests[quest_id] = stored
+		}
+		return nil
+	})
+}
+
+func (r *memoryRepository) JoinQuest(quest model.Quest) (joined bool, err error) {
+	err = r.store.Write(func(d *memory.Data) error {
+		stored, ok := d.Quests[quest.ID]
+		open := stored.Status == constant.AvailableQuest || stored.Status == constant.WorkingQuest
+		if !ok || !open || stored.Members >= stored.MaxMembers {
+			return nil
+		}
+		stored.Members++
+		if stored.Members >= stored.MinMembers {
+			stored.Status = constant.WorkingQuest
+		}
+		if stored.Members == stored.MinMembers {
+			stored.TakenAt = quest.TakenAt
+		}
+		d.Quests[quest.ID] = stored
+		joined = true
+		return nil
+	})
+	return
+}
+
+func (r *memoryRepository) UpdateTakenByReward(taken model.TakenBy) error {
+	return r.store.Write(func(d *memory.Data) error {
+		if i := indexTakenBy(d, taken.QuestID, taken.AdventurerID); i >= 0 {
+			d.TakenBy[i].Reward = taken.Reward
+		}
+		return nil
+	})
+}
+
+func (r *memoryRepository) GetQuestActiveAdventurer(id int64) ([]model.Quest, error) {
+	quests := []model.Quest{}
+	r.store.Read(func(d *memory.Data) {
+		// quest NATURAL JOIN taken_by, joined on quest_id
+		for _, quest := range sortedQuests(d) {
+			if quest.Status == constant.WorkingQuest && indexTakenBy(d, quest.ID, id) >= 0 {
+				quests = append(quests, quest)
+			}
+		}
+	})
+	return quests, nil
+}
+
+func (r *memoryRepository) UpdateQuestTakenAt(quest model.Quest) error {
+	return r.update(quest.ID, func(stored *model.Quest) {
+		stored.TakenAt = quest.TakenAt
+	})
+}
+
+func (r *memoryRepository) GetTakenBy(quest_id int64) ([]model.TakenBy, error) {
+	takers := []model.TakenBy{}
+	r.store.Read(func(d *memory.Data) {
+		for _, taken := range d.TakenBy {
+			if taken.QuestID == quest_id {
+				takers = append(takers, taken)
+			}
+		}
+	})
+	sort.Slice(takers, func(i, j int) bool {
+		return takers[i].AdventurerID < takers[j].AdventurerID
+	})
+	return takers, nil
+}
+
+func (r *memoryRepository) GetOverdueQuests(now, takenBefore time.Time) ([]model.Quest, error) {
+	quests := []model.Quest{}
+	r.store.Read(func(d *memory.Data) {
+		for _, quest := range sortedQuests(d) {
+			open := quest.Status == constant.AvailableQuest || quest.Status == constant.WorkingQuest
+			pastDeadline := open && quest.Deadline != nil && quest.Deadline.Before(now)
+			abandoned := quest.Status == constant.WorkingQuest && quest.TakenAt != nil && quest.TakenAt.Before(takenBefore)
+			if pastDeadline || abandoned {
+				quests = append(quests, quest)
+			}
+		}
+	})
+	return quests, nil
+}
+
+func (r *memoryRepository) ListQuests(filter model.QuestFilter) ([]model.Quest, int, error) {
+	quests := []model.Quest{}
+	r.store.Read(func(d *memory.Data) {
+		for _, quest := range sortedQuests(d) {
+			if matchQuest(quest, filter) {
+				quests = append(quests, quest)
+			}
+		}
+	})
+
+	key := func(quest model.Quest) int64 {
+		switch filter.Sort {
+		case constant.SortReward:
+			return int64(quest.RewardNumber)
+		case constant.SortRank:
+			return int64(quest.MinimumRank)
+		}
+		return quest.CreatedAt.UnixNano()
+	}
+	sort.SliceStable(quests, func(i, j int) bool {
+		a, b := quests[i], quests[j]
+		if filter.Desc {
+			a, b = b, a
+		}
+		if key(a) != key(b) {
+			return key(a) < key(b)
+		}
+		return a.ID < b.ID
+	})
+
+	total := len(quests)
+	start, end := filter.Offset, filter.Offset+filter.Limit
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+	return quests[start:end], total, nil
+}
+
+func (r *memoryRepository) ListTakers(questIDs []int64) (map[int64][]model.Taker, error) {
+	takers := map[int64][]model.Taker{}
+	r.store.Read(func(d *memory.Data) {
+		for _, id := range questIDs {
+			if party := questTakers(d, id); len(party) > 0 {
+				takers[id] = party
+			}
+		}
+	})
+	return takers, nil
+}
+
+func matchQuest(quest model.Quest, filter model.QuestFilter) bool {
+	if len(filter.Statuses) > 0 {
+		found := false
+		for _, status := range filter.Statuses {
+			found = found || quest.Status == status
+		}
+		if !found {
+			return false
+		}
+	}
+	if filter.MinReward > 0 && quest.RewardNumber < filter.MinReward {
+		return false
+	}
+	if filter.MaxReward > 0 && quest.RewardNumber > filter.MaxReward {
+		return false
+	}
+	if filter.MinRank > 0 && quest.MinimumRank < filter.MinRank {
+		return false
+	}
+	if filter.MaxRank > 0 && quest.MinimumRank > filter.MaxRank {
+		return false
+	}
+	if filter.CreatedBy > 0 && quest.CreatedBy != filter.CreatedBy {
+		return false
+	}
+	return strings.Contains(strings.ToLower(quest.Name), strings.ToLower(filter.Name))
+}
+
+func (r *memoryRepository) CreateAssignment(assignment model.Assignment) error {
+	return r.store.Write(func(d *memory.Data) error {
+		_, questOK := d.Quests[assignment.QuestID]
+		_, advOK := d.Adventurers[assignment.AdventurerID]
+		if !questOK || !advOK {
+			return errAssignmentReference
+		}
+		d.LastAssignmentID++
+		d.Assignments = append(d.Assignments, model.Assignment{
+			ID:           d.LastAssignmentID,
+			QuestID:      assignment.QuestID,
+			AdventurerID: assignment.AdventurerID,
+			TakenAt:      assignment.TakenAt.UTC(),
+		})
+		return nil
+	})
+}
+
+func (r *memoryRepository) FinishAssignment(assignment model.Assignment) error {
+	return r.store.Write(func(d *memory.Data) error {
+		for i, stored := range d.Assignments {
+			if stored.QuestID == assignment.QuestID && stored.AdventurerID == assignment.AdventurerID && stored.Outcome == "" {
+				if assignment.FinishedAt != nil {
+					finishedAt := assignment.FinishedAt.UTC()
+					stored.FinishedAt = &finishedAt
+				}
+				stored.Outcome = assignment.Outcome
+				d.Assignments[i] = stored
+			}
+		}
+		return nil
+	})
+}
+
+func (r *memoryRepository) ListAssignments(filter model.AssignmentFilter) ([]model.Assignment, int, error) {
+	assignments := []model.Assignment{}
+	r.store.Read(func(d *memory.Data) {
+		for _, assignment := range d.Assignments {
+			if (filter.QuestID == 0 || assignment.QuestID == filter.QuestID) &&
+				(filter.AdventurerID == 0 || assignment.AdventurerID == filter.AdventurerID) {
+				assignments = append(assignments, assignment)
+			}
+		}
+	})
+	sort.Slice(assignments, func(i, j int) bool {
+		a, b := assignments[i], assignments[j]
+		if !a.TakenAt.Equal(b.TakenAt) {
+			return a.TakenAt.After(b.TakenAt)
+		}
+		return a.ID > b.ID
+	})
+
+	total := len(assignments)
+	start, end := filter.Offset, filter.Offset+filter.Limit
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+	return assignments[start:end], total, nil
+}
+
+func sortedQuests(d *memory.Data) []model.Quest {
+	quests := make([]model.Quest, 0, len(d.Quests))
+	for _, quest := range d.Quests {
+		quests = append(quests, quest)
+	}
+	sort.Slice(quests, func(i, j int) bool {
+		return quests[i].ID < quests[j].ID
+	})
+	return quests
+}
+
+func indexTakenBy(d *memory.Data, quest_id, adv_id int64) int {
+	for i, taken := range d.TakenBy {
+		if taken.QuestID == quest_id && taken.AdventurerID == adv_id {
+			return i
+		}
+	}
+	return -1
+}
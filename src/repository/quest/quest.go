@@ -2,6 +2,7 @@ package quest
 
 import (
 	"database/sql"
+	"time"
 
 	constant "github.com/arfaghifari/guild-board/src/constant"
 	"github.com/arfaghifari/guild-board/src/database"
@@ -10,107 +11,149 @@ import (
 
 type Repository interface {
 	Close()
-	GetAllCompletedQuest() ([]model.GetQuestByStatus, error)
-	GetAllAvailableQuest() ([]model.GetQuestByStatus, error)
+	GetQuestsByStatus(int32) ([]model.GetQuestByStatus, error)
 	CreateQuest(model.Quest) (model.Quest, error)
 	UpdateQuestRank(model.Quest) error
 	UpdateQuestStatus(model.Quest) error
+	UpdateQuestStatusIf(model.Quest, int32) (bool, error)
 	UpdateQuestReward(model.Quest) error
 	DeleteQuest(model.Quest) error
 	GetQuest(int64) (model.Quest, error)
 	CreateTakenBy(int64, int64) error
+	DeleteTakenBy(int64, int64) error
 	IsExistTakenBy(int64, int64) error
 	GetQuestActiveAdventurer(int64) ([]model.Quest, error)
+	UpdateQuestTakenAt(model.Quest) error
+	GetTakenBy(int64) ([]model.TakenBy, error)
+	GetOverdueQuests(time.Time, time.Time) ([]model.Quest, error)
+	JoinQuest(model.Quest) (bool, error)
+	UpdateTakenByReward(model.TakenBy) error
+	ListQuests(model.QuestFilter) ([]model.Quest, int, error)
+	ListTakers([]int64) (map[int64][]model.Taker, error)
+	CreateAssignment(model.Assignment) error
+	FinishAssignment(model.Assignment) error
+	ListAssignments(model.AssignmentFilter) ([]model.Assignment, int, error)
 }
 
 type repository struct {
-	db *sql.DB
+	db      *sql.DB
+	tx      *sql.Tx
+	dialect database.Dialect
 }
 
-func NewRepository() (Repository, error) {
-	db := database.GetDB()
+func NewRepository(db *sql.DB, dialect database.Dialect) (Repository, error) {
+	if db == nil {
+		return nil, database.ErrNilDB
+	}
+
+	return &repository{db: db, dialect: dialect}, nil
+}
 
-	return &repository{db}, nil
+// NewTxRepository returns a repository whose queries run inside tx.
+func NewTxRepository(tx *sql.Tx, dialect database.Dialect) Repository {
+	return &repository{tx: tx, dialect: dialect}
 }
 
 func (r *repository) Close() {
-	r.db.Close()
+	if r.db != nil {
+		r.db.Close()
+	}
 }
 
-func (r *repository) GetAllCompletedQuest() (quests []model.GetQuestByStatus, err error) {
-	db := r.db
+func (r *repository) conn() database.Querier {
+	if r.tx != nil {
+		return database.Bind(r.tx, r.dialect)
+	}
+	return database.Bind(r.db, r.dialect)
+}
+
+// GetQuestsByStatus lists the quests in status with the adventurers that took
+// them, which only working and completed quests keep.
+func (r *repository) GetQuestsByStatus(status int32) (quests []model.GetQuestByStatus, err error) {
+	db := r.conn()
 
 	query := `
-	SELECT quest_id, name, description, minimum_rank, reward_number
+	SELECT quest_id, name, description, minimum_rank, reward_number, status, deadline, taken_at,
+		min_members, max_members, rank_rule, members
 	FROM quest
 	WHERE status = $1
+	ORDER BY quest_id
 	`
-	completedStatus := constant.CompletedQuest
 	quests = []model.GetQuestByStatus{}
-	rows, err := db.Query(query, completedStatus)
+	rows, err := db.Query(query, status)
 	if err != nil {
 		return
 	}
 	defer rows.Close()
 
+	index := map[int64]int{}
 	for rows.Next() {
 		quest := model.GetQuestByStatus{}
-		if err = rows.Scan(&quest.ID, &quest.Name, &quest.Description, &quest.MinimumRank, &quest.RewardNumber); err != nil {
+		var deadline, takenAt sql.NullTime
+		if err = rows.Scan(&quest.ID, &quest.Name, &quest.Description, &quest.MinimumRank, &quest.RewardNumber, &quest.Status,
+			&deadline, &takenAt, &quest.MinMembers, &quest.MaxMembers, &quest.RankRule, &quest.Members); err != nil {
 			return
 		}
+		quest.Deadline = timePtr(deadline)
+		quest.TakenAt = timePtr(takenAt)
+		index[quest.ID] = len(quests)
 		quests = append(quests, quest)
 	}
+	if len(quests) == 0 {
+		return
+	}
 
-	return
-}
-
-func (r *repository) GetAllAvailableQuest() (quests []model.GetQuestByStatus, err error) {
-	db := r.db
-
-	query := `
-	SELECT quest_id, name, description, minimum_rank, reward_number
-	FROM quest
-	WHERE status = $1
+	query = `
+	SELECT t.quest_id, a.id, a.name, a.rank, t.reward
+	FROM taken_by t
+	JOIN quest q ON q.quest_id = t.quest_id
+	JOIN adventurer a ON a.id = t.adv_id
+	WHERE q.status = $1
+	ORDER BY t.quest_id, a.id
 	`
-	availableStatus := constant.AvailableQuest
-	quests = []model.GetQuestByStatus{}
-	rows, err := db.Query(query, availableStatus)
+	takers, err := db.Query(query, status)
 	if err != nil {
 		return
 	}
-	defer rows.Close()
+	defer takers.Close()
 
-	for rows.Next() {
-		quest := model.GetQuestByStatus{}
-		if err = rows.Scan(&quest.ID, &quest.Name, &quest.Description, &quest.MinimumRank, &quest.RewardNumber); err != nil {
+	for takers.Next() {
+		var questID int64
+		var taker model.Taker
+		if err = takers.Scan(&questID, &taker.AdventurerID, &taker.Name, &taker.Rank, &taker.Reward); err != nil {
 			return
 		}
-		quests = append(quests, quest)
+		if i, ok := index[questID]; ok {
+			quests[i].Takers = append(quests[i].Takers, taker)
+		}
 	}
 
 	return
 }
 
 func (r *repository) CreateQuest(quest model.Quest) (qst model.Quest, err error) {
-	db := r.db
-	query := `INSERT INTO quest(name, description, minimum_rank, reward_number)
-	VALUES($1, $2, $3, $4) RETURNING quest_id`
+	db := r.conn()
+	query := `INSERT INTO quest(name, description, minimum_rank, reward_number, created_at, deadline, min_members, max_members, rank_rule, created_by)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)` + r.dialect.Returning("quest_id")
 	createForm, err := db.Prepare(query)
 	qst = quest
 	if err != nil {
 		return model.Quest{}, err
 	}
-	err = createForm.QueryRow(quest.Name, quest.Description, quest.MinimumRank, quest.RewardNumber).Scan(&qst.ID)
+	qst.ID, err = r.dialect.InsertID(createForm, quest.Name, quest.Description, quest.MinimumRank, quest.RewardNumber,
+		quest.CreatedAt.UTC(), nullTime(quest.Deadline), quest.MinMembers, quest.MaxMembers, quest.RankRule, nullID(quest.CreatedBy))
 	if err != nil {
 		return model.Quest{}, err
 	}
 	qst.Status = 0
+	qst.TakenAt = nil
+	qst.Members = 0
 	defer createForm.Close()
 	return
 }
 
 func (r *repository) UpdateQuestRank(quest model.Quest) error {
-	db := r.db
+	db := r.conn()
 	query := `UPDATE quest
 	SET minimum_rank = $1
 	WHERE quest_id = $2`
@@ -118,13 +161,13 @@ func (r *repository) UpdateQuestRank(quest model.Quest) error {
 	if err != nil {
 		return err
 	}
-	updateForm.Exec(quest.MinimumRank, quest.ID)
+	_, err = updateForm.Exec(quest.MinimumRank, quest.ID)
 	defer updateForm.Close()
 	return err
 }
 
 func (r *repository) UpdateQuestReward(quest model.Quest) error {
-	db := r.db
+	db := r.conn()
 	query := `UPDATE quest
 	SET reward_number = $1
 	WHERE quest_id = $2`
@@ -132,13 +175,13 @@ func (r *repository) UpdateQuestReward(quest model.Quest) error {
 	if err != nil {
 		return err
 	}
-	updateForm.Exec(quest.RewardNumber, quest.ID)
+	_, err = updateForm.Exec(quest.RewardNumber, quest.ID)
 	defer updateForm.Close()
 	return err
 }
 
 func (r *repository) UpdateQuestStatus(quest model.Quest) error {
-	db := r.db
+	db := r.conn()
 	query := `UPDATE quest
 	SET status = $1
 	WHERE quest_id = $2`
@@ -146,13 +189,29 @@ func (r *repository) UpdateQuestStatus(quest model.Quest) error {
 	if err != nil {
 		return err
 	}
-	updateForm.Exec(quest.Status, quest.ID)
+	_, err = updateForm.Exec(quest.Status, quest.ID)
 	defer updateForm.Close()
 	return err
 }
 
+// UpdateQuestStatusIf sets the quest status only while it still equals current,
+// and reports whether the row was updated. Concurrent callers racing on the
+// same transition are serialized by the row lock, so only one of them wins.
+func (r *repository) UpdateQuestStatusIf(quest model.Quest, current int32) (bool, error) {
+	db := r.conn()
+	query := `UPDATE quest
+	SET status = $1
+	WHERE quest_id = $2 AND status = $3`
+	res, err := db.Exec(query, quest.Status, quest.ID, current)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
+
 func (r *repository) DeleteQuest(quest model.Quest) error {
-	db := r.db
+	db := r.conn()
 	query := `DELETE FROM quest
 	WHERE quest_id = $1`
 	deleteForm, err := db.Prepare(query)
@@ -167,18 +226,19 @@ func (r *repository) DeleteQuest(quest model.Quest) error {
 }
 
 func (r *repository) GetQuest(id int64) (quest model.Quest, err error) {
-	db := r.db
-	query := `SELECT name, description, minimum_rank, reward_number, status
+	db := r.conn()
+	query := `SELECT quest_id, name, description, minimum_rank, reward_number, status, created_at, deadline, taken_at,
+		min_members, max_members, rank_rule, members, created_by
 	FROM quest
 	WHERE quest_id = $1`
+	quest, err = scanQuest(db.QueryRow(query, id))
 	quest.ID = id
-	err = db.QueryRow(query, id).Scan(&quest.Name, &quest.Description, &quest.MinimumRank, &quest.RewardNumber, &quest.Status)
 	return
 }
 
 func (r *repository) IsExistTakenBy(quest_id, adv_id int64) error {
 	var one int
-	db := r.db
+	db := r.conn()
 	query := `SELECT 1
 	FROM taken_by
 	WHERE quest_id = $1 AND adv_id = $2`
@@ -187,23 +247,73 @@ func (r *repository) IsExistTakenBy(quest_id, adv_id int64) error {
 }
 
 func (r *repository) CreateTakenBy(quest_id, adventurer_id int64) error {
-	db := r.db
+	db := r.conn()
 	query := `INSERT INTO taken_by(quest_id, adv_id)
 	VALUES($1, $2)`
 	createForm, err := db.Prepare(query)
 	if err != nil {
 		return err
 	}
-	createForm.Exec(quest_id, adventurer_id)
+	_, err = createForm.Exec(quest_id, adventurer_id)
 	defer createForm.Close()
 	return err
 }
 
+// DeleteTakenBy unlinks the adventurer from the quest and frees their place in
+// the party.
+func (r *repository) DeleteTakenBy(quest_id, adventurer_id int64) error {
+	db := r.conn()
+	query := `DELETE FROM taken_by
+	WHERE quest_id = $1 AND adv_id = $2`
+	res, err := db.Exec(query, quest_id, adventurer_id)
+	if err != nil {
+		return err
+	}
+	if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+		return err
+	}
+	query = `UPDATE quest
+	SET members = members - 1
+	WHERE quest_id = $1 AND members > 0`
+	_, err = db.Exec(query, quest_id)
+	return err
+}
+
+// JoinQuest takes a place in the party of an available or working quest that
+// is not full, and reports whether one was taken. The quest turns working,
+// taken at quest.TakenAt, once the party reaches its minimum size. Like
+// UpdateQuestStatusIf, the conditional update lets only as many racing callers
+// in as there are places.
+func (r *repository) JoinQuest(quest model.Quest) (bool, error) {
+	db := r.conn()
+	query := `UPDATE quest
+	SET members = members + 1,
+		status = CASE WHEN members + 1 >= min_members THEN $1 ELSE status END,
+		taken_at = CASE WHEN members + 1 = min_members THEN $2 ELSE taken_at END
+	WHERE quest_id = $3 AND status IN ($4, $1) AND members < max_members`
+	res, err := db.Exec(query, constant.WorkingQuest, nullTime(quest.TakenAt), quest.ID, constant.AvailableQuest)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *repository) UpdateTakenByReward(taken model.TakenBy) error {
+	db := r.conn()
+	query := `UPDATE taken_by
+	SET reward = $1
+	WHERE quest_id = $2 AND adv_id = $3`
+	_, err := db.Exec(query, taken.Reward, taken.QuestID, taken.AdventurerID)
+	return err
+}
+
 func (r *repository) GetQuestActiveAdventurer(id int64) (quests []model.Quest, err error) {
-	db := r.db
+	db := r.conn()
 
 	query := `
-	SELECT quest_id, name, description, minimum_rank, reward_number, status
+	SELECT quest_id, name, description, minimum_rank, reward_number, status, created_at, deadline, taken_at,
+		min_members, max_members, rank_rule, members, created_by
 	FROM quest NATURAL JOIN taken_by
 	WHERE status = $1 AND adv_id = $2
 	`
@@ -215,8 +325,101 @@ func (r *repository) GetQuestActiveAdventurer(id int64) (quests []model.Quest, e
 	defer rows.Close()
 
 	for rows.Next() {
-		quest := model.Quest{}
-		if err = rows.Scan(&quest.ID, &quest.Name, &quest.Description, &quest.MinimumRank, &quest.RewardNumber, &quest.Status); err != nil {
+		var quest model.Quest
+		if quest, err = scanQuest(rows); err != nil {
+			return
+		}
+		quests = append(quests, quest)
+	}
+
+	return
+}
+
+func (r *repository) UpdateQuestTakenAt(quest model.Quest) error {
+	db := r.conn()
+	query := `UPDATE quest
+	SET taken_at = $1
+	WHERE quest_id = $2`
+	_, err := db.Exec(query, nullTime(quest.TakenAt), quest.ID)
+	return err
+}
+
+func (r *repository) GetTakenBy(quest_id int64) (takers []model.TakenBy, err error) {
+	db := r.conn()
+	query := `SELECT quest_id, adv_id, reward
+	FROM taken_by
+	WHERE quest_id = $1
+	ORDER BY adv_id`
+	takers = []model.TakenBy{}
+	rows, err := db.Query(query, quest_id)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		taken := model.TakenBy{}
+		if err = rows.Scan(&taken.QuestID, &taken.AdventurerID, &taken.Reward); err != nil {
+			return
+		}
+		takers = append(takers, taken)
+	}
+
+	return
+}
+
+// GetOverdueQuests returns the open quests whose deadline is before now and
+// the working quests taken before takenBefore.
+func (r *repository) GetOverdueQuests(now, takenBefore time.Time) (quests []model.Quest, err error) {
+	db := r.conn()
+
+	query := `
+	SELECT quest_id, name, description, minimum_rank, reward_number, status, created_at, deadline, taken_at,
+		min_members, max_members, rank_rule, members, created_by
+	FROM quest
+	WHERE (status IN ($1, $2) AND deadline < $3) OR (status = $2 AND taken_at < $4)
+	ORDER BY quest_id
+	`
+	quests = []model.Quest{}
+	rows, err := db.Query(query, constant.AvailableQuest, constant.WorkingQuest, now.UTC(), takenBefore.UTC())
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var quest model.Quest
+		if quest, err = scanQuest(rows); err != nil {
+			return
+		}
+		quests = append(quests, quest)
+	}
+
+	return
+}
+
+// ListQuests returns the page of quests selected by filter and the number of
+// quests matching it across all pages.
+func (r *repository) ListQuests(filter model.QuestFilter) (quests []model.Quest, total int, err error) {
+	db := r.conn()
+	c := questConditions(filter)
+
+	query, args := countQuestsQuery(c)
+	if err = db.QueryRow(query, args...).Scan(&total); err != nil {
+		return
+	}
+
+	quests = []model.Quest{}
+	query, args = listQuestsQuery(c, filter)
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var quest model.Quest
+		if quest, err = scanQuest(rows); err != nil {
 			return
 		}
 		quests = append(quests, quest)
@@ -224,3 +427,83 @@ func (r *repository) GetQuestActiveAdventurer(id int64) (quests []model.Quest, e
 
 	return
 }
+
+// ListTakers returns the adventurers assigned to each of the quests, ordered by
+// id. Quests without any are left out.
+func (r *repository) ListTakers(questIDs []int64) (takers map[int64][]model.Taker, err error) {
+	takers = map[int64][]model.Taker{}
+	if len(questIDs) == 0 {
+		return
+	}
+	db := r.conn()
+	c := &database.Conditions{}
+	ids := make([]interface{}, len(questIDs))
+	for i, id := range questIDs {
+		ids[i] = id
+	}
+	c.In("t.quest_id", ids...)
+
+	query := "SELECT t.quest_id, a.id, a.name, a.rank, t.reward FROM taken_by t JOIN adventurer a ON a.id = t.adv_id" +
+		c.Where() + " ORDER BY t.quest_id, a.id"
+	rows, err := db.Query(query, c.Args()...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var questID int64
+		var taker model.Taker
+		if err = rows.Scan(&questID, &taker.AdventurerID, &taker.Name, &taker.Rank, &taker.Reward); err != nil {
+			return
+		}
+		takers[questID] = append(takers[questID], taker)
+	}
+
+	return
+}
+
+type scanner interface {
+	Scan(...interface{}) error
+}
+
+// scanQuest reads a row selected as quest_id, name, description, minimum_rank,
+// reward_number, status, created_at, deadline, taken_at, min_members,
+// max_members, rank_rule, members, created_by.
+func scanQuest(row scanner) (quest model.Quest, err error) {
+	var deadline, takenAt sql.NullTime
+	var createdBy sql.NullInt64
+	err = row.Scan(&quest.ID, &quest.Name, &quest.Description, &quest.MinimumRank, &quest.RewardNumber, &quest.Status,
+		&quest.CreatedAt, &deadline, &takenAt, &quest.MinMembers, &quest.MaxMembers, &quest.RankRule, &quest.Members, &createdBy)
+	if err != nil {
+		return model.Quest{}, err
+	}
+	quest.Deadline = timePtr(deadline)
+	quest.TakenAt = timePtr(takenAt)
+	quest.CreatedBy = createdBy.Int64
+	return
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// nullID stores 0, the id of nothing, as NULL.
+func nullID(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// nullTime stores times in UTC so that SQLite, which keeps them as text,
+// compares them in order.
+func nullTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
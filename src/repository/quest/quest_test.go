@@ -2,12 +2,15 @@ package quest
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"log"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/database"
 	modelAdv "github.com/arfaghifari/guild-board/src/model/adventurer"
 	model "github.com/arfaghifari/guild-board/src/model/quest"
 	"github.com/stretchr/testify/assert"
@@ -22,6 +25,12 @@ func NewMock() (*sql.DB, sqlmock.Sqlmock) {
 	return db, mock
 }
 
+var (
+	createdAt = time.Date(2023, time.July, 1, 9, 0, 0, 0, time.UTC)
+	deadline  = time.Date(2023, time.July, 8, 9, 0, 0, 0, time.UTC)
+	takenAt   = time.Date(2023, time.July, 2, 9, 0, 0, 0, time.UTC)
+)
+
 var bulkQuest = []model.Quest{
 	{
 		ID:           1,
@@ -30,6 +39,12 @@ var bulkQuest = []model.Quest{
 		MinimumRank:  11,
 		RewardNumber: 200000,
 		Status:       constant.AvailableQuest,
+		CreatedAt:    createdAt,
+		Deadline:     &deadline,
+		MinMembers:   1,
+		MaxMembers:   1,
+		RankRule:     constant.RankRuleAll,
+		CreatedBy:    giverID,
 	},
 	{
 		ID:           2,
@@ -38,6 +53,12 @@ var bulkQuest = []model.Quest{
 		MinimumRank:  11,
 		RewardNumber: 200000,
 		Status:       constant.WorkingQuest,
+		CreatedAt:    createdAt,
+		TakenAt:      &takenAt,
+		MinMembers:   1,
+		MaxMembers:   1,
+		RankRule:     constant.RankRuleAll,
+		Members:      1,
 	},
 	{
 		ID:           3,
@@ -46,6 +67,13 @@ var bulkQuest = []model.Quest{
 		MinimumRank:  13,
 		RewardNumber: 600000,
 		Status:       constant.WorkingQuest,
+		CreatedAt:    createdAt,
+		TakenAt:      &takenAt,
+		MinMembers:   2,
+		MaxMembers:   3,
+		RankRule:     constant.RankRuleAverage,
+		Members:      2,
+		CreatedBy:    giverID,
 	},
 }
 var bulkQuestByStatus = []model.GetQuestByStatus{
@@ -55,6 +83,11 @@ var bulkQuestByStatus = []model.GetQuestByStatus{
 		Description:  "menyelamatkan kucing yang terjebak di atas pohon",
 		MinimumRank:  11,
 		RewardNumber: 200000,
+		Status:       constant.AvailableQuest,
+		Deadline:     &deadline,
+		MinMembers:   1,
+		MaxMembers:   1,
+		RankRule:     constant.RankRuleAll,
 	},
 	{
 		ID:           2,
@@ -62,6 +95,12 @@ var bulkQuestByStatus = []model.GetQuestByStatus{
 		Description:  "membersihkan selokan penuh dengan lumut",
 		MinimumRank:  11,
 		RewardNumber: 200000,
+		Status:       constant.WorkingQuest,
+		TakenAt:      &takenAt,
+		MinMembers:   1,
+		MaxMembers:   1,
+		RankRule:     constant.RankRuleAll,
+		Members:      1,
 	},
 	{
 		ID:           3,
@@ -69,6 +108,12 @@ var bulkQuestByStatus = []model.GetQuestByStatus{
 		Description:  "Mengantar pulang pergi dan keliling kota, Jakarta-Bandung, Sudah di kasih makan",
 		MinimumRank:  13,
 		RewardNumber: 600000,
+		Status:       constant.WorkingQuest,
+		TakenAt:      &takenAt,
+		MinMembers:   2,
+		MaxMembers:   3,
+		RankRule:     constant.RankRuleAverage,
+		Members:      2,
 	},
 }
 var adv = modelAdv.Adventurer{
@@ -78,6 +123,43 @@ var adv = modelAdv.Adventurer{
 	CompletedQuest: 1,
 }
 
+// giverID is the quest giver who posted bulkQuest[0] and bulkQuest[2].
+const giverID = 1
+
+// nullable turns an optional time into the value a row driver returns.
+func nullable(t *time.Time) driver.Value {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
+
+func nullableID(id int64) driver.Value {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+func questRow(quest model.Quest) []driver.Value {
+	return []driver.Value{quest.ID, quest.Name, quest.Description, quest.MinimumRank, quest.RewardNumber, quest.Status,
+		quest.CreatedAt, nullable(quest.Deadline), nullable(quest.TakenAt), quest.MinMembers, quest.MaxMembers, quest.RankRule, quest.Members,
+		nullableID(quest.CreatedBy)}
+}
+
+var questColumns = []string{"quest_id", "name", "description", "minimum_rank", "reward_number", "status", "created_at", "deadline", "taken_at",
+	"min_members", "max_members", "rank_rule", "members", "created_by"}
+
+func questByStatusRow(quest model.GetQuestByStatus) []driver.Value {
+	return []driver.Value{quest.ID, quest.Name, quest.Description, quest.MinimumRank, quest.RewardNumber, quest.Status,
+		nullable(quest.Deadline), nullable(quest.TakenAt), quest.MinMembers, quest.MaxMembers, quest.RankRule, quest.Members}
+}
+
+var questByStatusColumns = []string{"quest_id", "name", "description", "minimum_rank", "reward_number", "status", "deadline", "taken_at",
+	"min_members", "max_members", "rank_rule", "members"}
+
+var takerColumns = []string{"quest_id", "id", "name", "rank", "reward"}
+
 func TestClose(t *testing.T) {
 	db, _ := NewMock()
 	r := repository{
@@ -87,212 +169,140 @@ func TestClose(t *testing.T) {
 }
 
 func TestNewRepository(t *testing.T) {
-	res, err := NewRepository()
+	db, _ := NewMock()
+	defer db.Close()
+
+	res, err := NewRepository(db, database.Postgres)
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
+
+	res, err = NewRepository(nil, database.Postgres)
+	assert.Equal(t, database.ErrNilDB, err)
+	assert.Nil(t, res)
 }
 
-func TestGetAllCompletedQuest(t *testing.T) {
+func TestGetQuestsByStatus(t *testing.T) {
 	db, mock := NewMock()
 	defer func() {
 		db.Close()
 	}()
-	query := regexp.QuoteMeta("SELECT quest_id, name, description, minimum_rank, reward_number FROM quest WHERE status = $1")
-	type fields struct {
-		db *sql.DB
+	query := regexp.QuoteMeta("SELECT quest_id, name, description, minimum_rank, reward_number, status, deadline, taken_at, min_members, max_members, rank_rule, members FROM quest WHERE status = $1 ORDER BY quest_id")
+	takersQuery := regexp.QuoteMeta("SELECT t.quest_id, a.id, a.name, a.rank, t.reward FROM taken_by t JOIN quest q ON q.quest_id = t.quest_id JOIN adventurer a ON a.id = t.adv_id WHERE q.status = $1 ORDER BY t.quest_id, a.id")
+	withTakers := func(quest model.GetQuestByStatus, takers ...model.Taker) model.GetQuestByStatus {
+		quest.Takers = takers
+		return quest
 	}
+	budi := model.Taker{AdventurerID: 2, Name: "budi", Rank: 13}
 	tests := []struct {
 		name     string
-		fields   fields
+		status   int32
 		mock     func()
 		outQuest []model.GetQuestByStatus
-		outLen   int
 		wantErr  bool
 	}{
 		{
-			name: "success get quest",
-			fields: fields{
-				db: db,
-			},
-
-			mock: func() {
-				rows := sqlmock.NewRows([]string{"quest_id", "name", "description", "minimum_rank", "reward_number"}).
-					AddRow(bulkQuestByStatus[2].ID, bulkQuestByStatus[2].Name, bulkQuestByStatus[2].Description, bulkQuestByStatus[2].MinimumRank, bulkQuestByStatus[2].RewardNumber)
-
-				mock.ExpectQuery(query).WithArgs(constant.CompletedQuest).WillReturnRows(rows)
-			},
-			outQuest: bulkQuestByStatus[2:],
-			outLen:   1,
-			wantErr:  false,
-		},
-		{
-			name: "none quest",
-			fields: fields{
-				db: db,
-			},
+			name:   "success get available quest",
+			status: constant.AvailableQuest,
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"quest_id", "name", "description", "minimum_rank", "reward_number"})
-
-				mock.ExpectQuery(query).WithArgs(constant.CompletedQuest).WillReturnRows(rows)
+				rows := sqlmock.NewRows(questByStatusColumns).
+					AddRow(questByStatusRow(bulkQuestByStatus[0])...)
+				mock.ExpectQuery(query).WithArgs(constant.AvailableQuest).WillReturnRows(rows)
+				mock.ExpectQuery(takersQuery).WithArgs(constant.AvailableQuest).WillReturnRows(sqlmock.NewRows(takerColumns))
 			},
-			outQuest: []model.GetQuestByStatus{},
-			outLen:   0,
+			outQuest: bulkQuestByStatus[:1],
 			wantErr:  false,
 		},
 		{
-			name: "failed query",
-			fields: fields{
-				db: db,
-			},
+			name:   "success get working quest with takers",
+			status: constant.WorkingQuest,
 			mock: func() {
-				mock.ExpectQuery(query).WithArgs(constant.CompletedQuest).WillReturnError(sql.ErrConnDone)
+				rows := sqlmock.NewRows(questByStatusColumns).
+					AddRow(questByStatusRow(bulkQuestByStatus[1])...).
+					AddRow(questByStatusRow(bulkQuestByStatus[2])...)
+				mock.ExpectQuery(query).WithArgs(constant.WorkingQuest).WillReturnRows(rows)
+				takers := sqlmock.NewRows(takerColumns).
+					AddRow(2, adv.ID, adv.Name, adv.Rank, 0).
+					AddRow(3, adv.ID, adv.Name, adv.Rank, 0).
+					AddRow(3, budi.AdventurerID, budi.Name, budi.Rank, 0)
+				mock.ExpectQuery(takersQuery).WithArgs(constant.WorkingQuest).WillReturnRows(takers)
+			},
+			outQuest: []model.GetQuestByStatus{
+				withTakers(bulkQuestByStatus[1], model.Taker{AdventurerID: adv.ID, Name: adv.Name, Rank: adv.Rank}),
+				withTakers(bulkQuestByStatus[2], model.Taker{AdventurerID: adv.ID, Name: adv.Name, Rank: adv.Rank}, budi),
 			},
-			outQuest: []model.GetQuestByStatus{},
-			outLen:   0,
-			wantErr:  true,
+			wantErr: false,
 		},
 		{
-			name: "failed scan query",
-			fields: fields{
-				db: db,
-			},
+			name:   "none quest",
+			status: constant.CompletedQuest,
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"quest_id", "name", "description", "minimum_rank", "reward_number"}).
-					AddRow(bulkQuestByStatus[2].ID, nil, bulkQuestByStatus[2].Description, bulkQuestByStatus[2].MinimumRank, bulkQuestByStatus[2].RewardNumber)
+				rows := sqlmock.NewRows(questByStatusColumns)
 				mock.ExpectQuery(query).WithArgs(constant.CompletedQuest).WillReturnRows(rows)
-
 			},
 			outQuest: []model.GetQuestByStatus{},
-			outLen:   0,
-			wantErr:  true,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			r := &repository{
-				db: tt.fields.db,
-			}
-			tt.mock()
-			res, err := r.GetAllCompletedQuest()
-			assert.NotNil(t, res)
-			assert.Len(t, tt.outQuest, tt.outLen)
-			assert.Equal(t, tt.outQuest, res)
-			if tt.wantErr {
-				assert.Error(t, err, tt.name)
-			} else {
-				assert.NoError(t, err, tt.name)
-			}
-		})
-	}
-}
-
-func TestGetAllAvailabeTest(t *testing.T) {
-	db, mock := NewMock()
-	defer func() {
-		db.Close()
-	}()
-	query := regexp.QuoteMeta("SELECT quest_id, name, description, minimum_rank, reward_number FROM quest WHERE status = $1")
-	type fields struct {
-		db *sql.DB
-	}
-	tests := []struct {
-		name     string
-		fields   fields
-		mock     func()
-		outQuest []model.GetQuestByStatus
-		outLen   int
-		wantErr  bool
-	}{
-		{
-			name: "success get 1 quest",
-			fields: fields{
-				db: db,
-			},
-
-			mock: func() {
-				rows := sqlmock.NewRows([]string{"quest_id", "name", "description", "minimum_rank", "reward_number"}).
-					AddRow(bulkQuestByStatus[0].ID, bulkQuestByStatus[0].Name, bulkQuestByStatus[0].Description, bulkQuestByStatus[0].MinimumRank, bulkQuestByStatus[0].RewardNumber)
-
-				mock.ExpectQuery(query).WithArgs(constant.AvailableQuest).WillReturnRows(rows)
-			},
-			outQuest: bulkQuestByStatus[:1],
-			outLen:   1,
 			wantErr:  false,
 		},
 		{
-			name: "success get 2 quest",
-			fields: fields{
-				db: db,
-			},
-
+			name:   "failed query",
+			status: constant.CompletedQuest,
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"quest_id", "name", "description", "minimum_rank", "reward_number"}).
-					AddRow(bulkQuestByStatus[0].ID, bulkQuestByStatus[0].Name, bulkQuestByStatus[0].Description, bulkQuestByStatus[0].MinimumRank, bulkQuestByStatus[0].RewardNumber).
-					AddRow(bulkQuestByStatus[1].ID, bulkQuestByStatus[1].Name, bulkQuestByStatus[1].Description, bulkQuestByStatus[1].MinimumRank, bulkQuestByStatus[1].RewardNumber)
-
-				mock.ExpectQuery(query).WithArgs(constant.AvailableQuest).WillReturnRows(rows)
+				mock.ExpectQuery(query).WithArgs(constant.CompletedQuest).WillReturnError(sql.ErrConnDone)
 			},
-			outQuest: bulkQuestByStatus[:2],
-			outLen:   2,
-			wantErr:  false,
+			outQuest: []model.GetQuestByStatus{},
+			wantErr:  true,
 		},
 		{
-			name: "none quest",
-			fields: fields{
-				db: db,
-			},
+			name:   "failed scan query",
+			status: constant.AvailableQuest,
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"quest_id", "name", "description", "minimum_rank", "reward_number"})
-
+				row := questByStatusRow(bulkQuestByStatus[0])
+				row[1] = nil
+				rows := sqlmock.NewRows(questByStatusColumns).AddRow(row...)
 				mock.ExpectQuery(query).WithArgs(constant.AvailableQuest).WillReturnRows(rows)
 			},
 			outQuest: []model.GetQuestByStatus{},
-			outLen:   0,
-			wantErr:  false,
+			wantErr:  true,
 		},
 		{
-			name: "failed query",
-			fields: fields{
-				db: db,
-			},
+			name:   "failed takers query",
+			status: constant.WorkingQuest,
 			mock: func() {
-				mock.ExpectQuery(query).WithArgs(constant.AvailableQuest).WillReturnError(sql.ErrConnDone)
+				rows := sqlmock.NewRows(questByStatusColumns).
+					AddRow(questByStatusRow(bulkQuestByStatus[1])...)
+				mock.ExpectQuery(query).WithArgs(constant.WorkingQuest).WillReturnRows(rows)
+				mock.ExpectQuery(takersQuery).WithArgs(constant.WorkingQuest).WillReturnError(sql.ErrConnDone)
 			},
-			outQuest: []model.GetQuestByStatus{},
-			outLen:   0,
+			outQuest: bulkQuestByStatus[1:2],
 			wantErr:  true,
 		},
 		{
-			name: "failed scan query",
-			fields: fields{
-				db: db,
-			},
+			name:   "failed scan takers query",
+			status: constant.WorkingQuest,
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"quest_id", "name", "description", "minimum_rank", "reward_number"}).
-					AddRow(bulkQuest[0].ID, nil, bulkQuest[0].Description, bulkQuest[0].MinimumRank, bulkQuest[0].RewardNumber)
-				mock.ExpectQuery(query).WithArgs(constant.AvailableQuest).WillReturnRows(rows)
-
+				rows := sqlmock.NewRows(questByStatusColumns).
+					AddRow(questByStatusRow(bulkQuestByStatus[1])...)
+				mock.ExpectQuery(query).WithArgs(constant.WorkingQuest).WillReturnRows(rows)
+				takers := sqlmock.NewRows(takerColumns).AddRow(2, adv.ID, nil, adv.Rank, 0)
+				mock.ExpectQuery(takersQuery).WithArgs(constant.WorkingQuest).WillReturnRows(takers)
 			},
-			outQuest: []model.GetQuestByStatus{},
-			outLen:   0,
+			outQuest: bulkQuestByStatus[1:2],
 			wantErr:  true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &repository{
-				db: tt.fields.db,
+				db: db,
 			}
 			tt.mock()
-			res, err := r.GetAllAvailableQuest()
-			assert.NotNil(t, res)
-			assert.Len(t, tt.outQuest, tt.outLen)
+			res, err := r.GetQuestsByStatus(tt.status)
 			assert.Equal(t, tt.outQuest, res)
 			if tt.wantErr {
 				assert.Error(t, err, tt.name)
 			} else {
 				assert.NoError(t, err, tt.name)
 			}
+			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
@@ -302,7 +312,7 @@ func TestCreateQuest(t *testing.T) {
 	defer func() {
 		db.Close()
 	}()
-	query := regexp.QuoteMeta("INSERT INTO quest(name, description, minimum_rank, reward_number) VALUES($1, $2, $3, $4) RETURNING quest_id")
+	query := regexp.QuoteMeta("INSERT INTO quest(name, description, minimum_rank, reward_number, created_at, deadline, min_members, max_members, rank_rule, created_by) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING quest_id")
 	type fields struct {
 		db *sql.DB
 	}
@@ -329,7 +339,8 @@ func TestCreateQuest(t *testing.T) {
 				rows := sqlmock.NewRows([]string{"quest_id"}).
 					AddRow(bulkQuest[0].ID)
 				prep := mock.ExpectPrepare(query)
-				prep.ExpectQuery().WithArgs(bulkQuest[0].Name, bulkQuest[0].Description, bulkQuest[0].MinimumRank, bulkQuest[0].RewardNumber).WillReturnRows(rows)
+				prep.ExpectQuery().WithArgs(bulkQuest[0].Name, bulkQuest[0].Description, bulkQuest[0].MinimumRank, bulkQuest[0].RewardNumber, createdAt, deadline,
+					bulkQuest[0].MinMembers, bulkQuest[0].MaxMembers, bulkQuest[0].RankRule, bulkQuest[0].CreatedBy).WillReturnRows(rows)
 			},
 			outQuest: bulkQuest[0],
 			wantErr:  false,
@@ -643,7 +654,7 @@ func TestGetQuest(t *testing.T) {
 	defer func() {
 		db.Close()
 	}()
-	query := regexp.QuoteMeta("SELECT name, description, minimum_rank, reward_number, status FROM quest WHERE quest_id = $1")
+	query := regexp.QuoteMeta("SELECT quest_id, name, description, minimum_rank, reward_number, status, created_at, deadline, taken_at, min_members, max_members, rank_rule, members, created_by FROM quest WHERE quest_id = $1")
 	type fields struct {
 		db *sql.DB
 	}
@@ -667,8 +678,7 @@ func TestGetQuest(t *testing.T) {
 				ID: bulkQuest[0].ID,
 			},
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"name", "description", "minimum_rank", "reward_number", "status"}).
-					AddRow(bulkQuest[0].Name, bulkQuest[0].Description, bulkQuest[0].MinimumRank, bulkQuest[0].RewardNumber, bulkQuest[0].Status)
+				rows := sqlmock.NewRows(questColumns).AddRow(questRow(bulkQuest[0])...)
 				mock.ExpectQuery(query).WithArgs(bulkQuest[0].ID).WillReturnRows(rows)
 			},
 			outQuest: bulkQuest[0],
@@ -683,7 +693,7 @@ func TestGetQuest(t *testing.T) {
 				ID: bulkQuest[0].ID,
 			},
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"name", "description", "minimum_rank", "reward_number", "status"})
+				rows := sqlmock.NewRows(questColumns)
 				mock.ExpectQuery(query).WithArgs(bulkQuest[0].ID).WillReturnRows(rows)
 			},
 			outQuest: model.Quest{ID: bulkQuest[0].ID},
@@ -848,7 +858,7 @@ func TestGetGetQuestActiveAdventurer(t *testing.T) {
 	defer func() {
 		db.Close()
 	}()
-	query := regexp.QuoteMeta("SELECT quest_id, name, description, minimum_rank, reward_number, status FROM quest NATURAL JOIN taken_by WHERE status = $1 AND adv_id = $2")
+	query := regexp.QuoteMeta("SELECT quest_id, name, description, minimum_rank, reward_number, status, created_at, deadline, taken_at, min_members, max_members, rank_rule, members, created_by FROM quest NATURAL JOIN taken_by WHERE status = $1 AND adv_id = $2")
 	type fields struct {
 		db *sql.DB
 	}
@@ -867,8 +877,7 @@ func TestGetGetQuestActiveAdventurer(t *testing.T) {
 			},
 
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"quest_id", "name", "description", "minimum_rank", "reward_number", "status"}).
-					AddRow(bulkQuest[2].ID, bulkQuest[2].Name, bulkQuest[2].Description, bulkQuest[2].MinimumRank, bulkQuest[2].RewardNumber, bulkQuest[2].Status)
+				rows := sqlmock.NewRows(questColumns).AddRow(questRow(bulkQuest[2])...)
 
 				mock.ExpectQuery(query).WithArgs(constant.WorkingQuest, adv.ID).WillReturnRows(rows)
 			},
@@ -882,7 +891,7 @@ func TestGetGetQuestActiveAdventurer(t *testing.T) {
 				db: db,
 			},
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"quest_id", "name", "description", "minimum_rank", "reward_number", "status"})
+				rows := sqlmock.NewRows(questColumns)
 
 				mock.ExpectQuery(query).WithArgs(constant.WorkingQuest, adv.ID).WillReturnRows(rows)
 			},
@@ -908,8 +917,9 @@ func TestGetGetQuestActiveAdventurer(t *testing.T) {
 				db: db,
 			},
 			mock: func() {
-				rows := sqlmock.NewRows([]string{"quest_id", "name", "description", "minimum_rank", "reward_number", "status"}).
-					AddRow(bulkQuest[2].ID, nil, bulkQuest[2].Description, bulkQuest[2].MinimumRank, bulkQuest[2].RewardNumber, bulkQuest[2].Status)
+				row := questRow(bulkQuest[2])
+				row[1] = nil
+				rows := sqlmock.NewRows(questColumns).AddRow(row...)
 				mock.ExpectQuery(query).WithArgs(constant.WorkingQuest, adv.ID).WillReturnRows(rows)
 			},
 			outQuest: []model.Quest{},
@@ -935,3 +945,827 @@ func TestGetGetQuestActiveAdventurer(t *testing.T) {
 		})
 	}
 }
+
+func TestNewTxRepository(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	query := regexp.QuoteMeta("DELETE FROM taken_by WHERE quest_id = $1 AND adv_id = $2")
+	mock.ExpectBegin()
+	mock.ExpectExec(query).WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	r := NewTxRepository(tx, database.Postgres)
+	assert.NoError(t, r.DeleteTakenBy(1, 1))
+	r.Close()
+	assert.NoError(t, tx.Commit())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateQuestStatusIf(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	query := regexp.QuoteMeta("UPDATE quest SET status = $1 WHERE quest_id = $2 AND status = $3")
+	type fields struct {
+		db *sql.DB
+	}
+	type args struct {
+		quest   model.Quest
+		current int32
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		mock       func()
+		outUpdated bool
+		wantErr    bool
+	}{
+		{
+			name: "success updated quest status",
+			fields: fields{
+				db: db,
+			},
+			args: args{
+				quest:   bulkQuest[1],
+				current: constant.AvailableQuest,
+			},
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(bulkQuest[1].Status, bulkQuest[1].ID, constant.AvailableQuest).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			outUpdated: true,
+			wantErr:    false,
+		},
+		{
+			name: "status already changed",
+			fields: fields{
+				db: db,
+			},
+			args: args{
+				quest:   bulkQuest[1],
+				current: constant.AvailableQuest,
+			},
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(bulkQuest[1].Status, bulkQuest[1].ID, constant.AvailableQuest).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			outUpdated: false,
+			wantErr:    false,
+		},
+		{
+			name: "failed updated quest status",
+			fields: fields{
+				db: db,
+			},
+			args: args{
+				quest:   bulkQuest[1],
+				current: constant.AvailableQuest,
+			},
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(bulkQuest[1].Status, bulkQuest[1].ID, constant.AvailableQuest).WillReturnError(sql.ErrConnDone)
+			},
+			outUpdated: false,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repository{
+				db: tt.fields.db,
+			}
+			tt.mock()
+			res, err := r.UpdateQuestStatusIf(tt.args.quest, tt.args.current)
+			assert.Equal(t, tt.outUpdated, res)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+		})
+	}
+}
+
+func TestDeleteTakenBy(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	query := regexp.QuoteMeta("DELETE FROM taken_by WHERE quest_id = $1 AND adv_id = $2")
+	membersQuery := regexp.QuoteMeta("UPDATE quest SET members = members - 1 WHERE quest_id = $1 AND members > 0")
+	type fields struct {
+		db *sql.DB
+	}
+	type args struct {
+		quest_id int64
+		adv_id   int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		mock    func()
+		wantErr bool
+	}{
+		{
+			name: "success deleted taken by",
+			fields: fields{
+				db: db,
+			},
+			args: args{
+				quest_id: 1,
+				adv_id:   1,
+			},
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(membersQuery).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "success nothing to delete",
+			fields: fields{
+				db: db,
+			},
+			args: args{
+				quest_id: 1,
+				adv_id:   1,
+			},
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed free party place",
+			fields: fields{
+				db: db,
+			},
+			args: args{
+				quest_id: 1,
+				adv_id:   1,
+			},
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(membersQuery).WithArgs(1).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed deleted taken by",
+			fields: fields{
+				db: db,
+			},
+			args: args{
+				quest_id: 1,
+				adv_id:   1,
+			},
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(1, 1).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repository{
+				db: tt.fields.db,
+			}
+			tt.mock()
+			err := r.DeleteTakenBy(tt.args.quest_id, tt.args.adv_id)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateQuestTakenAt(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	query := regexp.QuoteMeta("UPDATE quest SET taken_at = $1 WHERE quest_id = $2")
+	tests := []struct {
+		name    string
+		quest   model.Quest
+		mock    func()
+		wantErr bool
+	}{
+		{
+			name:  "success set taken at",
+			quest: bulkQuest[1],
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(takenAt, bulkQuest[1].ID).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name:  "success clear taken at",
+			quest: bulkQuest[0],
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(nil, bulkQuest[0].ID).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name:  "failed query",
+			quest: bulkQuest[1],
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(takenAt, bulkQuest[1].ID).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repository{
+				db: db,
+			}
+			tt.mock()
+			err := r.UpdateQuestTakenAt(tt.quest)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetTakenBy(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	query := regexp.QuoteMeta("SELECT quest_id, adv_id, reward FROM taken_by WHERE quest_id = $1 ORDER BY adv_id")
+	tests := []struct {
+		name    string
+		mock    func()
+		out     []model.TakenBy
+		wantErr bool
+	}{
+		{
+			name: "success get takers",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"quest_id", "adv_id", "reward"}).AddRow(bulkQuest[1].ID, adv.ID, 200000)
+				mock.ExpectQuery(query).WithArgs(bulkQuest[1].ID).WillReturnRows(rows)
+			},
+			out:     []model.TakenBy{{QuestID: bulkQuest[1].ID, AdventurerID: adv.ID, Reward: 200000}},
+			wantErr: false,
+		},
+		{
+			name: "failed query",
+			mock: func() {
+				mock.ExpectQuery(query).WithArgs(bulkQuest[1].ID).WillReturnError(sql.ErrConnDone)
+			},
+			out:     []model.TakenBy{},
+			wantErr: true,
+		},
+		{
+			name: "failed scan query",
+			mock: func() {
+				rows := sqlmock.NewRows([]string{"quest_id", "adv_id", "reward"}).AddRow(bulkQuest[1].ID, nil, 0)
+				mock.ExpectQuery(query).WithArgs(bulkQuest[1].ID).WillReturnRows(rows)
+			},
+			out:     []model.TakenBy{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repository{
+				db: db,
+			}
+			tt.mock()
+			res, err := r.GetTakenBy(bulkQuest[1].ID)
+			assert.Equal(t, tt.out, res)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListTakers(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	query := regexp.QuoteMeta("SELECT t.quest_id, a.id, a.name, a.rank, t.reward FROM taken_by t JOIN adventurer a ON a.id = t.adv_id WHERE t.quest_id IN ($1, $2) ORDER BY t.quest_id, a.id")
+	columns := []string{"quest_id", "id", "name", "rank", "reward"}
+	tests := []struct {
+		name     string
+		questIDs []int64
+		mock     func()
+		out      map[int64][]model.Taker
+		wantErr  bool
+	}{
+		{
+			name:     "success list takers",
+			questIDs: []int64{bulkQuest[1].ID, bulkQuest[2].ID},
+			mock: func() {
+				rows := sqlmock.NewRows(columns).AddRow(bulkQuest[1].ID, adv.ID, adv.Name, adv.Rank, 200000)
+				mock.ExpectQuery(query).WithArgs(bulkQuest[1].ID, bulkQuest[2].ID).WillReturnRows(rows)
+			},
+			out: map[int64][]model.Taker{bulkQuest[1].ID: {{AdventurerID: adv.ID, Name: adv.Name, Rank: adv.Rank, Reward: 200000}}},
+		},
+		{
+			name: "success without quests",
+			mock: func() {},
+			out:  map[int64][]model.Taker{},
+		},
+		{
+			name:     "failed query",
+			questIDs: []int64{bulkQuest[1].ID, bulkQuest[2].ID},
+			mock: func() {
+				mock.ExpectQuery(query).WithArgs(bulkQuest[1].ID, bulkQuest[2].ID).WillReturnError(sql.ErrConnDone)
+			},
+			out:     map[int64][]model.Taker{},
+			wantErr: true,
+		},
+		{
+			name:     "failed scan query",
+			questIDs: []int64{bulkQuest[1].ID, bulkQuest[2].ID},
+			mock: func() {
+				rows := sqlmock.NewRows(columns).AddRow(bulkQuest[1].ID, nil, adv.Name, adv.Rank, 0)
+				mock.ExpectQuery(query).WithArgs(bulkQuest[1].ID, bulkQuest[2].ID).WillReturnRows(rows)
+			},
+			out:     map[int64][]model.Taker{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repository{
+				db: db,
+			}
+			tt.mock()
+			res, err := r.ListTakers(tt.questIDs)
+			assert.Equal(t, tt.out, res)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetOverdueQuests(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	query := regexp.QuoteMeta("SELECT quest_id, name, description, minimum_rank, reward_number, status, created_at, deadline, taken_at, min_members, max_members, rank_rule, members, created_by FROM quest WHERE (status IN ($1, $2) AND deadline < $3) OR (status = $2 AND taken_at < $4) ORDER BY quest_id")
+	now := deadline.Add(time.Hour)
+	tests := []struct {
+		name    string
+		mock    func()
+		out     []model.Quest
+		wantErr bool
+	}{
+		{
+			name: "success get overdue quests",
+			mock: func() {
+				rows := sqlmock.NewRows(questColumns).AddRow(questRow(bulkQuest[0])...).AddRow(questRow(bulkQuest[1])...)
+				mock.ExpectQuery(query).WithArgs(constant.AvailableQuest, constant.WorkingQuest, now, takenAt).WillReturnRows(rows)
+			},
+			out:     bulkQuest[0:2],
+			wantErr: false,
+		},
+		{
+			name: "failed query",
+			mock: func() {
+				mock.ExpectQuery(query).WithArgs(constant.AvailableQuest, constant.WorkingQuest, now, takenAt).WillReturnError(sql.ErrConnDone)
+			},
+			out:     []model.Quest{},
+			wantErr: true,
+		},
+		{
+			name: "failed scan query",
+			mock: func() {
+				row := questRow(bulkQuest[0])
+				row[6] = nil
+				rows := sqlmock.NewRows(questColumns).AddRow(row...)
+				mock.ExpectQuery(query).WithArgs(constant.AvailableQuest, constant.WorkingQuest, now, takenAt).WillReturnRows(rows)
+			},
+			out:     []model.Quest{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repository{
+				db: db,
+			}
+			tt.mock()
+			res, err := r.GetOverdueQuests(now, takenAt)
+			assert.Equal(t, tt.out, res)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestJoinQuest(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	query := regexp.QuoteMeta(`UPDATE quest SET members = members + 1,
+	status = CASE WHEN members + 1 >= min_members THEN $1 ELSE status END,
+	taken_at = CASE WHEN members + 1 = min_members THEN $2 ELSE taken_at END
+	WHERE quest_id = $3 AND status IN ($4, $1) AND members < max_members`)
+	quest := model.Quest{ID: bulkQuest[0].ID, TakenAt: &takenAt}
+	tests := []struct {
+		name      string
+		mock      func()
+		outJoined bool
+		wantErr   bool
+	}{
+		{
+			name: "success joined",
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(constant.WorkingQuest, takenAt, quest.ID, constant.AvailableQuest).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			outJoined: true,
+			wantErr:   false,
+		},
+		{
+			name: "party full",
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(constant.WorkingQuest, takenAt, quest.ID, constant.AvailableQuest).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			outJoined: false,
+			wantErr:   false,
+		},
+		{
+			name: "failed query",
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(constant.WorkingQuest, takenAt, quest.ID, constant.AvailableQuest).WillReturnError(sql.ErrConnDone)
+			},
+			outJoined: false,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repository{
+				db: db,
+			}
+			tt.mock()
+			joined, err := r.JoinQuest(quest)
+			assert.Equal(t, tt.outJoined, joined)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateTakenByReward(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	query := regexp.QuoteMeta("UPDATE taken_by SET reward = $1 WHERE quest_id = $2 AND adv_id = $3")
+	taken := model.TakenBy{QuestID: bulkQuest[1].ID, AdventurerID: adv.ID, Reward: 100000}
+	tests := []struct {
+		name    string
+		mock    func()
+		wantErr bool
+	}{
+		{
+			name: "success set reward",
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(taken.Reward, taken.QuestID, taken.AdventurerID).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed query",
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(taken.Reward, taken.QuestID, taken.AdventurerID).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repository{
+				db: db,
+			}
+			tt.mock()
+			err := r.UpdateTakenByReward(taken)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListQuests(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	filter := model.QuestFilter{
+		Statuses:  []int32{constant.AvailableQuest, constant.WorkingQuest},
+		MinReward: 100000,
+		MaxRank:   13,
+		Name:      "50%_off",
+		Sort:      constant.SortReward,
+		Desc:      true,
+		Limit:     20,
+		Offset:    40,
+	}
+	where := ` WHERE status IN ($1, $2) AND reward_number >= $3 AND minimum_rank <= $4 AND LOWER(name) LIKE $5 ESCAPE '\'`
+	countQuery := regexp.QuoteMeta("SELECT COUNT(*) FROM quest" + where)
+	query := regexp.QuoteMeta("SELECT quest_id, name, description, minimum_rank, reward_number, status, created_at, deadline, taken_at, min_members, max_members, rank_rule, members, created_by FROM quest" +
+		where + " ORDER BY reward_number DESC, quest_id DESC LIMIT $6 OFFSET $7")
+	args := []driver.Value{constant.AvailableQuest, constant.WorkingQuest, 100000, 13, `%50\%\_off%`}
+	tests := []struct {
+		name    string
+		mock    func()
+		out     []model.Quest
+		total   int
+		wantErr bool
+	}{
+		{
+			name: "success list quests",
+			mock: func() {
+				mock.ExpectQuery(countQuery).WithArgs(args...).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+				rows := sqlmock.NewRows(questColumns).AddRow(questRow(bulkQuest[0])...).AddRow(questRow(bulkQuest[1])...)
+				mock.ExpectQuery(query).WithArgs(append(args, 20, 40)...).WillReturnRows(rows)
+			},
+			out:     bulkQuest[0:2],
+			total:   42,
+			wantErr: false,
+		},
+		{
+			name: "failed count query",
+			mock: func() {
+				mock.ExpectQuery(countQuery).WithArgs(args...).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed query",
+			mock: func() {
+				mock.ExpectQuery(countQuery).WithArgs(args...).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+				mock.ExpectQuery(query).WithArgs(append(args, 20, 40)...).WillReturnError(sql.ErrConnDone)
+			},
+			out:     []model.Quest{},
+			total:   42,
+			wantErr: true,
+		},
+		{
+			name: "failed scan query",
+			mock: func() {
+				mock.ExpectQuery(countQuery).WithArgs(args...).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+				row := questRow(bulkQuest[0])
+				row[6] = nil
+				rows := sqlmock.NewRows(questColumns).AddRow(row...)
+				mock.ExpectQuery(query).WithArgs(append(args, 20, 40)...).WillReturnRows(rows)
+			},
+			out:     []model.Quest{},
+			total:   42,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repository{
+				db: db,
+			}
+			tt.mock()
+			res, total, err := r.ListQuests(filter)
+			assert.Equal(t, tt.out, res)
+			assert.Equal(t, tt.total, total)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListQuestsWithoutFilter(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM quest")).WithArgs().WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM quest ORDER BY created_at ASC, quest_id ASC LIMIT $1 OFFSET $2")).WithArgs(20, 0).
+		WillReturnRows(sqlmock.NewRows(questColumns))
+
+	r := &repository{db: db}
+	res, total, err := r.ListQuests(model.QuestFilter{Limit: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Quest{}, res)
+	assert.Equal(t, 0, total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateAssignment(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	questQuery := regexp.QuoteMeta("SELECT 1 FROM quest WHERE quest_id = $1")
+	query := regexp.QuoteMeta("INSERT INTO assignment(quest_id, adv_id, taken_at) VALUES($1, $2, $3)")
+	assignment := model.Assignment{QuestID: 1, AdventurerID: 1, TakenAt: takenAt}
+	tests := []struct {
+		name    string
+		mock    func()
+		wantErr bool
+	}{
+		{
+			name: "success create assignment",
+			mock: func() {
+				mock.ExpectQuery(questQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+				mock.ExpectExec(query).WithArgs(1, 1, takenAt).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed missing quest",
+			mock: func() {
+				mock.ExpectQuery(questQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"1"}))
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed exec",
+			mock: func() {
+				mock.ExpectQuery(questQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+				mock.ExpectExec(query).WithArgs(1, 1, takenAt).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repository{
+				db: db,
+			}
+			tt.mock()
+			err := r.CreateAssignment(assignment)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFinishAssignment(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	query := regexp.QuoteMeta("UPDATE assignment SET finished_at = $1, outcome = $2 WHERE quest_id = $3 AND adv_id = $4 AND outcome = ''")
+	assignment := model.Assignment{QuestID: 1, AdventurerID: 1, FinishedAt: &deadline, Outcome: constant.OutcomeCompleted}
+	tests := []struct {
+		name    string
+		mock    func()
+		wantErr bool
+	}{
+		{
+			name: "success finish assignment",
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(deadline, constant.OutcomeCompleted, 1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed exec",
+			mock: func() {
+				mock.ExpectExec(query).WithArgs(deadline, constant.OutcomeCompleted, 1, 1).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repository{
+				db: db,
+			}
+			tt.mock()
+			err := r.FinishAssignment(assignment)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListAssignments(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	countQuery := regexp.QuoteMeta("SELECT COUNT(*) FROM assignment WHERE quest_id = $1 AND adv_id = $2")
+	query := regexp.QuoteMeta("SELECT id, quest_id, adv_id, taken_at, finished_at, outcome FROM assignment WHERE quest_id = $1 AND adv_id = $2 ORDER BY taken_at DESC, id DESC LIMIT $3 OFFSET $4")
+	columns := []string{"id", "quest_id", "adv_id", "taken_at", "finished_at", "outcome"}
+	filter := model.AssignmentFilter{QuestID: 2, AdventurerID: 1, Limit: 20, Offset: 0}
+	assignments := []model.Assignment{
+		{ID: 2, QuestID: 2, AdventurerID: 1, TakenAt: deadline},
+		{ID: 1, QuestID: 2, AdventurerID: 1, TakenAt: takenAt, FinishedAt: &deadline, Outcome: constant.OutcomeFailed},
+	}
+	tests := []struct {
+		name    string
+		mock    func()
+		out     []model.Assignment
+		total   int
+		wantErr bool
+	}{
+		{
+			name: "success list assignments",
+			mock: func() {
+				mock.ExpectQuery(countQuery).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				rows := sqlmock.NewRows(columns).
+					AddRow(2, 2, 1, deadline, nil, "").
+					AddRow(1, 2, 1, takenAt, deadline, constant.OutcomeFailed)
+				mock.ExpectQuery(query).WithArgs(2, 1, 20, 0).WillReturnRows(rows)
+			},
+			out:     assignments,
+			total:   2,
+			wantErr: false,
+		},
+		{
+			name: "failed count query",
+			mock: func() {
+				mock.ExpectQuery(countQuery).WithArgs(2, 1).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed query",
+			mock: func() {
+				mock.ExpectQuery(countQuery).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectQuery(query).WithArgs(2, 1, 20, 0).WillReturnError(sql.ErrConnDone)
+			},
+			out:     []model.Assignment{},
+			total:   2,
+			wantErr: true,
+		},
+		{
+			name: "failed scan query",
+			mock: func() {
+				mock.ExpectQuery(countQuery).WithArgs(2, 1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				rows := sqlmock.NewRows(columns).AddRow(2, 2, 1, nil, nil, "")
+				mock.ExpectQuery(query).WithArgs(2, 1, 20, 0).WillReturnRows(rows)
+			},
+			out:     []model.Assignment{},
+			total:   2,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &repository{
+				db: db,
+			}
+			tt.mock()
+			res, total, err := r.ListAssignments(filter)
+			assert.Equal(t, tt.out, res)
+			assert.Equal(t, tt.total, total)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
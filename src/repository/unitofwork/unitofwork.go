@@ -0,0 +1,81 @@
+package unitofwork
+
+import (
+	"database/sql"
+
+	"github.com/arfaghifari/guild-board/src/database"
+	"github.com/arfaghifari/guild-board/src/database/memory"
+	repoAdv "github.com/arfaghifari/guild-board/src/repository/adventurer"
+	repoLedger "github.com/arfaghifari/guild-board/src/repository/ledger"
+	repoQuest "github.com/arfaghifari/guild-board/src/repository/quest"
+)
+
+// Repositories are bound to a single transaction; they must not be used after
+// the function given to Do returns.
+type Repositories struct {
+	Quest      repoQuest.Repository
+	Adventurer repoAdv.Repository
+	Ledger     repoLedger.Repository
+}
+
+type UnitOfWork interface {
+	// Do runs fn in one transaction. It commits when fn returns nil and rolls
+	// back when fn returns an error or panics.
+	Do(func(Repositories) error) error
+}
+
+type unitOfWork struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+func NewUnitOfWork(db *sql.DB, dialect database.Dialect) (UnitOfWork, error) {
+	if db == nil {
+		return nil, database.ErrNilDB
+	}
+
+	return &unitOfWork{db, dialect}, nil
+}
+
+func (u *unitOfWork) Do(fn func(Repositories) error) (err error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	repos := Repositories{
+		Quest:      repoQuest.NewTxRepository(tx, u.dialect),
+		Adventurer: repoAdv.NewTxRepository(tx, u.dialect),
+		Ledger:     repoLedger.NewTxRepository(tx, u.dialect),
+	}
+	if err = fn(repos); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+type memoryUnitOfWork struct {
+	store *memory.Store
+}
+
+// NewMemoryUnitOfWork runs units of work as memory.Store transactions.
+func NewMemoryUnitOfWork(store *memory.Store) UnitOfWork {
+	return &memoryUnitOfWork{store}
+}
+
+func (u *memoryUnitOfWork) Do(fn func(Repositories) error) error {
+	return u.store.Transaction(func(tx *memory.Store) error {
+		return fn(Repositories{
+			Quest:      repoQuest.NewMemoryRepository(tx),
+			Adventurer: repoAdv.NewMemoryRepository(tx),
+			Ledger:     repoLedger.NewMemoryRepository(tx),
+		})
+	})
+}
@@ -0,0 +1,223 @@
+package unitofwork
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/database"
+	"github.com/arfaghifari/guild-board/src/database/databasetest"
+	"github.com/arfaghifari/guild-board/src/database/memory"
+	modelLedger "github.com/arfaghifari/guild-board/src/model/ledger"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+	repoLedger "github.com/arfaghifari/guild-board/src/repository/ledger"
+	repoQuest "github.com/arfaghifari/guild-board/src/repository/quest"
+	"github.com/stretchr/testify/assert"
+)
+
+func NewMock() (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		log.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	return db, mock
+}
+
+func TestNewUnitOfWork(t *testing.T) {
+	db, _ := NewMock()
+	defer db.Close()
+
+	res, err := NewUnitOfWork(db, database.Postgres)
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+
+	res, err = NewUnitOfWork(nil, database.Postgres)
+	assert.Equal(t, database.ErrNilDB, err)
+	assert.Nil(t, res)
+}
+
+func TestDo(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	query := regexp.QuoteMeta("UPDATE quest SET status = $1 WHERE quest_id = $2 AND status = $3")
+	quest := model.Quest{ID: 1, Status: constant.WorkingQuest}
+	tests := []struct {
+		name    string
+		mock    func()
+		fn      func(Repositories) error
+		wantErr bool
+	}{
+		{
+			name: "commit on success",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(query).WithArgs(constant.WorkingQuest, 1, constant.AvailableQuest).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			fn: func(repos Repositories) error {
+				_, err := repos.Quest.UpdateQuestStatusIf(quest, constant.AvailableQuest)
+				return err
+			},
+			wantErr: false,
+		},
+		{
+			name: "rollback on error",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(query).WithArgs(constant.WorkingQuest, 1, constant.AvailableQuest).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectRollback()
+			},
+			fn: func(repos Repositories) error {
+				if _, err := repos.Quest.UpdateQuestStatusIf(quest, constant.AvailableQuest); err != nil {
+					return err
+				}
+				return errors.New("any error")
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed begin",
+			mock: func() {
+				mock.ExpectBegin().WillReturnError(sql.ErrConnDone)
+			},
+			fn: func(repos Repositories) error {
+				return nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed commit",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectCommit().WillReturnError(sql.ErrConnDone)
+			},
+			fn: func(repos Repositories) error {
+				return nil
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &unitOfWork{
+				db: db,
+			}
+			tt.mock()
+			err := u.Do(tt.fn)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDoPanic(t *testing.T) {
+	db, mock := NewMock()
+	defer func() {
+		db.Close()
+	}()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	u := &unitOfWork{
+		db: db,
+	}
+	assert.Panics(t, func() {
+		u.Do(func(Repositories) error {
+			panic("boom")
+		})
+	})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// escrow is what posting the second quest would cost, rolled back with it.
+var escrow = modelLedger.Transaction{
+	QuestID: 2,
+	Kind:    constant.LedgerEscrow,
+	Entries: []modelLedger.Entry{
+		{Account: modelLedger.Guild, Amount: -200000},
+		{Account: modelLedger.EscrowAccount(2), Amount: 200000},
+	},
+}
+
+func TestMemoryDo(t *testing.T) {
+	store := memory.NewStore()
+	u := NewMemoryUnitOfWork(store)
+	quest := model.Quest{Name: "menyelamatkan kucing", MinimumRank: 11, RewardNumber: 200000}
+
+	err := u.Do(func(repos Repositories) error {
+		_, err := repos.Quest.CreateQuest(quest)
+		return err
+	})
+	assert.NoError(t, err)
+
+	err = u.Do(func(repos Repositories) error {
+		if _, err := repos.Quest.CreateQuest(quest); err != nil {
+			return err
+		}
+		if _, err := repos.Ledger.PostTransaction(escrow); err != nil {
+			return err
+		}
+		return errors.New("any error")
+	})
+	assert.Error(t, err)
+
+	assert.Panics(t, func() {
+		u.Do(func(repos Repositories) error {
+			repos.Quest.CreateQuest(quest)
+			panic("boom")
+		})
+	})
+
+	quests, err := repoQuest.NewMemoryRepository(store).GetQuestsByStatus(constant.AvailableQuest)
+	assert.NoError(t, err)
+	assert.Len(t, quests, 1)
+	balance, err := repoLedger.NewMemoryRepository(store).GetBalance(modelLedger.EscrowAccount(2))
+	assert.NoError(t, err)
+	assert.Zero(t, balance.Balance)
+}
+
+func TestSQLiteDo(t *testing.T) {
+	u := &unitOfWork{databasetest.NewSQLite(t), database.SQLite}
+	quest := model.Quest{Name: "menyelamatkan kucing", MinimumRank: 11, RewardNumber: 200000}
+
+	err := u.Do(func(repos Repositories) error {
+		_, err := repos.Quest.CreateQuest(quest)
+		return err
+	})
+	assert.NoError(t, err)
+
+	err = u.Do(func(repos Repositories) error {
+		if _, err := repos.Quest.CreateQuest(quest); err != nil {
+			return err
+		}
+		if _, err := repos.Ledger.PostTransaction(escrow); err != nil {
+			return err
+		}
+		return errors.New("any error")
+	})
+	assert.Error(t, err)
+
+	var quests []model.GetQuestByStatus
+	var balance modelLedger.Balance
+	err = u.Do(func(repos Repositories) (err error) {
+		if quests, err = repos.Quest.GetQuestsByStatus(constant.AvailableQuest); err != nil {
+			return
+		}
+		balance, err = repos.Ledger.GetBalance(modelLedger.EscrowAccount(2))
+		return
+	})
+	assert.NoError(t, err)
+	assert.Len(t, quests, 1)
+	assert.Zero(t, balance.Balance)
+}
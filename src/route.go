@@ -1,42 +1,303 @@
 package src
 
 import (
+	"database/sql"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
-	"time"
 
+	"github.com/arfaghifari/guild-board/src/clock"
+	"github.com/arfaghifari/guild-board/src/config"
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/database"
+	"github.com/arfaghifari/guild-board/src/database/memory"
 	advHandlers "github.com/arfaghifari/guild-board/src/handlers/http/adventurer"
+	"github.com/arfaghifari/guild-board/src/handlers/http/auth"
+	giverHandlers "github.com/arfaghifari/guild-board/src/handlers/http/giver"
+	ledgerHandlers "github.com/arfaghifari/guild-board/src/handlers/http/ledger"
+	"github.com/arfaghifari/guild-board/src/handlers/http/openapi"
 	qstHandlers "github.com/arfaghifari/guild-board/src/handlers/http/quest"
+	"github.com/arfaghifari/guild-board/src/handlers/http/response"
+	"github.com/arfaghifari/guild-board/src/logger"
+	repoAdv "github.com/arfaghifari/guild-board/src/repository/adventurer"
+	repoAuth "github.com/arfaghifari/guild-board/src/repository/auth"
+	repoGiver "github.com/arfaghifari/guild-board/src/repository/giver"
+	repoLedger "github.com/arfaghifari/guild-board/src/repository/ledger"
+	repoQuest "github.com/arfaghifari/guild-board/src/repository/quest"
+	"github.com/arfaghifari/guild-board/src/repository/unitofwork"
+	"github.com/arfaghifari/guild-board/src/scheduler"
 	server "github.com/arfaghifari/guild-board/src/server"
+	advUsecase "github.com/arfaghifari/guild-board/src/usecase/adventurer"
+	authUsecase "github.com/arfaghifari/guild-board/src/usecase/auth"
+	giverUsecase "github.com/arfaghifari/guild-board/src/usecase/giver"
+	ledgerUsecase "github.com/arfaghifari/guild-board/src/usecase/ledger"
+	qstUsecase "github.com/arfaghifari/guild-board/src/usecase/quest"
 	"github.com/gorilla/mux"
 )
 
+// Main is the composition root: it builds every dependency from the config
+// and stops the service if any of them cannot be built.
 func Main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("[Config] unable to load config, err: " + err.Error())
+	}
+	appLogger, err := logger.NewLogger(cfg.Log.Level)
+	if err != nil {
+		log.Fatal("[Config] unable to build logger, err: " + err.Error())
+	}
 
-	// Init serve HTTP
-	router := mux.NewRouter()
-	questHandlers, _ := qstHandlers.NewHandlers()
-	adventurerHandlers, _ := advHandlers.NewHandlers()
-	// routes http
-	router.HandleFunc("/hello", qstHandlers.GetHello).Methods(http.MethodGet)
-
-	router.HandleFunc("/quest-status", questHandlers.GetQuestByStatus).Methods(http.MethodGet)
-	router.HandleFunc("/quest", questHandlers.CreateQuest).Methods(http.MethodPost)
-	router.HandleFunc("/quest", questHandlers.DeleteQuest).Methods(http.MethodDelete)
-	router.HandleFunc("/quest-rank", questHandlers.UpdateQuestRank).Methods(http.MethodPatch)
-	router.HandleFunc("/quest-reward", questHandlers.UpdateQuestReward).Methods(http.MethodPatch)
+	appLogger.Infof("[Config] log level %s, auto migrate %t", cfg.Log.Level, cfg.Features.AutoMigrate)
 
-	router.HandleFunc("/adventurer", adventurerHandlers.CreateAdventurer).Methods(http.MethodPost)
-	router.HandleFunc("/adventurer", adventurerHandlers.GetAdventurer).Methods(http.MethodGet)
-	router.HandleFunc("/adventurer-rank", adventurerHandlers.UpdateAdventurerRank).Methods(http.MethodPatch)
+	repos, closer, err := newRepositories(cfg)
+	if err != nil {
+		log.Fatal("[Database] unable to connect, err: " + err.Error())
+	}
+	defer closer.Close()
 
-	router.HandleFunc("/quest-active-adv", questHandlers.GetQuestActiveAdventurer).Methods(http.MethodGet)
-	router.HandleFunc("/take-quest", questHandlers.TakeQuest).Methods(http.MethodPost)
-	router.HandleFunc("/done-quest", questHandlers.ReportQuest).Methods(http.MethodPost)
+	usecases, err := newUsecases(repos, clock.NewClock(), cfg.Progression, cfg.Commission)
+	if err != nil {
+		log.Fatal("[Usecase] unable to build usecases, err: " + err.Error())
+	}
+	if cfg.Database.Driver == config.DriverMemory {
+		// the first staff key of the memory store, which issues the others
+		// through POST /v2/api-keys
+		token, _, err := usecases.auth.IssueAPIKey("memory store", constant.RoleStaff, 0)
+		if err != nil {
+			log.Fatal("[Auth] unable to issue a staff key, err: " + err.Error())
+		}
+		// a live credential: printed once on stdout like the apikey command,
+		// never written to the log
+		fmt.Printf("staff api key of the memory store: %s\n", token)
+	}
+	router, err := newRouter(cfg, usecases, appLogger)
+	if err != nil {
+		log.Fatal("[Router] unable to build handlers, err: " + err.Error())
+	}
+	expiry, err := newScheduler(cfg.Scheduler, usecases.quest, appLogger)
+	if err != nil {
+		log.Fatal("[Scheduler] unable to build scheduler, err: " + err.Error())
+	}
+	if expiry != nil {
+		expiry.Start()
+		defer expiry.Stop()
+	}
 
 	serverConfig := server.Config{
-		WriteTimeout: 5 * time.Second,
-		ReadTimeout:  5 * time.Second,
-		Port:         8000,
+		WriteTimeout:    cfg.HTTP.WriteTimeout,
+		ReadTimeout:     cfg.HTTP.ReadTimeout,
+		Port:            cfg.HTTP.Port,
+		ShutdownTimeout: cfg.HTTP.ShutdownTimeout,
 	}
+	appLogger.Debugf("[Config] http %+v", cfg.HTTP)
 	server.Serve(serverConfig, router)
 }
+
+type repositories struct {
+	quest      repoQuest.Repository
+	adventurer repoAdv.Repository
+	auth       repoAuth.Repository
+	giver      repoGiver.Repository
+	ledger     repoLedger.Repository
+	uow        unitofwork.UnitOfWork
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
+// newRepositories opens the configured store and applies pending migrations
+// when enabled. The returned closer releases the database connection.
+func newRepositories(cfg config.Config) (repositories, io.Closer, error) {
+	if cfg.Database.Driver == config.DriverMemory {
+		store := memory.NewStore()
+		return repositories{
+			quest:      repoQuest.NewMemoryRepository(store),
+			adventurer: repoAdv.NewMemoryRepository(store),
+			auth:       repoAuth.NewMemoryRepository(store),
+			giver:      repoGiver.NewMemoryRepository(store),
+			ledger:     repoLedger.NewMemoryRepository(store),
+			uow:        unitofwork.NewMemoryUnitOfWork(store),
+		}, nopCloser{}, nil
+	}
+
+	db, err := database.Open(cfg.Database)
+	if err != nil {
+		return repositories{}, nil, err
+	}
+	repos, err := newSQLRepositories(db, database.DialectOf(cfg.Database.Driver), cfg.Features.AutoMigrate)
+	if err != nil {
+		db.Close()
+		return repositories{}, nil, err
+	}
+	return repos, db, nil
+}
+
+func newSQLRepositories(db *sql.DB, dialect database.Dialect, autoMigrate bool) (repos repositories, err error) {
+	// Apply pending schema migrations before wiring the routes
+	if autoMigrate {
+		if err = migrateUp(db, dialect); err != nil {
+			return
+		}
+	}
+	if repos.quest, err = repoQuest.NewRepository(db, dialect); err != nil {
+		return
+	}
+	if repos.adventurer, err = repoAdv.NewRepository(db, dialect); err != nil {
+		return
+	}
+	if repos.auth, err = repoAuth.NewRepository(db, dialect); err != nil {
+		return
+	}
+	if repos.giver, err = repoGiver.NewRepository(db, dialect); err != nil {
+		return
+	}
+	if repos.ledger, err = repoLedger.NewRepository(db, dialect); err != nil {
+		return
+	}
+	repos.uow, err = unitofwork.NewUnitOfWork(db, dialect)
+	return
+}
+
+type usecases struct {
+	quest      qstUsecase.Usecase
+	adventurer advUsecase.Usecase
+	auth       authUsecase.Usecase
+	giver      giverUsecase.Usecase
+	ledger     ledgerUsecase.Usecase
+}
+
+func newUsecases(repos repositories, clk clock.Clock, progression config.Progression, commission config.Commission) (u usecases, err error) {
+	policy, err := advUsecase.NewPolicy(progression)
+	if err != nil {
+		return
+	}
+	fees, err := qstUsecase.NewFeePolicy(commission)
+	if err != nil {
+		return
+	}
+	if u.quest, err = qstUsecase.NewUsecase(repos.quest, repos.adventurer, repos.uow, clk, policy, fees); err != nil {
+		return
+	}
+	if u.adventurer, err = advUsecase.NewUsecase(repos.adventurer, policy); err != nil {
+		return
+	}
+	if u.auth, err = authUsecase.NewUsecase(repos.auth, repos.adventurer, repos.giver, clk); err != nil {
+		return
+	}
+	if u.giver, err = giverUsecase.NewUsecase(repos.giver, u.quest, clk); err != nil {
+		return
+	}
+	u.ledger, err = ledgerUsecase.NewUsecase(repos.ledger, repos.quest, repos.adventurer, repos.giver)
+	return
+}
+
+// newScheduler builds the run that expires overdue quests, or returns nil when
+// the interval is zero.
+func newScheduler(cfg config.Scheduler, questUsecase qstUsecase.Usecase, appLogger logger.Logger) (scheduler.Scheduler, error) {
+	if cfg.Interval == 0 {
+		appLogger.Infof("[Scheduler] quest expiry is disabled")
+		return nil, nil
+	}
+	return scheduler.NewScheduler(cfg.Interval, func() error {
+		expired, released, err := questUsecase.ExpireOverdueQuests(cfg.WorkingTimeout)
+		if expired > 0 || released > 0 {
+			appLogger.Infof("[Scheduler] expired %d quests, released %d quests", expired, released)
+		}
+		return err
+	}, appLogger)
+}
+
+func newRouter(cfg config.Config, u usecases, appLogger logger.Logger) (*mux.Router, error) {
+	questHandlers, err := qstHandlers.NewHandlers(u.quest, appLogger, cfg.HTTP)
+	if err != nil {
+		return nil, err
+	}
+	adventurerHandlers, err := advHandlers.NewHandlers(u.adventurer, appLogger, cfg.HTTP)
+	if err != nil {
+		return nil, err
+	}
+	givers, err := giverHandlers.NewHandlers(u.giver, appLogger, cfg.HTTP)
+	if err != nil {
+		return nil, err
+	}
+	ledger, err := ledgerHandlers.NewHandlers(u.ledger, appLogger)
+	if err != nil {
+		return nil, err
+	}
+	apiKeys, err := auth.NewHandlers(u.auth, appLogger, cfg.HTTP)
+	if err != nil {
+		return nil, err
+	}
+
+	handle := func(h response.Handler) http.HandlerFunc {
+		return response.Handle(appLogger, h)
+	}
+
+	// routes http
+	root := mux.NewRouter()
+	root.Use(response.Recover(appLogger))
+	root.HandleFunc("/hello", qstHandlers.GetHello).Methods(http.MethodGet)
+	root.HandleFunc(specPath, openapi.Serve(newSpec())).Methods(http.MethodGet)
+	root.HandleFunc(docsPath, openapi.UI(apiTitle, specPath)).Methods(http.MethodGet)
+
+	// every other route needs an api key
+	router := root.NewRoute().Subrouter()
+	router.Use(auth.Middleware(u.auth, appLogger))
+	router.HandleFunc("/quest-status", handle(questHandlers.GetQuestByStatus)).Methods(http.MethodGet)
+	router.HandleFunc("/quest", handle(questHandlers.CreateQuest)).Methods(http.MethodPost)
+	router.HandleFunc("/quest", handle(questHandlers.DeleteQuest)).Methods(http.MethodDelete)
+	router.HandleFunc("/quest-rank", handle(questHandlers.UpdateQuestRank)).Methods(http.MethodPatch)
+	router.HandleFunc("/quest-reward", handle(questHandlers.UpdateQuestReward)).Methods(http.MethodPatch)
+	router.HandleFunc("/quest/{id}/cancel", handle(questHandlers.CancelQuest)).Methods(http.MethodPost)
+
+	router.HandleFunc("/adventurer", handle(adventurerHandlers.CreateAdventurer)).Methods(http.MethodPost)
+	router.HandleFunc("/adventurer", handle(adventurerHandlers.GetAdventurer)).Methods(http.MethodGet)
+	router.HandleFunc("/adventurer-rank", handle(adventurerHandlers.UpdateAdventurerRank)).Methods(http.MethodPatch)
+	router.HandleFunc("/adventurer/{id}/progress", handle(adventurerHandlers.GetProgress)).Methods(http.MethodGet)
+
+	router.HandleFunc("/quest-active-adv", handle(questHandlers.GetQuestActiveAdventurer)).Methods(http.MethodGet)
+	router.HandleFunc("/take-quest", handle(questHandlers.TakeQuest)).Methods(http.MethodPost)
+	router.HandleFunc("/done-quest", handle(questHandlers.ReportQuest)).Methods(http.MethodPost)
+
+	registerV2(router.PathPrefix("/v2").Subrouter(), handle, questHandlers, adventurerHandlers, givers, ledger, apiKeys)
+
+	return root, nil
+}
+
+// registerV2 adds the resource oriented routes. The routes above are kept for
+// existing clients and reach the same usecases.
+func registerV2(router *mux.Router, handle func(response.Handler) http.HandlerFunc, questHandlers qstHandlers.Handlers,
+	adventurerHandlers advHandlers.Handlers, givers giverHandlers.Handlers, ledger ledgerHandlers.Handlers, apiKeys auth.Handlers) {
+	router.HandleFunc("/quests", handle(questHandlers.ListQuests)).Methods(http.MethodGet)
+	router.HandleFunc("/quests", handle(questHandlers.CreateQuest)).Methods(http.MethodPost)
+	router.HandleFunc("/quests/{id}", handle(questHandlers.GetQuest)).Methods(http.MethodGet)
+	router.HandleFunc("/quests/{id}", handle(questHandlers.PatchQuest)).Methods(http.MethodPatch)
+	router.HandleFunc("/quests/{id}", handle(questHandlers.RemoveQuest)).Methods(http.MethodDelete)
+	router.HandleFunc("/quests/{id}/takers", handle(questHandlers.GetTakers)).Methods(http.MethodGet)
+	router.HandleFunc("/quests/{id}/takers", handle(questHandlers.AddTaker)).Methods(http.MethodPost)
+	router.HandleFunc("/quests/{id}/report", handle(questHandlers.SubmitReport)).Methods(http.MethodPost)
+	router.HandleFunc("/quests/{id}/cancel", handle(questHandlers.CancelQuest)).Methods(http.MethodPost)
+	router.HandleFunc("/quests/{id}/history", handle(questHandlers.GetQuestHistory)).Methods(http.MethodGet)
+	router.HandleFunc("/quests/{id}/escrow", handle(ledger.GetQuestEscrow)).Methods(http.MethodGet)
+	router.HandleFunc("/quests/{id}/payout", handle(ledger.GetQuestPayout)).Methods(http.MethodGet)
+
+	router.HandleFunc("/adventurers", handle(adventurerHandlers.ListAdventurers)).Methods(http.MethodGet)
+	router.HandleFunc("/adventurers", handle(adventurerHandlers.CreateAdventurer)).Methods(http.MethodPost)
+	router.HandleFunc("/adventurers/{id}", handle(adventurerHandlers.GetAdventurerByID)).Methods(http.MethodGet)
+	router.HandleFunc("/adventurers/{id}", handle(adventurerHandlers.PatchAdventurer)).Methods(http.MethodPatch)
+	router.HandleFunc("/adventurers/{id}/quests", handle(questHandlers.GetAdventurerQuests)).Methods(http.MethodGet)
+	router.HandleFunc("/adventurers/{id}/progress", handle(adventurerHandlers.GetProgress)).Methods(http.MethodGet)
+	router.HandleFunc("/adventurers/{id}/history", handle(questHandlers.GetAdventurerHistory)).Methods(http.MethodGet)
+	router.HandleFunc("/adventurers/{id}/balance", handle(ledger.GetAdventurerBalance)).Methods(http.MethodGet)
+
+	router.HandleFunc("/givers", handle(givers.CreateGiver)).Methods(http.MethodPost)
+	router.HandleFunc("/givers/{id}", handle(givers.GetGiver)).Methods(http.MethodGet)
+	router.HandleFunc("/givers/{id}/quests", handle(givers.ListGiverQuests)).Methods(http.MethodGet)
+	router.HandleFunc("/givers/{id}/balance", handle(ledger.GetGiverBalance)).Methods(http.MethodGet)
+
+	router.HandleFunc("/guild/balance", handle(ledger.GetGuildBalance)).Methods(http.MethodGet)
+
+	router.HandleFunc("/api-keys", handle(apiKeys.CreateAPIKey)).Methods(http.MethodPost)
+}
@@ -0,0 +1,430 @@
+package src
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/arfaghifari/guild-board/src/clock"
+	"github.com/arfaghifari/guild-board/src/config"
+	"github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/handlers/http/auth"
+	"github.com/arfaghifari/guild-board/src/logger"
+	modelQuest "github.com/arfaghifari/guild-board/src/model/quest"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRepositories(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Driver = config.DriverMemory
+	repos, closer, err := newRepositories(cfg)
+	assert.NoError(t, err)
+	assert.NoError(t, closer.Close())
+	assert.NotNil(t, repos.quest)
+	assert.NotNil(t, repos.adventurer)
+	assert.NotNil(t, repos.auth)
+	assert.NotNil(t, repos.giver)
+	assert.NotNil(t, repos.ledger)
+	assert.NotNil(t, repos.uow)
+
+	cfg.Database.Driver = config.DriverSQLite
+	cfg.Database.DSN = "file:" + t.TempDir() + "/missing/guild.db"
+	_, _, err = newRepositories(cfg)
+	assert.Error(t, err)
+}
+
+func TestNewRouter(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Driver = config.DriverMemory
+	repos, _, _ := newRepositories(cfg)
+	appLogger, _ := logger.NewLogger("error")
+
+	u, err := newUsecases(repos, clock.NewClock(), cfg.Progression, cfg.Commission)
+	assert.NoError(t, err)
+	router, err := newRouter(cfg, u, appLogger)
+	assert.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/adventurer", strings.NewReader(`{"name":"andi","rank":11}`))
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.NotEmpty(t, recorder.Header().Get("WWW-Authenticate"))
+
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(http.MethodPost, "/adventurer", strings.NewReader(`{"name":"andi","rank":11}`))
+	request.Header.Set("Authorization", "Bearer gb_unknown")
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	request = httptest.NewRequest(http.MethodPost, "/adventurer", strings.NewReader(`{"name":"andi","rank":11}`))
+	router.ServeHTTP(recorder, authorized(t, u, map[int64]string{}, request, 0))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/hello", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	_, err = newRouter(cfg, usecases{}, appLogger)
+	assert.Error(t, err)
+}
+
+// authorized signs request with a key of the adventurer advID, or of the staff
+// when advID is 0. Keys are issued on first use, once earlier requests have
+// created the adventurer.
+func authorized(t *testing.T, u usecases, keys map[int64]string, request *http.Request, advID int64) *http.Request {
+	key, ok := keys[advID]
+	if !ok {
+		role := constant.RoleStaff
+		if advID > 0 {
+			role = constant.RoleAdventurer
+		}
+		var err error
+		if key, _, err = u.auth.IssueAPIKey("test", role, advID); err != nil {
+			t.Fatal(err)
+		}
+		keys[advID] = key
+	}
+	request.Header.Set("Authorization", "Bearer "+key)
+	return request
+}
+
+func TestGiverRoutes(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Driver = config.DriverMemory
+	repos, _, _ := newRepositories(cfg)
+	appLogger, _ := logger.NewLogger("error")
+	u, _ := newUsecases(repos, clock.NewClock(), cfg.Progression, cfg.Commission)
+	router, err := newRouter(cfg, u, appLogger)
+	assert.NoError(t, err)
+
+	const giver = -1
+	requests := []struct {
+		method, path, body string
+		wantStatusCode     int
+		caller             int64 // adventurer id, 0 for the staff or giver for quest giver 1
+	}{
+		{http.MethodPost, "/v2/givers", `{"name":"mayor of riverwood"}`, http.StatusOK, 0},
+		{http.MethodPost, "/v2/givers", `{"name":"merchant guild"}`, http.StatusForbidden, giver},
+		{http.MethodPost, "/v2/adventurers", `{"name":"andi","rank":11}`, http.StatusOK, 0},
+		{http.MethodPost, "/v2/quests", `{"name":"menyelamatkan kucing","minimum_rank":11,"reward_number":200000}`, http.StatusOK, giver},
+		{http.MethodPost, "/v2/quests", `{"name":"membersihkan selokan","minimum_rank":11,"reward_number":100000}`, http.StatusOK, 0},
+		{http.MethodPost, "/v2/quests/1/takers", `{"adv_id":1}`, http.StatusOK, 1},
+		{http.MethodPatch, "/v2/quests/1", `{"reward_number":300000}`, http.StatusOK, giver},
+		{http.MethodPatch, "/v2/quests/2", `{"reward_number":300000}`, http.StatusForbidden, giver},
+		{http.MethodPost, "/v2/quests/2/cancel", ``, http.StatusForbidden, giver},
+		{http.MethodDelete, "/v2/quests/1", ``, http.StatusForbidden, giver},
+		{http.MethodGet, "/v2/givers/1", ``, http.StatusOK, 1},
+		{http.MethodGet, "/v2/givers/2", ``, http.StatusNotFound, 0},
+		{http.MethodGet, "/v2/givers/1/quests?status=1", ``, http.StatusOK, giver},
+		{http.MethodGet, "/v2/givers/2/quests", ``, http.StatusNotFound, 0},
+	}
+	keys := map[int64]string{}
+	for _, req := range requests {
+		request := httptest.NewRequest(req.method, req.path, strings.NewReader(req.body))
+		if req.caller == giver {
+			if _, ok := keys[giver]; !ok {
+				if keys[giver], _, err = u.auth.IssueAPIKey("mayor", constant.RoleGiver, 1); err != nil {
+					t.Fatal(err)
+				}
+			}
+			request.Header.Set("Authorization", "Bearer "+keys[giver])
+		} else {
+			request = authorized(t, u, keys, request, req.caller)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, req.wantStatusCode, recorder.Code, req.method+" "+req.path)
+	}
+
+	posted, total, err := u.giver.ListGiverQuests(1, modelQuest.QuestFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	if assert.Len(t, posted, 1) {
+		assert.Equal(t, int64(1), posted[0].CreatedBy)
+		assert.Equal(t, int32(300000), posted[0].RewardNumber)
+		assert.Equal(t, int32(constant.WorkingQuest), posted[0].Status)
+		assert.Equal(t, []modelQuest.Taker{{AdventurerID: 1, Name: "andi", Rank: 11}}, posted[0].Takers)
+	}
+}
+
+// TestAPIKeyRoutes runs a quest from posting to payout on the memory store with
+// keys handed out by POST /v2/api-keys, starting from a single staff key.
+func TestAPIKeyRoutes(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Driver = config.DriverMemory
+	repos, _, _ := newRepositories(cfg)
+	appLogger, _ := logger.NewLogger("error")
+	u, _ := newUsecases(repos, clock.NewClock(), cfg.Progression, cfg.Commission)
+	router, err := newRouter(cfg, u, appLogger)
+	assert.NoError(t, err)
+
+	staffKey, _, err := u.auth.IssueAPIKey("memory store", constant.RoleStaff, 0)
+	assert.NoError(t, err)
+	keys := map[string]string{"staff": staffKey}
+	requests := []struct {
+		method, path, body string
+		wantStatusCode     int
+		caller             string // name of the key to send, or none
+		issues             string // name the issued key is kept under
+	}{
+		{http.MethodPost, "/v2/givers", `{"name":"mayor of riverwood"}`, http.StatusOK, "staff", ""},
+		{http.MethodPost, "/v2/adventurers", `{"name":"andi","rank":11}`, http.StatusOK, "staff", ""},
+		{http.MethodPost, "/v2/api-keys", `{"name":"mayor","role":"quest_giver","giver_id":1}`, http.StatusOK, "staff", "giver"},
+		{http.MethodPost, "/v2/api-keys", `{"name":"andi","role":"adventurer","adv_id":1}`, http.StatusOK, "staff", "andi"},
+		{http.MethodPost, "/v2/api-keys", `{"name":"andi","role":"adventurer","adv_id":2}`, http.StatusNotFound, "staff", ""},
+		{http.MethodPost, "/v2/api-keys", `{"name":"andi","role":"adventurer","giver_id":1}`, http.StatusUnprocessableEntity, "staff", ""},
+		{http.MethodPost, "/v2/api-keys", `{"name":"desk","role":"staff"}`, http.StatusForbidden, "andi", ""},
+		{http.MethodPost, "/v2/api-keys", `{"name":"desk","role":"staff"}`, http.StatusUnauthorized, "", ""},
+		{http.MethodPost, "/v2/quests", `{"name":"menyelamatkan kucing","minimum_rank":11,"reward_number":200000}`, http.StatusOK, "giver", ""},
+		{http.MethodPost, "/v2/quests/1/takers", `{}`, http.StatusOK, "andi", ""},
+		{http.MethodPost, "/v2/quests/1/report", `{"is_completed":true}`, http.StatusOK, "andi", ""},
+		{http.MethodGet, "/v2/quests/1/payout", ``, http.StatusOK, "andi", ""},
+	}
+	for _, req := range requests {
+		request := httptest.NewRequest(req.method, req.path, strings.NewReader(req.body))
+		if req.caller != "" {
+			request.Header.Set("Authorization", "Bearer "+keys[req.caller])
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, req.wantStatusCode, recorder.Code, req.method+" "+req.path+" "+req.body)
+		if req.issues != "" {
+			var resp auth.APIKeyResponse
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			keys[req.issues] = resp.Data.Key
+		}
+	}
+
+	quest, err := u.quest.GetQuest(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(constant.CompletedQuest), quest.Status)
+}
+
+func TestSpecCoversRoutes(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Driver = config.DriverMemory
+	repos, _, _ := newRepositories(cfg)
+	appLogger, _ := logger.NewLogger("error")
+	u, _ := newUsecases(repos, clock.NewClock(), cfg.Progression, cfg.Commission)
+	router, err := newRouter(cfg, u, appLogger)
+	assert.NoError(t, err)
+
+	spec := newSpec()
+	routed := map[string]bool{}
+	assert.NoError(t, router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		// subrouters only hold a prefix, if anything
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		for _, method := range methods {
+			routed[method+" "+path] = true
+			assert.True(t, spec.Has(method, path), "%s %s is missing from the spec", method, path)
+		}
+		return nil
+	}))
+	for path, item := range spec.Paths {
+		for method := range item {
+			assert.True(t, routed[strings.ToUpper(method)+" "+path], "%s %s is documented but not routed", method, path)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, specPath, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"#/components/schemas/quest.QuestResponse"`)
+	assert.Nil(t, spec.Paths["/hello"]["get"].Security)
+	assert.NotNil(t, spec.Paths["/v2/quests"]["get"].Security)
+	assert.Contains(t, spec.Paths["/v2/quests"]["get"].Responses, "401")
+}
+
+func TestNewUsecases(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Driver = config.DriverMemory
+	repos, _, _ := newRepositories(cfg)
+
+	u, err := newUsecases(repos, clock.NewClock(), cfg.Progression, cfg.Commission)
+	assert.NoError(t, err)
+	assert.NotNil(t, u.quest)
+	assert.NotNil(t, u.adventurer)
+	assert.NotNil(t, u.auth)
+	assert.NotNil(t, u.giver)
+	assert.NotNil(t, u.ledger)
+
+	_, err = newUsecases(repositories{}, clock.NewClock(), cfg.Progression, cfg.Commission)
+	assert.Error(t, err)
+
+	_, err = newUsecases(repos, clock.NewClock(), config.Progression{}, cfg.Commission)
+	assert.Error(t, err)
+
+	_, err = newUsecases(repos, clock.NewClock(), cfg.Progression, config.Commission{Percent: 120})
+	assert.Error(t, err)
+}
+
+func TestNewScheduler(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Driver = config.DriverMemory
+	repos, _, _ := newRepositories(cfg)
+	u, _ := newUsecases(repos, clock.NewClock(), cfg.Progression, cfg.Commission)
+	appLogger, _ := logger.NewLogger("error")
+
+	expiry, err := newScheduler(cfg.Scheduler, u.quest, appLogger)
+	assert.NoError(t, err)
+	assert.NotNil(t, expiry)
+
+	expiry, err = newScheduler(config.Scheduler{}, u.quest, appLogger)
+	assert.NoError(t, err)
+	assert.Nil(t, expiry)
+}
+
+func TestCancelQuestRoute(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Driver = config.DriverMemory
+	repos, _, _ := newRepositories(cfg)
+	appLogger, _ := logger.NewLogger("error")
+	u, _ := newUsecases(repos, clock.NewClock(), cfg.Progression, cfg.Commission)
+	router, err := newRouter(cfg, u, appLogger)
+	assert.NoError(t, err)
+
+	requests := []struct {
+		method, path, body string
+		wantStatusCode     int
+		caller             int64 // adventurer id, or 0 for the staff
+	}{
+		{http.MethodPost, "/adventurer", `{"name":"andi","rank":11}`, http.StatusOK, 0},
+		{http.MethodPost, "/quest", `{"name":"menyelamatkan kucing","minimum_rank":11,"reward_number":200000}`, http.StatusOK, 0},
+		{http.MethodPost, "/take-quest", `{"quest_id":1,"adv_id":1}`, http.StatusOK, 1},
+		{http.MethodPost, "/quest/1/cancel", ``, http.StatusOK, 0},
+		{http.MethodPost, "/quest/1/cancel", ``, http.StatusConflict, 0},
+		{http.MethodPost, "/take-quest", `{"quest_id":1,"adv_id":1}`, http.StatusConflict, 1},
+		{http.MethodPost, "/quest/2/cancel", ``, http.StatusNotFound, 0},
+	}
+	keys := map[int64]string{}
+	for _, req := range requests {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, authorized(t, u, keys, httptest.NewRequest(req.method, req.path, strings.NewReader(req.body)), req.caller))
+		assert.Equal(t, req.wantStatusCode, recorder.Code, req.method+" "+req.path)
+	}
+
+	active, err := u.quest.GetQuestActiveAdventurer(1)
+	assert.NoError(t, err)
+	assert.Empty(t, active)
+}
+
+func TestProgressRoute(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Driver = config.DriverMemory
+	repos, _, _ := newRepositories(cfg)
+	appLogger, _ := logger.NewLogger("error")
+	u, _ := newUsecases(repos, clock.NewClock(), cfg.Progression, cfg.Commission)
+	router, err := newRouter(cfg, u, appLogger)
+	assert.NoError(t, err)
+
+	requests := []struct {
+		method, path, body string
+		wantStatusCode     int
+		caller             int64 // adventurer id, or 0 for the staff
+	}{
+		{http.MethodPost, "/adventurer", `{"name":"andi","rank":11}`, http.StatusOK, 0},
+		{http.MethodPost, "/quest", `{"name":"mengawal pedagang","minimum_rank":11,"reward_number":1000000}`, http.StatusOK, 0},
+		{http.MethodPost, "/take-quest", `{"quest_id":1,"adv_id":1}`, http.StatusOK, 1},
+		{http.MethodPost, "/done-quest", `{"quest_id":1,"adv_id":1,"is_completed":true}`, http.StatusOK, 1},
+		{http.MethodGet, "/adventurer/1/progress", ``, http.StatusOK, 0},
+		{http.MethodGet, "/adventurer/2/progress", ``, http.StatusNotFound, 0},
+		{http.MethodGet, "/adventurer/abc/progress", ``, http.StatusBadRequest, 0},
+	}
+	keys := map[int64]string{}
+	for _, req := range requests {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, authorized(t, u, keys, httptest.NewRequest(req.method, req.path, strings.NewReader(req.body)), req.caller))
+		assert.Equal(t, req.wantStatusCode, recorder.Code, req.method+" "+req.path)
+	}
+
+	progress, err := u.adventurer.GetProgress(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(12), progress.Rank)
+	assert.Equal(t, int32(1), progress.RankPoints)
+}
+
+func TestV2Routes(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Driver = config.DriverMemory
+	repos, _, _ := newRepositories(cfg)
+	appLogger, _ := logger.NewLogger("error")
+	u, _ := newUsecases(repos, clock.NewClock(), cfg.Progression, cfg.Commission)
+	router, err := newRouter(cfg, u, appLogger)
+	assert.NoError(t, err)
+
+	requests := []struct {
+		method, path, body string
+		wantStatusCode     int
+		caller             int64 // adventurer id, or 0 for the staff
+	}{
+		{http.MethodPost, "/v2/adventurers", `{"name":"andi","rank":11}`, http.StatusOK, 0},
+		{http.MethodPost, "/v2/adventurers", `{"name":"budi","rank":12}`, http.StatusOK, 0},
+		{http.MethodGet, "/v2/adventurers/1", ``, http.StatusOK, 0},
+		{http.MethodGet, "/v2/adventurers?name=an&min_rank=11&sort=-completed_quest", ``, http.StatusOK, 0},
+		{http.MethodGet, "/v2/adventurers?sort=name", ``, http.StatusUnprocessableEntity, 0},
+		{http.MethodGet, "/v2/adventurers/9", ``, http.StatusNotFound, 0},
+		{http.MethodPatch, "/v2/adventurers/1", `{"rank":100}`, http.StatusForbidden, 1},
+		{http.MethodPatch, "/v2/adventurers/1", `{"rank":12}`, http.StatusOK, 0},
+		{http.MethodPatch, "/v2/adventurers/999", `{"rank":12}`, http.StatusNotFound, 0},
+		{http.MethodPatch, "/adventurer-rank", `{"id":999,"rank":12}`, http.StatusNotFound, 0},
+		{http.MethodPost, "/v2/quests", `{"name":"mengawal pedagang","minimum_rank":12,"reward_number":200000,"max_members":2}`, http.StatusOK, 0},
+		{http.MethodGet, "/v2/quests?status=0", ``, http.StatusOK, 0},
+		{http.MethodGet, "/v2/quests?status=0,1&min_reward=100000&sort=-reward&limit=10", ``, http.StatusOK, 0},
+		{http.MethodGet, "/v2/quests?sort=name", ``, http.StatusUnprocessableEntity, 0},
+		{http.MethodGet, "/v2/quests/1", ``, http.StatusOK, 0},
+		{http.MethodGet, "/v2/quests/9", ``, http.StatusNotFound, 0},
+		{http.MethodPatch, "/v2/quests/1", `{"reward_number":300000}`, http.StatusOK, 0},
+		{http.MethodPatch, "/v2/quests/1", `{}`, http.StatusUnprocessableEntity, 0},
+		{http.MethodPatch, "/v2/quests/1", `{"reward_number":300000,"status":2,"bonus":1}`, http.StatusUnprocessableEntity, 0},
+		{http.MethodPatch, "/v2/quests/99", `{"minimum_rank":12}`, http.StatusNotFound, 0},
+		{http.MethodPost, "/v2/quests/1/takers", `{"adv_id":9}`, http.StatusForbidden, 1},
+		{http.MethodPost, "/v2/quests/1/takers", `{}`, http.StatusForbidden, 0},
+		{http.MethodPost, "/v2/quests/1/takers", `{"adv_id":1}`, http.StatusOK, 1},
+		{http.MethodPost, "/v2/quests/1/takers", `{"adv_id":2}`, http.StatusOK, 2},
+		{http.MethodGet, "/v2/quests/1/takers", ``, http.StatusOK, 0},
+		{http.MethodGet, "/v2/quests/9/takers", ``, http.StatusNotFound, 0},
+		{http.MethodGet, "/v2/adventurers/2/quests", ``, http.StatusOK, 0},
+		{http.MethodPost, "/v2/quests/1/report", `{"adv_id":2,"is_completed":true}`, http.StatusOK, 2},
+		{http.MethodGet, "/v2/adventurers/2/progress", ``, http.StatusOK, 0},
+		{http.MethodGet, "/v2/quests/1/history?limit=1", ``, http.StatusOK, 0},
+		{http.MethodGet, "/v2/adventurers/2/history", ``, http.StatusOK, 0},
+		{http.MethodGet, "/v2/adventurers/9/history", ``, http.StatusNotFound, 0},
+		{http.MethodGet, "/v2/quests/1/history?offset=-1", ``, http.StatusUnprocessableEntity, 0},
+		{http.MethodPost, "/v2/quests", `{"name":"menyelamatkan kucing","minimum_rank":11,"reward_number":200000}`, http.StatusOK, 0},
+		{http.MethodPost, "/v2/quests/2/cancel", ``, http.StatusOK, 0},
+		{http.MethodDelete, "/v2/quests/2", ``, http.StatusForbidden, 1},
+		{http.MethodDelete, "/v2/quests/2", ``, http.StatusOK, 0},
+		{http.MethodGet, "/v2/quests/2", ``, http.StatusNotFound, 0},
+	}
+	keys := map[int64]string{}
+	for _, req := range requests {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, authorized(t, u, keys, httptest.NewRequest(req.method, req.path, strings.NewReader(req.body)), req.caller))
+		assert.Equal(t, req.wantStatusCode, recorder.Code, req.method+" "+req.path)
+	}
+
+	takers, err := u.quest.GetTakers(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{150000, 150000}, []int32{takers[0].Reward, takers[1].Reward})
+	quest, err := u.quest.GetQuest(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(constant.CompletedQuest), quest.Status)
+	history, total, err := u.quest.ListAssignments(modelQuest.AssignmentFilter{QuestID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []string{constant.OutcomeCompleted, constant.OutcomeCompleted}, []string{history[0].Outcome, history[1].Outcome})
+}
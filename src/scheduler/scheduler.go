@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/arfaghifari/guild-board/src/logger"
+)
+
+// Job is one run of a background task.
+type Job func() error
+
+// Scheduler runs a job every interval until it is stopped.
+type Scheduler interface {
+	Start()
+	Stop()
+}
+
+var (
+	ErrInvalidInterval = errors.New("scheduler interval must be positive")
+	errMissingJob      = errors.New("scheduler needs a job and a logger")
+)
+
+type scheduler struct {
+	interval time.Duration
+	job      Job
+	logger   logger.Logger
+	start    sync.Once
+	stop     sync.Once
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewScheduler(interval time.Duration, job Job, logger logger.Logger) (Scheduler, error) {
+	if interval <= 0 {
+		return nil, ErrInvalidInterval
+	}
+	if job == nil || logger == nil {
+		return nil, errMissingJob
+	}
+
+	return &scheduler{interval: interval, job: job, logger: logger, done: make(chan struct{})}, nil
+}
+
+func (s *scheduler) Start() {
+	s.start.Do(func() {
+		s.wg.Add(1)
+		go s.loop()
+	})
+}
+
+// Stop ends the loop and waits for a running job to return.
+func (s *scheduler) Stop() {
+	s.stop.Do(func() {
+		close(s.done)
+	})
+	s.wg.Wait()
+}
+
+func (s *scheduler) loop() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			if err := s.job(); err != nil {
+				s.logger.Errorf("[Scheduler] job failed, err: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingLogger struct {
+	mu     sync.Mutex
+	errors []string
+}
+
+func (l *recordingLogger) Debugf(string, ...interface{}) {}
+func (l *recordingLogger) Infof(string, ...interface{})  {}
+func (l *recordingLogger) Warnf(string, ...interface{})  {}
+
+func (l *recordingLogger) Errorf(format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errors = append(l.errors, fmt.Sprintf(format, args...))
+}
+
+func TestNewScheduler(t *testing.T) {
+	job := func() error { return nil }
+	tests := []struct {
+		name     string
+		interval time.Duration
+		job      Job
+		logger   *recordingLogger
+		outErr   error
+		wantErr  bool
+	}{
+		{
+			name:     "success",
+			interval: time.Minute,
+			job:      job,
+			logger:   &recordingLogger{},
+			wantErr:  false,
+		},
+		{
+			name:     "failed zero interval",
+			interval: 0,
+			job:      job,
+			logger:   &recordingLogger{},
+			outErr:   ErrInvalidInterval,
+			wantErr:  true,
+		},
+		{
+			name:     "failed nil job",
+			interval: time.Minute,
+			logger:   &recordingLogger{},
+			wantErr:  true,
+		},
+		{
+			name:     "failed nil logger",
+			interval: time.Minute,
+			job:      job,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Scheduler
+			var err error
+			if tt.logger == nil {
+				s, err = NewScheduler(tt.interval, tt.job, nil)
+			} else {
+				s, err = NewScheduler(tt.interval, tt.job, tt.logger)
+			}
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+				assert.Nil(t, s)
+			} else {
+				assert.NoError(t, err, tt.name)
+				assert.NotNil(t, s)
+			}
+			if tt.outErr != nil {
+				assert.Equal(t, tt.outErr, err)
+			}
+		})
+	}
+}
+
+func TestSchedulerRunsJob(t *testing.T) {
+	runs := make(chan struct{}, 3)
+	log := &recordingLogger{}
+	s, err := NewScheduler(time.Millisecond, func() error {
+		select {
+		case runs <- struct{}{}:
+		default:
+		}
+		return errors.New("any error")
+	}, log)
+	assert.NoError(t, err)
+
+	s.Start()
+	s.Start()
+	for i := 0; i < 2; i++ {
+		select {
+		case <-runs:
+		case <-time.After(time.Second):
+			t.Fatal("job did not run")
+		}
+	}
+	s.Stop()
+	s.Stop()
+
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	assert.NotEmpty(t, log.errors)
+	assert.Contains(t, log.errors[0], "any error")
+}
+
+func TestSchedulerStopWithoutStart(t *testing.T) {
+	s, err := NewScheduler(time.Millisecond, func() error { return nil }, &recordingLogger{})
+	assert.NoError(t, err)
+	s.Stop()
+}
@@ -14,9 +14,10 @@ import (
 )
 
 type Config struct {
-	WriteTimeout time.Duration
-	ReadTimeout  time.Duration
-	Port         int
+	WriteTimeout    time.Duration
+	ReadTimeout     time.Duration
+	Port            int
+	ShutdownTimeout time.Duration
 }
 
 func Serve(cfg Config, router *mux.Router) {
@@ -39,7 +40,9 @@ func Serve(cfg Config, router *mux.Router) {
 	signal.Notify(s, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 	<-s
 
-	if err := srv.Shutdown(context.Background()); err != nil {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
 		log.Println("[Server] error on shutting down HTTP Server, err: ", err.Error())
 	}
 }
@@ -1,6 +1,12 @@
 package adventurer
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	constant "github.com/arfaghifari/guild-board/src/constant"
 	model "github.com/arfaghifari/guild-board/src/model/adventurer"
 	repo "github.com/arfaghifari/guild-board/src/repository/adventurer"
 )
@@ -9,16 +15,28 @@ type Usecase interface {
 	CreateAdventurer(model.Adventurer) (model.Adventurer, error)
 	UpdateAdventurerRank(model.Adventurer) error
 	GetAdventurer(int64) (model.Adventurer, error)
+	GetProgress(int64) (model.Progress, error)
+	ListAdventurers(model.AdventurerFilter) ([]model.Adventurer, int, error)
 }
 
+var (
+	ErrAdventurerNotFound = apperror.NewNotFound("adventurer_not_found", "adventurer not found")
+	ErrInvalidFilter      = apperror.NewValidation("invalid_filter", "invalid adventurer filter")
+)
+
 type usecase struct {
-	repo repo.Repository
+	repo   repo.Repository
+	policy Policy
 }
 
-func NewUsecase() (Usecase, error) {
-	repo, _ := repo.NewRepository()
+var errMissingDependency = errors.New("adventurer usecase needs a repository and a policy")
 
-	return &usecase{repo}, nil
+func NewUsecase(repo repo.Repository, policy Policy) (Usecase, error) {
+	if repo == nil || policy == nil {
+		return nil, errMissingDependency
+	}
+
+	return &usecase{repo, policy}, nil
 }
 
 func (u *usecase) CreateAdventurer(adv model.Adventurer) (model.Adventurer, error) {
@@ -26,9 +44,56 @@ func (u *usecase) CreateAdventurer(adv model.Adventurer) (model.Adventurer, erro
 }
 
 func (u *usecase) UpdateAdventurerRank(adv model.Adventurer) error {
+	if _, err := u.GetAdventurer(adv.ID); err != nil {
+		return err
+	}
 	return u.repo.UpdateAdventurerRank(adv)
 }
 
 func (u *usecase) GetAdventurer(id int64) (model.Adventurer, error) {
-	return u.repo.GetAdventurer(id)
+	adv, err := u.repo.GetAdventurer(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return adv, ErrAdventurerNotFound
+	}
+	return adv, err
+}
+
+func (u *usecase) GetProgress(id int64) (model.Progress, error) {
+	adv, err := u.GetAdventurer(id)
+	if err != nil {
+		return model.Progress{}, err
+	}
+	return u.policy.Progress(adv), nil
+}
+
+// ListAdventurers returns one page of the adventurers matching filter and the
+// number of matching adventurers. A zero limit asks for the default page size
+// by id.
+func (u *usecase) ListAdventurers(filter model.AdventurerFilter) ([]model.Adventurer, int, error) {
+	if filter.Limit == 0 {
+		filter.Limit = constant.DefaultPageSize
+	}
+	if filter.Sort == "" {
+		filter.Sort = constant.SortID
+	}
+	if err := validateFilter(filter); err != nil {
+		return nil, 0, err
+	}
+	return u.repo.ListAdventurers(filter)
+}
+
+func validateFilter(filter model.AdventurerFilter) error {
+	switch {
+	case filter.MinRank < 0 || filter.MaxRank < 0:
+		return fmt.Errorf("%w: rank must not be negative", ErrInvalidFilter)
+	case filter.MaxRank > 0 && filter.MinRank > filter.MaxRank:
+		return fmt.Errorf("%w: min_rank is above max_rank", ErrInvalidFilter)
+	case filter.Sort != constant.SortID && filter.Sort != constant.SortRank && filter.Sort != constant.SortCompletedQuest:
+		return fmt.Errorf("%w: unknown sort %q", ErrInvalidFilter, filter.Sort)
+	case filter.Limit < 0 || filter.Limit > constant.MaxPageSize:
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidFilter, constant.MaxPageSize)
+	case filter.Offset < 0:
+		return fmt.Errorf("%w: offset must not be negative", ErrInvalidFilter)
+	}
+	return nil
 }
@@ -48,6 +48,20 @@ func (mr *MockRepositoryMockRecorder) AddCompletedQuest(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCompletedQuest", reflect.TypeOf((*MockRepository)(nil).AddCompletedQuest), arg0)
 }
 
+// AddFailedQuest mocks base method.
+func (m *MockRepository) AddFailedQuest(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFailedQuest", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFailedQuest indicates an expected call of AddFailedQuest.
+func (mr *MockRepositoryMockRecorder) AddFailedQuest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFailedQuest", reflect.TypeOf((*MockRepository)(nil).AddFailedQuest), arg0)
+}
+
 // Close mocks base method.
 func (m *MockRepository) Close() {
 	m.ctrl.T.Helper()
@@ -90,6 +104,36 @@ func (mr *MockRepositoryMockRecorder) GetAdventurer(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdventurer", reflect.TypeOf((*MockRepository)(nil).GetAdventurer), arg0)
 }
 
+// ListAdventurers mocks base method.
+func (m *MockRepository) ListAdventurers(arg0 adventurer.AdventurerFilter) ([]adventurer.Adventurer, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAdventurers", arg0)
+	ret0, _ := ret[0].([]adventurer.Adventurer)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAdventurers indicates an expected call of ListAdventurers.
+func (mr *MockRepositoryMockRecorder) ListAdventurers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdventurers", reflect.TypeOf((*MockRepository)(nil).ListAdventurers), arg0)
+}
+
+// UpdateAdventurerProgress mocks base method.
+func (m *MockRepository) UpdateAdventurerProgress(arg0 adventurer.Adventurer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAdventurerProgress", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAdventurerProgress indicates an expected call of UpdateAdventurerProgress.
+func (mr *MockRepositoryMockRecorder) UpdateAdventurerProgress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdventurerProgress", reflect.TypeOf((*MockRepository)(nil).UpdateAdventurerProgress), arg0)
+}
+
 // UpdateAdventurerRank mocks base method.
 func (m *MockRepository) UpdateAdventurerRank(arg0 adventurer.Adventurer) error {
 	m.ctrl.T.Helper()
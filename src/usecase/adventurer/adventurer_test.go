@@ -1,9 +1,12 @@
 package adventurer
 
 import (
+	"database/sql"
 	"errors"
 	"testing"
 
+	"github.com/arfaghifari/guild-board/src/config"
+	constant "github.com/arfaghifari/guild-board/src/constant"
 	model "github.com/arfaghifari/guild-board/src/model/adventurer"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -16,10 +19,30 @@ var adv = model.Adventurer{
 	CompletedQuest: 1,
 }
 
+var progression = config.Progression{
+	PromotionPoints:  10,
+	RewardUnit:       100000,
+	DemotionFailures: 3,
+	MinRank:          1,
+	MaxRank:          constant.MaxRank,
+}
+
 func TestNewUsecase(t *testing.T) {
-	res, err := NewUsecase()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	policy, _ := NewPolicy(progression)
+	res, err := NewUsecase(NewMockRepository(mockCtrl), policy)
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
+
+	res, err = NewUsecase(nil, policy)
+	assert.Error(t, err)
+	assert.Nil(t, res)
+
+	res, err = NewUsecase(NewMockRepository(mockCtrl), nil)
+	assert.Error(t, err)
+	assert.Nil(t, res)
 }
 
 func TestCreateAdventurer(t *testing.T) {
@@ -101,6 +124,7 @@ func TestUpdateAdventureRank(t *testing.T) {
 		fields  fields
 		args    args
 		mock    func(*MockRepository)
+		outErr  error
 		wantErr bool
 	}{
 		{
@@ -112,10 +136,25 @@ func TestUpdateAdventureRank(t *testing.T) {
 				adv: adv,
 			},
 			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetAdventurer(adv.ID).Return(adv, nil).Times(1)
 				repo.EXPECT().UpdateAdventurerRank(adv).Return(nil).Times(1)
 			},
 			wantErr: false,
 		},
+		{
+			name: "not found",
+			fields: fields{
+				r: NewMockRepository(mockCtrl),
+			},
+			args: args{
+				adv: adv,
+			},
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetAdventurer(adv.ID).Return(model.Adventurer{}, sql.ErrNoRows).Times(1)
+			},
+			outErr:  ErrAdventurerNotFound,
+			wantErr: true,
+		},
 		{
 			name: "failed",
 			fields: fields{
@@ -125,6 +164,7 @@ func TestUpdateAdventureRank(t *testing.T) {
 				adv: adv,
 			},
 			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetAdventurer(adv.ID).Return(adv, nil).Times(1)
 				repo.EXPECT().UpdateAdventurerRank(adv).Return(errors.New("any error")).Times(1)
 			},
 			wantErr: true,
@@ -142,6 +182,9 @@ func TestUpdateAdventureRank(t *testing.T) {
 			} else {
 				assert.NoError(t, err, tt.name)
 			}
+			if tt.outErr != nil {
+				assert.Equal(t, tt.outErr, err, tt.name)
+			}
 		})
 	}
 }
@@ -162,6 +205,7 @@ func TestGetAdventurer(t *testing.T) {
 		args    args
 		mock    func(*MockRepository)
 		outAdv  model.Adventurer
+		outErr  error
 		wantErr bool
 	}{
 		{
@@ -192,6 +236,21 @@ func TestGetAdventurer(t *testing.T) {
 			outAdv:  model.Adventurer{},
 			wantErr: true,
 		},
+		{
+			name: "not found",
+			fields: fields{
+				r: NewMockRepository(mockCtrl),
+			},
+			args: args{
+				ID: adv.ID,
+			},
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetAdventurer(adv.ID).Return(model.Adventurer{}, sql.ErrNoRows).Times(1)
+			},
+			outAdv:  model.Adventurer{},
+			outErr:  ErrAdventurerNotFound,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -206,6 +265,171 @@ func TestGetAdventurer(t *testing.T) {
 			} else {
 				assert.NoError(t, err, tt.name)
 			}
+			if tt.outErr != nil {
+				assert.Equal(t, tt.outErr, err, tt.name)
+			}
+		})
+	}
+}
+
+func TestGetProgress(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	policy, _ := NewPolicy(progression)
+	progressed := adv
+	progressed.RankPoints, progressed.FailedStreak = 4, 1
+	type fields struct {
+		r *MockRepository
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		mock        func(*MockRepository)
+		outProgress model.Progress
+		wantErr     bool
+	}{
+		{
+			name: "success get progress of an adventurer",
+			fields: fields{
+				r: NewMockRepository(mockCtrl),
+			},
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetAdventurer(adv.ID).Return(progressed, nil).Times(1)
+			},
+			outProgress: model.Progress{
+				AdventurerID:       adv.ID,
+				Rank:               11,
+				NextRank:           12,
+				RankPoints:         4,
+				PointsToNextRank:   6,
+				FailedStreak:       1,
+				FailuresToDemotion: 2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed",
+			fields: fields{
+				r: NewMockRepository(mockCtrl),
+			},
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetAdventurer(adv.ID).Return(model.Adventurer{}, errors.New("any error")).Times(1)
+			},
+			outProgress: model.Progress{},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				repo:   tt.fields.r,
+				policy: policy,
+			}
+			tt.mock(tt.fields.r)
+			res, err := u.GetProgress(adv.ID)
+			assert.Equal(t, tt.outProgress, res)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+		})
+	}
+}
+
+func TestListAdventurers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name     string
+		filter   model.AdventurerFilter
+		mock     func(*MockRepository)
+		outAdvs  []model.Adventurer
+		outTotal int
+		wantErr  bool
+		invalid  bool
+	}{
+		{
+			name:   "defaults to the first page by id",
+			filter: model.AdventurerFilter{},
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().ListAdventurers(model.AdventurerFilter{Sort: constant.SortID, Limit: constant.DefaultPageSize}).Return([]model.Adventurer{adv}, 1, nil).Times(1)
+			},
+			outAdvs:  []model.Adventurer{adv},
+			outTotal: 1,
+		},
+		{
+			name:   "passes a valid filter through",
+			filter: model.AdventurerFilter{NamePrefix: "an", MinRank: 11, MaxRank: 11, Sort: constant.SortCompletedQuest, Desc: true, Limit: constant.MaxPageSize, Offset: 100},
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().ListAdventurers(model.AdventurerFilter{NamePrefix: "an", MinRank: 11, MaxRank: 11, Sort: constant.SortCompletedQuest, Desc: true,
+					Limit: constant.MaxPageSize, Offset: 100}).Return([]model.Adventurer{}, 1, nil).Times(1)
+			},
+			outAdvs:  []model.Adventurer{},
+			outTotal: 1,
+		},
+		{
+			name:    "negative rank",
+			filter:  model.AdventurerFilter{MinRank: -1},
+			mock:    func(repo *MockRepository) {},
+			wantErr: true,
+			invalid: true,
+		},
+		{
+			name:    "rank range inverted",
+			filter:  model.AdventurerFilter{MinRank: 13, MaxRank: 12},
+			mock:    func(repo *MockRepository) {},
+			wantErr: true,
+			invalid: true,
+		},
+		{
+			name:    "unknown sort",
+			filter:  model.AdventurerFilter{Sort: "name"},
+			mock:    func(repo *MockRepository) {},
+			wantErr: true,
+			invalid: true,
+		},
+		{
+			name:    "limit too large",
+			filter:  model.AdventurerFilter{Limit: constant.MaxPageSize + 1},
+			mock:    func(repo *MockRepository) {},
+			wantErr: true,
+			invalid: true,
+		},
+		{
+			name:    "negative offset",
+			filter:  model.AdventurerFilter{Offset: -1},
+			mock:    func(repo *MockRepository) {},
+			wantErr: true,
+			invalid: true,
+		},
+		{
+			name:   "failed list adventurers",
+			filter: model.AdventurerFilter{},
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().ListAdventurers(gomock.Any()).Return(nil, 0, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewMockRepository(mockCtrl)
+			u := &usecase{
+				repo: r,
+			}
+			tt.mock(r)
+			res, total, err := u.ListAdventurers(tt.filter)
+			assert.Equal(t, tt.outAdvs, res)
+			assert.Equal(t, tt.outTotal, total)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			assert.Equal(t, tt.invalid, errors.Is(err, ErrInvalidFilter))
 		})
 	}
 }
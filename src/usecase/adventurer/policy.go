@@ -0,0 +1,105 @@
+package adventurer
+
+import (
+	"errors"
+
+	"github.com/arfaghifari/guild-board/src/config"
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	model "github.com/arfaghifari/guild-board/src/model/adventurer"
+)
+
+// Policy moves adventurers between ranks as their quests are reported.
+type Policy interface {
+	// Completed credits a completed quest with the given minimum rank and the
+	// reward the adventurer earned from it, promoting when enough points add up.
+	Completed(adv model.Adventurer, minimumRank, reward int32) model.Adventurer
+	// Failed counts a failed report, demoting after too many in a row.
+	Failed(adv model.Adventurer) model.Adventurer
+	// Progress tells how far adv is from the next promotion and demotion.
+	Progress(adv model.Adventurer) model.Progress
+}
+
+type policy struct {
+	promotionPoints  int32
+	rewardUnit       int32
+	demotionFailures int32
+	minRank          int32
+	maxRank          int32
+}
+
+var ErrInvalidPolicy = errors.New("progression needs positive promotion points and min rank, and a max rank between the min rank and the highest rank")
+
+func NewPolicy(cfg config.Progression) (Policy, error) {
+	if cfg.PromotionPoints <= 0 || cfg.RewardUnit < 0 || cfg.DemotionFailures < 0 || cfg.MinRank <= 0 ||
+		cfg.MaxRank < cfg.MinRank || cfg.MaxRank > constant.MaxRank {
+		return nil, ErrInvalidPolicy
+	}
+
+	return &policy{
+		promotionPoints:  int32(cfg.PromotionPoints),
+		rewardUnit:       int32(cfg.RewardUnit),
+		demotionFailures: int32(cfg.DemotionFailures),
+		minRank:          int32(cfg.MinRank),
+		maxRank:          int32(cfg.MaxRank),
+	}, nil
+}
+
+// points is what a completed quest is worth: one, plus one for every rank the
+// quest asks above the adventurer's, plus one for every reward unit earned.
+func (p *policy) points(rank, minimumRank, reward int32) int32 {
+	points := int32(1)
+	if minimumRank > rank {
+		points += minimumRank - rank
+	}
+	if p.rewardUnit > 0 {
+		points += reward / p.rewardUnit
+	}
+	return points
+}
+
+func (p *policy) atTop(rank int32) bool {
+	return rank >= p.maxRank
+}
+
+func (p *policy) Completed(adv model.Adventurer, minimumRank, reward int32) model.Adventurer {
+	adv.FailedStreak = 0
+	adv.RankPoints += p.points(adv.Rank, minimumRank, reward)
+	for adv.RankPoints >= p.promotionPoints && !p.atTop(adv.Rank) {
+		adv.RankPoints -= p.promotionPoints
+		adv.Rank++
+	}
+	return adv
+}
+
+func (p *policy) Failed(adv model.Adventurer) model.Adventurer {
+	adv.FailedStreak++
+	if p.demotionFailures == 0 || adv.FailedStreak < p.demotionFailures {
+		return adv
+	}
+	adv.FailedStreak = 0
+	adv.RankPoints = 0
+	if adv.Rank > p.minRank {
+		adv.Rank--
+	}
+	return adv
+}
+
+func (p *policy) Progress(adv model.Adventurer) model.Progress {
+	progress := model.Progress{
+		AdventurerID: adv.ID,
+		Rank:         adv.Rank,
+		NextRank:     adv.Rank,
+		RankPoints:   adv.RankPoints,
+		FailedStreak: adv.FailedStreak,
+	}
+	if !p.atTop(adv.Rank) {
+		progress.NextRank = adv.Rank + 1
+		if adv.RankPoints < p.promotionPoints {
+			progress.PointsToNextRank = p.promotionPoints - adv.RankPoints
+		}
+	}
+	if p.demotionFailures > 0 && adv.Rank > p.minRank {
+		progress.FailuresToDemotion = p.demotionFailures - adv.FailedStreak
+	}
+	return progress
+}
@@ -0,0 +1,214 @@
+package adventurer
+
+import (
+	"testing"
+
+	"github.com/arfaghifari/guild-board/src/config"
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	model "github.com/arfaghifari/guild-board/src/model/adventurer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.Progression
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			cfg:     progression,
+			wantErr: false,
+		},
+		{
+			name:    "zero promotion points",
+			cfg:     config.Progression{MinRank: 1},
+			wantErr: true,
+		},
+		{
+			name:    "negative reward unit",
+			cfg:     config.Progression{PromotionPoints: 10, RewardUnit: -1, MinRank: 1},
+			wantErr: true,
+		},
+		{
+			name:    "zero min rank",
+			cfg:     config.Progression{PromotionPoints: 10},
+			wantErr: true,
+		},
+		{
+			name:    "max rank below min rank",
+			cfg:     config.Progression{PromotionPoints: 10, MinRank: 5, MaxRank: 3},
+			wantErr: true,
+		},
+		{
+			name:    "uncapped max rank",
+			cfg:     config.Progression{PromotionPoints: 10, MinRank: 1},
+			wantErr: true,
+		},
+		{
+			name:    "max rank above the highest rank",
+			cfg:     config.Progression{PromotionPoints: 10, MinRank: 1, MaxRank: constant.MaxRank + 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := NewPolicy(tt.cfg)
+			if tt.wantErr {
+				assert.Equal(t, ErrInvalidPolicy, err, tt.name)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err, tt.name)
+				assert.NotNil(t, res)
+			}
+		})
+	}
+}
+
+func TestPolicyCompleted(t *testing.T) {
+	capped := progression
+	capped.MaxRank = 12
+	noReward := progression
+	noReward.RewardUnit = 0
+	tests := []struct {
+		name        string
+		cfg         config.Progression
+		adv         model.Adventurer
+		minimumRank int32
+		reward      int32
+		outAdv      model.Adventurer
+	}{
+		{
+			name:        "easy quest earns a point",
+			cfg:         noReward,
+			adv:         model.Adventurer{Rank: 11, FailedStreak: 2},
+			minimumRank: 9,
+			reward:      500000,
+			outAdv:      model.Adventurer{Rank: 11, RankPoints: 1},
+		},
+		{
+			name:        "harder quest and reward earn more",
+			cfg:         progression,
+			adv:         model.Adventurer{Rank: 11},
+			minimumRank: 13,
+			reward:      250000,
+			outAdv:      model.Adventurer{Rank: 11, RankPoints: 5},
+		},
+		{
+			name:        "promoted carrying the rest",
+			cfg:         progression,
+			adv:         model.Adventurer{Rank: 11, RankPoints: 8},
+			minimumRank: 11,
+			reward:      300000,
+			outAdv:      model.Adventurer{Rank: 12, RankPoints: 2},
+		},
+		{
+			name:        "promoted twice",
+			cfg:         progression,
+			adv:         model.Adventurer{Rank: 11, RankPoints: 9},
+			minimumRank: 11,
+			reward:      1100000,
+			outAdv:      model.Adventurer{Rank: 13, RankPoints: 1},
+		},
+		{
+			name:        "not promoted past max rank",
+			cfg:         capped,
+			adv:         model.Adventurer{Rank: 12, RankPoints: 9},
+			minimumRank: 12,
+			reward:      100000,
+			outAdv:      model.Adventurer{Rank: 12, RankPoints: 11},
+		},
+		{
+			name:        "not promoted past the highest rank",
+			cfg:         progression,
+			adv:         model.Adventurer{Rank: constant.MaxRank, RankPoints: 9},
+			minimumRank: constant.MaxRank,
+			reward:      100000,
+			outAdv:      model.Adventurer{Rank: constant.MaxRank, RankPoints: 11},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := NewPolicy(tt.cfg)
+			assert.Equal(t, tt.outAdv, p.Completed(tt.adv, tt.minimumRank, tt.reward))
+		})
+	}
+}
+
+func TestPolicyFailed(t *testing.T) {
+	lenient := progression
+	lenient.DemotionFailures = 0
+	tests := []struct {
+		name   string
+		cfg    config.Progression
+		adv    model.Adventurer
+		outAdv model.Adventurer
+	}{
+		{
+			name:   "failure counted",
+			cfg:    progression,
+			adv:    model.Adventurer{Rank: 11, RankPoints: 4, FailedStreak: 1},
+			outAdv: model.Adventurer{Rank: 11, RankPoints: 4, FailedStreak: 2},
+		},
+		{
+			name:   "demoted after repeated failures",
+			cfg:    progression,
+			adv:    model.Adventurer{Rank: 11, RankPoints: 4, FailedStreak: 2},
+			outAdv: model.Adventurer{Rank: 10},
+		},
+		{
+			name:   "not demoted below min rank",
+			cfg:    progression,
+			adv:    model.Adventurer{Rank: 1, RankPoints: 4, FailedStreak: 2},
+			outAdv: model.Adventurer{Rank: 1},
+		},
+		{
+			name:   "never demoted",
+			cfg:    lenient,
+			adv:    model.Adventurer{Rank: 11, FailedStreak: 5},
+			outAdv: model.Adventurer{Rank: 11, FailedStreak: 6},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := NewPolicy(tt.cfg)
+			assert.Equal(t, tt.outAdv, p.Failed(tt.adv))
+		})
+	}
+}
+
+func TestPolicyProgress(t *testing.T) {
+	capped := progression
+	capped.MaxRank = 12
+	tests := []struct {
+		name        string
+		cfg         config.Progression
+		adv         model.Adventurer
+		outProgress model.Progress
+	}{
+		{
+			name:        "on the way to the next rank",
+			cfg:         progression,
+			adv:         model.Adventurer{ID: 1, Rank: 11, RankPoints: 3, FailedStreak: 1},
+			outProgress: model.Progress{AdventurerID: 1, Rank: 11, NextRank: 12, RankPoints: 3, PointsToNextRank: 7, FailedStreak: 1, FailuresToDemotion: 2},
+		},
+		{
+			name:        "at max rank",
+			cfg:         capped,
+			adv:         model.Adventurer{ID: 1, Rank: 12, RankPoints: 11},
+			outProgress: model.Progress{AdventurerID: 1, Rank: 12, NextRank: 12, RankPoints: 11, FailuresToDemotion: 3},
+		},
+		{
+			name:        "at min rank",
+			cfg:         progression,
+			adv:         model.Adventurer{ID: 1, Rank: 1, FailedStreak: 2},
+			outProgress: model.Progress{AdventurerID: 1, Rank: 1, NextRank: 2, PointsToNextRank: 10, FailedStreak: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := NewPolicy(tt.cfg)
+			assert.Equal(t, tt.outProgress, p.Progress(tt.adv))
+		})
+	}
+}
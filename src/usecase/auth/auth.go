@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/clock"
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	model "github.com/arfaghifari/guild-board/src/model/auth"
+	repoAdv "github.com/arfaghifari/guild-board/src/repository/adventurer"
+	repo "github.com/arfaghifari/guild-board/src/repository/auth"
+	repoGiver "github.com/arfaghifari/guild-board/src/repository/giver"
+	advUsecase "github.com/arfaghifari/guild-board/src/usecase/adventurer"
+	giverUsecase "github.com/arfaghifari/guild-board/src/usecase/giver"
+)
+
+type Usecase interface {
+	IssueAPIKey(name, role string, holderID int64) (string, model.APIKey, error)
+	Authenticate(token string) (model.Principal, error)
+}
+
+var (
+	ErrUnauthenticated = apperror.NewUnauthenticated("unauthenticated", "missing or unknown api key")
+	ErrInvalidKey      = apperror.NewValidation("invalid_api_key", "api keys need a name and the staff, quest_giver or adventurer role; adventurer and quest_giver keys name who holds them")
+)
+
+// tokenPrefix marks guild board keys so that leaked ones are easy to find.
+const tokenPrefix = "gb_"
+
+type usecase struct {
+	repo      repo.Repository
+	repoAdv   repoAdv.Repository
+	repoGiver repoGiver.Repository
+	clock     clock.Clock
+	random    io.Reader
+}
+
+var errMissingDependency = errors.New("auth usecase needs repositories and a clock")
+
+func NewUsecase(repo repo.Repository, repoAdv repoAdv.Repository, repoGiver repoGiver.Repository, clock clock.Clock) (Usecase, error) {
+	if repo == nil || repoAdv == nil || repoGiver == nil || clock == nil {
+		return nil, errMissingDependency
+	}
+
+	return &usecase{repo, repoAdv, repoGiver, clock, rand.Reader}, nil
+}
+
+// IssueAPIKey creates a key and returns it with its record. The key itself is
+// not stored and cannot be shown again. holderID is the adventurer of an
+// adventurer key or the quest giver of a quest giver key, and 0 for staff.
+func (u *usecase) IssueAPIKey(name, role string, holderID int64) (string, model.APIKey, error) {
+	if name == "" || (role != constant.RoleStaff && role != constant.RoleGiver && role != constant.RoleAdventurer) ||
+		(role == constant.RoleStaff) != (holderID == 0) || holderID < 0 {
+		return "", model.APIKey{}, ErrInvalidKey
+	}
+	key := model.APIKey{Name: name, Role: role}
+	switch role {
+	case constant.RoleAdventurer:
+		if _, err := u.repoAdv.GetAdventurer(holderID); errors.Is(err, sql.ErrNoRows) {
+			return "", model.APIKey{}, advUsecase.ErrAdventurerNotFound
+		} else if err != nil {
+			return "", model.APIKey{}, err
+		}
+		key.AdventurerID = holderID
+	case constant.RoleGiver:
+		if _, err := u.repoGiver.GetGiver(holderID); errors.Is(err, sql.ErrNoRows) {
+			return "", model.APIKey{}, giverUsecase.ErrGiverNotFound
+		} else if err != nil {
+			return "", model.APIKey{}, err
+		}
+		key.GiverID = holderID
+	}
+
+	secret := make([]byte, 32)
+	if _, err := io.ReadFull(u.random, secret); err != nil {
+		return "", model.APIKey{}, err
+	}
+	token := tokenPrefix + hex.EncodeToString(secret)
+	key.Hash = hash(token)
+	key.CreatedAt = u.clock.Now()
+	key, err := u.repo.CreateAPIKey(key)
+	if err != nil {
+		return "", model.APIKey{}, err
+	}
+	return token, key, nil
+}
+
+func (u *usecase) Authenticate(token string) (model.Principal, error) {
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return model.Principal{}, ErrUnauthenticated
+	}
+	key, err := u.repo.GetAPIKeyByHash(hash(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Principal{}, ErrUnauthenticated
+	}
+	if err != nil {
+		return model.Principal{}, err
+	}
+	return model.Principal{KeyID: key.ID, Name: key.Name, Role: key.Role, AdventurerID: key.AdventurerID, GiverID: key.GiverID}, nil
+}
+
+// hash is enough for keys of 256 random bits; slow hashes are for passwords.
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
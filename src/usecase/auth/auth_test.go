@@ -0,0 +1,240 @@
+package auth
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/arfaghifari/guild-board/src/clock"
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	modelAdv "github.com/arfaghifari/guild-board/src/model/adventurer"
+	model "github.com/arfaghifari/guild-board/src/model/auth"
+	modelGiver "github.com/arfaghifari/guild-board/src/model/giver"
+	advUsecase "github.com/arfaghifari/guild-board/src/usecase/adventurer"
+	giverUsecase "github.com/arfaghifari/guild-board/src/usecase/giver"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2023, time.July, 1, 9, 0, 0, 0, time.UTC)
+
+// token is the key issued when the random source yields 32 zero bytes.
+const token = "gb_0000000000000000000000000000000000000000000000000000000000000000"
+
+func TestNewUsecase(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	res, err := NewUsecase(NewMockRepository(mockCtrl), NewAdvMockRepository(mockCtrl), NewGiverMockRepository(mockCtrl), clock.Fixed(now))
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+
+	res, err = NewUsecase(nil, NewAdvMockRepository(mockCtrl), NewGiverMockRepository(mockCtrl), clock.Fixed(now))
+	assert.Error(t, err)
+	assert.Nil(t, res)
+
+	res, err = NewUsecase(NewMockRepository(mockCtrl), nil, NewGiverMockRepository(mockCtrl), clock.Fixed(now))
+	assert.Error(t, err)
+	assert.Nil(t, res)
+
+	res, err = NewUsecase(NewMockRepository(mockCtrl), NewAdvMockRepository(mockCtrl), nil, clock.Fixed(now))
+	assert.Error(t, err)
+	assert.Nil(t, res)
+
+	res, err = NewUsecase(NewMockRepository(mockCtrl), NewAdvMockRepository(mockCtrl), NewGiverMockRepository(mockCtrl), nil)
+	assert.Error(t, err)
+	assert.Nil(t, res)
+}
+
+func TestIssueAPIKey(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	andi := model.APIKey{Name: "andi", Role: constant.RoleAdventurer, AdventurerID: 1, Hash: hash(token), CreatedAt: now}
+	staff := model.APIKey{Name: "front desk", Role: constant.RoleStaff, Hash: hash(token), CreatedAt: now}
+	dewi := model.APIKey{Name: "dewi", Role: constant.RoleGiver, GiverID: 1, Hash: hash(token), CreatedAt: now}
+	type args struct {
+		name     string
+		role     string
+		holderID int64
+	}
+	tests := []struct {
+		name      string
+		args      args
+		mock      func(*MockRepository, *AdvMockRepository, *GiverMockRepository)
+		outToken  string
+		outKey    model.APIKey
+		wantErr   error
+		wantError bool
+	}{
+		{
+			name: "success issued a staff key",
+			args: args{"front desk", constant.RoleStaff, 0},
+			mock: func(r *MockRepository, rAdv *AdvMockRepository, rGiver *GiverMockRepository) {
+				created := staff
+				created.ID = 1
+				r.EXPECT().CreateAPIKey(staff).Return(created, nil).Times(1)
+			},
+			outToken: token,
+			outKey:   model.APIKey{ID: 1, Name: "front desk", Role: constant.RoleStaff, Hash: hash(token), CreatedAt: now},
+		},
+		{
+			name: "success issued an adventurer key",
+			args: args{"andi", constant.RoleAdventurer, 1},
+			mock: func(r *MockRepository, rAdv *AdvMockRepository, rGiver *GiverMockRepository) {
+				rAdv.EXPECT().GetAdventurer(int64(1)).Return(modelAdv.Adventurer{ID: 1}, nil).Times(1)
+				r.EXPECT().CreateAPIKey(andi).Return(andi, nil).Times(1)
+			},
+			outToken: token,
+			outKey:   andi,
+		},
+		{
+			name: "success issued a quest giver key",
+			args: args{"dewi", constant.RoleGiver, 1},
+			mock: func(r *MockRepository, rAdv *AdvMockRepository, rGiver *GiverMockRepository) {
+				rGiver.EXPECT().GetGiver(int64(1)).Return(modelGiver.Giver{ID: 1}, nil).Times(1)
+				r.EXPECT().CreateAPIKey(dewi).Return(dewi, nil).Times(1)
+			},
+			outToken: token,
+			outKey:   dewi,
+		},
+		{
+			name:    "failed unknown role",
+			args:    args{"andi", "guildmaster", 0},
+			mock:    func(r *MockRepository, rAdv *AdvMockRepository, rGiver *GiverMockRepository) {},
+			wantErr: ErrInvalidKey,
+		},
+		{
+			name:    "failed no name",
+			args:    args{"", constant.RoleStaff, 0},
+			mock:    func(r *MockRepository, rAdv *AdvMockRepository, rGiver *GiverMockRepository) {},
+			wantErr: ErrInvalidKey,
+		},
+		{
+			name:    "failed adventurer key without an adventurer",
+			args:    args{"andi", constant.RoleAdventurer, 0},
+			mock:    func(r *MockRepository, rAdv *AdvMockRepository, rGiver *GiverMockRepository) {},
+			wantErr: ErrInvalidKey,
+		},
+		{
+			name:    "failed staff key with an adventurer",
+			args:    args{"front desk", constant.RoleStaff, 1},
+			mock:    func(r *MockRepository, rAdv *AdvMockRepository, rGiver *GiverMockRepository) {},
+			wantErr: ErrInvalidKey,
+		},
+		{
+			name:    "failed quest giver key without a giver",
+			args:    args{"dewi", constant.RoleGiver, 0},
+			mock:    func(r *MockRepository, rAdv *AdvMockRepository, rGiver *GiverMockRepository) {},
+			wantErr: ErrInvalidKey,
+		},
+		{
+			name: "failed unknown quest giver",
+			args: args{"dewi", constant.RoleGiver, 2},
+			mock: func(r *MockRepository, rAdv *AdvMockRepository, rGiver *GiverMockRepository) {
+				rGiver.EXPECT().GetGiver(int64(2)).Return(modelGiver.Giver{}, sql.ErrNoRows).Times(1)
+			},
+			wantErr: giverUsecase.ErrGiverNotFound,
+		},
+		{
+			name: "failed unknown adventurer",
+			args: args{"andi", constant.RoleAdventurer, 2},
+			mock: func(r *MockRepository, rAdv *AdvMockRepository, rGiver *GiverMockRepository) {
+				rAdv.EXPECT().GetAdventurer(int64(2)).Return(modelAdv.Adventurer{}, sql.ErrNoRows).Times(1)
+			},
+			wantErr: advUsecase.ErrAdventurerNotFound,
+		},
+		{
+			name: "failed to store the key",
+			args: args{"front desk", constant.RoleStaff, 0},
+			mock: func(r *MockRepository, rAdv *AdvMockRepository, rGiver *GiverMockRepository) {
+				r.EXPECT().CreateAPIKey(staff).Return(model.APIKey{}, errors.New("some error")).Times(1)
+			},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, rAdv, rGiver := NewMockRepository(mockCtrl), NewAdvMockRepository(mockCtrl), NewGiverMockRepository(mockCtrl)
+			u := &usecase{r, rAdv, rGiver, clock.Fixed(now), bytes.NewReader(make([]byte, 32))}
+			tt.mock(r, rAdv, rGiver)
+			token, key, err := u.IssueAPIKey(tt.args.name, tt.args.role, tt.args.holderID)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				assert.Equal(t, tt.wantError, err != nil)
+			}
+			assert.Equal(t, tt.outToken, token)
+			assert.Equal(t, tt.outKey, key)
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name      string
+		token     string
+		mock      func(*MockRepository)
+		out       model.Principal
+		wantErr   error
+		wantError bool
+	}{
+		{
+			name:  "success authenticated an adventurer",
+			token: token,
+			mock: func(r *MockRepository) {
+				r.EXPECT().GetAPIKeyByHash(hash(token)).Return(model.APIKey{ID: 2, Name: "andi", Role: constant.RoleAdventurer, AdventurerID: 1}, nil).Times(1)
+			},
+			out: model.Principal{KeyID: 2, Name: "andi", Role: constant.RoleAdventurer, AdventurerID: 1},
+		},
+		{
+			name:  "success authenticated a quest giver",
+			token: token,
+			mock: func(r *MockRepository) {
+				r.EXPECT().GetAPIKeyByHash(hash(token)).Return(model.APIKey{ID: 3, Name: "dewi", Role: constant.RoleGiver, GiverID: 1}, nil).Times(1)
+			},
+			out: model.Principal{KeyID: 3, Name: "dewi", Role: constant.RoleGiver, GiverID: 1},
+		},
+		{
+			name:    "failed not a guild board key",
+			token:   "secret",
+			mock:    func(r *MockRepository) {},
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name:  "failed unknown key",
+			token: token,
+			mock: func(r *MockRepository) {
+				r.EXPECT().GetAPIKeyByHash(hash(token)).Return(model.APIKey{}, sql.ErrNoRows).Times(1)
+			},
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name:  "failed to read the key",
+			token: token,
+			mock: func(r *MockRepository) {
+				r.EXPECT().GetAPIKeyByHash(hash(token)).Return(model.APIKey{}, errors.New("some error")).Times(1)
+			},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewMockRepository(mockCtrl)
+			u, _ := NewUsecase(r, NewAdvMockRepository(mockCtrl), NewGiverMockRepository(mockCtrl), clock.Fixed(now))
+			tt.mock(r)
+			res, err := u.Authenticate(tt.token)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				assert.Equal(t, tt.wantError, err != nil)
+			}
+			assert.Equal(t, tt.out, res)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package giver
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/clock"
+	model "github.com/arfaghifari/guild-board/src/model/giver"
+	modelQuest "github.com/arfaghifari/guild-board/src/model/quest"
+	repo "github.com/arfaghifari/guild-board/src/repository/giver"
+	questUsecase "github.com/arfaghifari/guild-board/src/usecase/quest"
+)
+
+type Usecase interface {
+	CreateGiver(model.Giver) (model.Giver, error)
+	GetGiver(int64) (model.Giver, error)
+	ListGiverQuests(int64, modelQuest.QuestFilter) ([]modelQuest.PostedQuest, int, error)
+}
+
+var ErrGiverNotFound = apperror.NewNotFound("giver_not_found", "quest giver not found")
+
+type usecase struct {
+	repo   repo.Repository
+	quests questUsecase.Usecase
+	clock  clock.Clock
+}
+
+var errMissingDependency = errors.New("giver usecase needs a repository, the quest usecase and a clock")
+
+func NewUsecase(repo repo.Repository, quests questUsecase.Usecase, clock clock.Clock) (Usecase, error) {
+	if repo == nil || quests == nil || clock == nil {
+		return nil, errMissingDependency
+	}
+
+	return &usecase{repo, quests, clock}, nil
+}
+
+func (u *usecase) CreateGiver(giver model.Giver) (model.Giver, error) {
+	giver.CreatedAt = u.clock.Now()
+	return u.repo.CreateGiver(giver)
+}
+
+func (u *usecase) GetGiver(id int64) (model.Giver, error) {
+	giver, err := u.repo.GetGiver(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return giver, ErrGiverNotFound
+	}
+	return giver, err
+}
+
+// ListGiverQuests returns one page of the quests posted by the giver, as
+// ListPostedQuests of the quest usecase does for any filter.
+func (u *usecase) ListGiverQuests(id int64, filter modelQuest.QuestFilter) ([]modelQuest.PostedQuest, int, error) {
+	if _, err := u.GetGiver(id); err != nil {
+		return nil, 0, err
+	}
+	filter.CreatedBy = id
+	return u.quests.ListPostedQuests(filter)
+}
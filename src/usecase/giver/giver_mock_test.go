@@ -0,0 +1,77 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: giver.go
+
+// Package mock_giver is a generated GoMock package.
+package giver
+
+import (
+	reflect "reflect"
+
+	giver "github.com/arfaghifari/guild-board/src/model/giver"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockRepository) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRepositoryMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRepository)(nil).Close))
+}
+
+// CreateGiver mocks base method.
+func (m *MockRepository) CreateGiver(arg0 giver.Giver) (giver.Giver, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGiver", arg0)
+	ret0, _ := ret[0].(giver.Giver)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGiver indicates an expected call of CreateGiver.
+func (mr *MockRepositoryMockRecorder) CreateGiver(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGiver", reflect.TypeOf((*MockRepository)(nil).CreateGiver), arg0)
+}
+
+// GetGiver mocks base method.
+func (m *MockRepository) GetGiver(arg0 int64) (giver.Giver, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGiver", arg0)
+	ret0, _ := ret[0].(giver.Giver)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGiver indicates an expected call of GetGiver.
+func (mr *MockRepositoryMockRecorder) GetGiver(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGiver", reflect.TypeOf((*MockRepository)(nil).GetGiver), arg0)
+}
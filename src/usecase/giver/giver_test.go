@@ -0,0 +1,176 @@
+package giver
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/arfaghifari/guild-board/src/clock"
+	model "github.com/arfaghifari/guild-board/src/model/giver"
+	modelQuest "github.com/arfaghifari/guild-board/src/model/quest"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2023, time.July, 1, 9, 0, 0, 0, time.UTC)
+
+var mayor = model.Giver{ID: 1, Name: "mayor of riverwood", CreatedAt: now}
+
+func TestNewUsecase(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	res, err := NewUsecase(NewMockRepository(mockCtrl), NewQuestMockUsecase(mockCtrl), clock.Fixed(now))
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+
+	res, err = NewUsecase(nil, NewQuestMockUsecase(mockCtrl), clock.Fixed(now))
+	assert.Error(t, err)
+	assert.Nil(t, res)
+
+	res, err = NewUsecase(NewMockRepository(mockCtrl), nil, clock.Fixed(now))
+	assert.Error(t, err)
+	assert.Nil(t, res)
+
+	res, err = NewUsecase(NewMockRepository(mockCtrl), NewQuestMockUsecase(mockCtrl), nil)
+	assert.Error(t, err)
+	assert.Nil(t, res)
+}
+
+func TestCreateGiver(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name     string
+		mock     func(*MockRepository)
+		outGiver model.Giver
+		wantErr  bool
+	}{
+		{
+			name: "success created a quest giver",
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().CreateGiver(model.Giver{Name: mayor.Name, CreatedAt: now}).Return(mayor, nil).Times(1)
+			},
+			outGiver: mayor,
+		},
+		{
+			name: "failed",
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().CreateGiver(gomock.Any()).Return(model.Giver{}, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewMockRepository(mockCtrl)
+			tt.mock(r)
+			u := &usecase{repo: r, clock: clock.Fixed(now)}
+			res, err := u.CreateGiver(model.Giver{Name: mayor.Name})
+			assert.Equal(t, tt.outGiver, res)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+		})
+	}
+}
+
+func TestGetGiver(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name     string
+		mock     func(*MockRepository)
+		outGiver model.Giver
+		wantErr  error
+	}{
+		{
+			name: "success get a quest giver",
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetGiver(mayor.ID).Return(mayor, nil).Times(1)
+			},
+			outGiver: mayor,
+		},
+		{
+			name: "failed not found",
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetGiver(mayor.ID).Return(model.Giver{ID: mayor.ID}, sql.ErrNoRows).Times(1)
+			},
+			outGiver: model.Giver{ID: mayor.ID},
+			wantErr:  ErrGiverNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewMockRepository(mockCtrl)
+			tt.mock(r)
+			u := &usecase{repo: r}
+			res, err := u.GetGiver(mayor.ID)
+			assert.Equal(t, tt.outGiver, res)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestListGiverQuests(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	posted := []modelQuest.PostedQuest{{
+		Quest:  modelQuest.Quest{ID: 1, Name: "menyelamatkan kucing", CreatedBy: mayor.ID},
+		Takers: []modelQuest.Taker{{AdventurerID: 1, Name: "andi", Rank: 11}},
+	}}
+	tests := []struct {
+		name      string
+		mock      func(*MockRepository, *QuestMockUsecase)
+		outPosted []modelQuest.PostedQuest
+		outTotal  int
+		wantErr   bool
+	}{
+		{
+			name: "success lists the quests of the giver",
+			mock: func(repo *MockRepository, quests *QuestMockUsecase) {
+				repo.EXPECT().GetGiver(mayor.ID).Return(mayor, nil).Times(1)
+				quests.EXPECT().ListPostedQuests(modelQuest.QuestFilter{CreatedBy: mayor.ID, Limit: 10}).Return(posted, 1, nil).Times(1)
+			},
+			outPosted: posted,
+			outTotal:  1,
+		},
+		{
+			name: "failed unknown giver",
+			mock: func(repo *MockRepository, quests *QuestMockUsecase) {
+				repo.EXPECT().GetGiver(mayor.ID).Return(model.Giver{ID: mayor.ID}, sql.ErrNoRows).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed list quests",
+			mock: func(repo *MockRepository, quests *QuestMockUsecase) {
+				repo.EXPECT().GetGiver(mayor.ID).Return(mayor, nil).Times(1)
+				quests.EXPECT().ListPostedQuests(gomock.Any()).Return(nil, 0, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewMockRepository(mockCtrl)
+			quests := NewQuestMockUsecase(mockCtrl)
+			tt.mock(r, quests)
+			u := &usecase{repo: r, quests: quests}
+			res, total, err := u.ListGiverQuests(mayor.ID, modelQuest.QuestFilter{CreatedBy: 2, Limit: 10})
+			assert.Equal(t, tt.outPosted, res)
+			assert.Equal(t, tt.outTotal, total)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+		})
+	}
+}
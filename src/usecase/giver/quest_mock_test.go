@@ -0,0 +1,274 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: quest.go
+
+// Package mock_quest is a generated GoMock package.
+package giver
+
+import (
+	reflect "reflect"
+	time "time"
+
+	quest "github.com/arfaghifari/guild-board/src/model/quest"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// QuestMockUsecase is a mock of Usecase interface.
+type QuestMockUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *QuestMockUsecaseMockRecorder
+}
+
+// QuestMockUsecaseMockRecorder is the mock recorder for QuestMockUsecase.
+type QuestMockUsecaseMockRecorder struct {
+	mock *QuestMockUsecase
+}
+
+// NewQuestMockUsecase creates a new mock instance.
+func NewQuestMockUsecase(ctrl *gomock.Controller) *QuestMockUsecase {
+	mock := &QuestMockUsecase{ctrl: ctrl}
+	mock.recorder = &QuestMockUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *QuestMockUsecase) EXPECT() *QuestMockUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CancelQuest mocks base method.
+func (m *QuestMockUsecase) CancelQuest(arg0 int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelQuest", arg0)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelQuest indicates an expected call of CancelQuest.
+func (mr *QuestMockUsecaseMockRecorder) CancelQuest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelQuest", reflect.TypeOf((*QuestMockUsecase)(nil).CancelQuest), arg0)
+}
+
+// CreateQuest mocks base method.
+func (m *QuestMockUsecase) CreateQuest(arg0 quest.Quest) (quest.Quest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQuest", arg0)
+	ret0, _ := ret[0].(quest.Quest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQuest indicates an expected call of CreateQuest.
+func (mr *QuestMockUsecaseMockRecorder) CreateQuest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuest", reflect.TypeOf((*QuestMockUsecase)(nil).CreateQuest), arg0)
+}
+
+// DeleteQuest mocks base method.
+func (m *QuestMockUsecase) DeleteQuest(arg0 quest.Quest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQuest", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteQuest indicates an expected call of DeleteQuest.
+func (mr *QuestMockUsecaseMockRecorder) DeleteQuest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuest", reflect.TypeOf((*QuestMockUsecase)(nil).DeleteQuest), arg0)
+}
+
+// ExpireOverdueQuests mocks base method.
+func (m *QuestMockUsecase) ExpireOverdueQuests(arg0 time.Duration) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireOverdueQuests", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ExpireOverdueQuests indicates an expected call of ExpireOverdueQuests.
+func (mr *QuestMockUsecaseMockRecorder) ExpireOverdueQuests(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireOverdueQuests", reflect.TypeOf((*QuestMockUsecase)(nil).ExpireOverdueQuests), arg0)
+}
+
+// GetQuest mocks base method.
+func (m *QuestMockUsecase) GetQuest(arg0 int64) (quest.Quest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuest", arg0)
+	ret0, _ := ret[0].(quest.Quest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuest indicates an expected call of GetQuest.
+func (mr *QuestMockUsecaseMockRecorder) GetQuest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuest", reflect.TypeOf((*QuestMockUsecase)(nil).GetQuest), arg0)
+}
+
+// GetQuestActiveAdventurer mocks base method.
+func (m *QuestMockUsecase) GetQuestActiveAdventurer(arg0 int64) ([]quest.Quest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestActiveAdventurer", arg0)
+	ret0, _ := ret[0].([]quest.Quest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestActiveAdventurer indicates an expected call of GetQuestActiveAdventurer.
+func (mr *QuestMockUsecaseMockRecorder) GetQuestActiveAdventurer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestActiveAdventurer", reflect.TypeOf((*QuestMockUsecase)(nil).GetQuestActiveAdventurer), arg0)
+}
+
+// GetQuestByStatus mocks base method.
+func (m *QuestMockUsecase) GetQuestByStatus(arg0 int32) ([]quest.GetQuestByStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestByStatus", arg0)
+	ret0, _ := ret[0].([]quest.GetQuestByStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestByStatus indicates an expected call of GetQuestByStatus.
+func (mr *QuestMockUsecaseMockRecorder) GetQuestByStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestByStatus", reflect.TypeOf((*QuestMockUsecase)(nil).GetQuestByStatus), arg0)
+}
+
+// GetTakers mocks base method.
+func (m *QuestMockUsecase) GetTakers(arg0 int64) ([]quest.TakenBy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTakers", arg0)
+	ret0, _ := ret[0].([]quest.TakenBy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTakers indicates an expected call of GetTakers.
+func (mr *QuestMockUsecaseMockRecorder) GetTakers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTakers", reflect.TypeOf((*QuestMockUsecase)(nil).GetTakers), arg0)
+}
+
+// ListAssignments mocks base method.
+func (m *QuestMockUsecase) ListAssignments(arg0 quest.AssignmentFilter) ([]quest.Assignment, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAssignments", arg0)
+	ret0, _ := ret[0].([]quest.Assignment)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAssignments indicates an expected call of ListAssignments.
+func (mr *QuestMockUsecaseMockRecorder) ListAssignments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssignments", reflect.TypeOf((*QuestMockUsecase)(nil).ListAssignments), arg0)
+}
+
+// ListPostedQuests mocks base method.
+func (m *QuestMockUsecase) ListPostedQuests(arg0 quest.QuestFilter) ([]quest.PostedQuest, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPostedQuests", arg0)
+	ret0, _ := ret[0].([]quest.PostedQuest)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListPostedQuests indicates an expected call of ListPostedQuests.
+func (mr *QuestMockUsecaseMockRecorder) ListPostedQuests(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostedQuests", reflect.TypeOf((*QuestMockUsecase)(nil).ListPostedQuests), arg0)
+}
+
+// ListQuests mocks base method.
+func (m *QuestMockUsecase) ListQuests(arg0 quest.QuestFilter) ([]quest.Quest, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQuests", arg0)
+	ret0, _ := ret[0].([]quest.Quest)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListQuests indicates an expected call of ListQuests.
+func (mr *QuestMockUsecaseMockRecorder) ListQuests(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQuests", reflect.TypeOf((*QuestMockUsecase)(nil).ListQuests), arg0)
+}
+
+// PatchQuest mocks base method.
+func (m *QuestMockUsecase) PatchQuest(arg0 quest.Quest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchQuest", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchQuest indicates an expected call of PatchQuest.
+func (mr *QuestMockUsecaseMockRecorder) PatchQuest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchQuest", reflect.TypeOf((*QuestMockUsecase)(nil).PatchQuest), arg0)
+}
+
+// ReportQuest mocks base method.
+func (m *QuestMockUsecase) ReportQuest(arg0, arg1 int64, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportQuest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportQuest indicates an expected call of ReportQuest.
+func (mr *QuestMockUsecaseMockRecorder) ReportQuest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportQuest", reflect.TypeOf((*QuestMockUsecase)(nil).ReportQuest), arg0, arg1, arg2)
+}
+
+// TakeQuest mocks base method.
+func (m *QuestMockUsecase) TakeQuest(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeQuest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TakeQuest indicates an expected call of TakeQuest.
+func (mr *QuestMockUsecaseMockRecorder) TakeQuest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeQuest", reflect.TypeOf((*QuestMockUsecase)(nil).TakeQuest), arg0, arg1)
+}
+
+// UpdateQuestRank mocks base method.
+func (m *QuestMockUsecase) UpdateQuestRank(arg0 quest.Quest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuestRank", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQuestRank indicates an expected call of UpdateQuestRank.
+func (mr *QuestMockUsecaseMockRecorder) UpdateQuestRank(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuestRank", reflect.TypeOf((*QuestMockUsecase)(nil).UpdateQuestRank), arg0)
+}
+
+// UpdateQuestReward mocks base method.
+func (m *QuestMockUsecase) UpdateQuestReward(arg0 quest.Quest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuestReward", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQuestReward indicates an expected call of UpdateQuestReward.
+func (mr *QuestMockUsecaseMockRecorder) UpdateQuestReward(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuestReward", reflect.TypeOf((*QuestMockUsecase)(nil).UpdateQuestReward), arg0)
+}
@@ -0,0 +1,77 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: giver.go
+
+// Package mock_giver is a generated GoMock package.
+package ledger
+
+import (
+	reflect "reflect"
+
+	giver "github.com/arfaghifari/guild-board/src/model/giver"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// GiverMockRepository is a mock of Repository interface.
+type GiverMockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *GiverMockRepositoryMockRecorder
+}
+
+// GiverMockRepositoryMockRecorder is the mock recorder for GiverMockRepository.
+type GiverMockRepositoryMockRecorder struct {
+	mock *GiverMockRepository
+}
+
+// NewGiverMockRepository creates a new mock instance.
+func NewGiverMockRepository(ctrl *gomock.Controller) *GiverMockRepository {
+	mock := &GiverMockRepository{ctrl: ctrl}
+	mock.recorder = &GiverMockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *GiverMockRepository) EXPECT() *GiverMockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *GiverMockRepository) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *GiverMockRepositoryMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*GiverMockRepository)(nil).Close))
+}
+
+// CreateGiver mocks base method.
+func (m *GiverMockRepository) CreateGiver(arg0 giver.Giver) (giver.Giver, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGiver", arg0)
+	ret0, _ := ret[0].(giver.Giver)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGiver indicates an expected call of CreateGiver.
+func (mr *GiverMockRepositoryMockRecorder) CreateGiver(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGiver", reflect.TypeOf((*GiverMockRepository)(nil).CreateGiver), arg0)
+}
+
+// GetGiver mocks base method.
+func (m *GiverMockRepository) GetGiver(arg0 int64) (giver.Giver, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGiver", arg0)
+	ret0, _ := ret[0].(giver.Giver)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGiver indicates an expected call of GetGiver.
+func (mr *GiverMockRepositoryMockRecorder) GetGiver(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGiver", reflect.TypeOf((*GiverMockRepository)(nil).GetGiver), arg0)
+}
@@ -0,0 +1,94 @@
+package ledger
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	model "github.com/arfaghifari/guild-board/src/model/ledger"
+	repoAdv "github.com/arfaghifari/guild-board/src/repository/adventurer"
+	repoGiver "github.com/arfaghifari/guild-board/src/repository/giver"
+	repo "github.com/arfaghifari/guild-board/src/repository/ledger"
+	repoQuest "github.com/arfaghifari/guild-board/src/repository/quest"
+	advUsecase "github.com/arfaghifari/guild-board/src/usecase/adventurer"
+	giverUsecase "github.com/arfaghifari/guild-board/src/usecase/giver"
+	questUsecase "github.com/arfaghifari/guild-board/src/usecase/quest"
+)
+
+// Usecase reads the balances of the ledger and the payouts of completed
+// quests. Money is moved by the quest usecase as quests are posted, completed
+// and closed.
+type Usecase interface {
+	GetGuildBalance() (model.Balance, error)
+	GetGiverBalance(int64) (model.Balance, error)
+	GetAdventurerBalance(int64) (model.Balance, error)
+	GetQuestEscrow(int64) (model.Balance, error)
+	GetQuestPayout(int64) (model.Payout, error)
+}
+
+type usecase struct {
+	repo      repo.Repository
+	repoQuest repoQuest.Repository
+	repoAdv   repoAdv.Repository
+	repoGiver repoGiver.Repository
+}
+
+var ErrPayoutNotFound = apperror.NewNotFound("payout_not_found", "quest has not been paid out")
+
+var errMissingDependency = errors.New("ledger usecase needs the ledger, quest, adventurer and giver repositories")
+
+func NewUsecase(repo repo.Repository, repoQuest repoQuest.Repository, repoAdv repoAdv.Repository, repoGiver repoGiver.Repository) (Usecase, error) {
+	if repo == nil || repoQuest == nil || repoAdv == nil || repoGiver == nil {
+		return nil, errMissingDependency
+	}
+
+	return &usecase{repo, repoQuest, repoAdv, repoGiver}, nil
+}
+
+func (u *usecase) GetGuildBalance() (model.Balance, error) {
+	return u.repo.GetBalance(model.Guild)
+}
+
+func (u *usecase) GetGiverBalance(id int64) (model.Balance, error) {
+	if _, err := u.repoGiver.GetGiver(id); err != nil {
+		return model.Balance{}, found(err, giverUsecase.ErrGiverNotFound)
+	}
+	return u.repo.GetBalance(model.GiverAccount(id))
+}
+
+func (u *usecase) GetAdventurerBalance(id int64) (model.Balance, error) {
+	if _, err := u.repoAdv.GetAdventurer(id); err != nil {
+		return model.Balance{}, found(err, advUsecase.ErrAdventurerNotFound)
+	}
+	return u.repo.GetBalance(model.AdventurerAccount(id))
+}
+
+// GetQuestEscrow returns what the escrow of a quest holds: its reward while
+// the quest is open, nothing once it is paid or refunded.
+func (u *usecase) GetQuestEscrow(id int64) (model.Balance, error) {
+	if _, err := u.repoQuest.GetQuest(id); err != nil {
+		return model.Balance{}, found(err, questUsecase.ErrQuestNotFound)
+	}
+	return u.repo.GetBalance(model.EscrowAccount(id))
+}
+
+// GetQuestPayout returns how the reward of a completed quest was split between
+// the guild fee and its party.
+func (u *usecase) GetQuestPayout(id int64) (model.Payout, error) {
+	if _, err := u.repoQuest.GetQuest(id); err != nil {
+		return model.Payout{}, found(err, questUsecase.ErrQuestNotFound)
+	}
+	payout, err := u.repo.GetPayout(id)
+	if err != nil {
+		return model.Payout{}, found(err, ErrPayoutNotFound)
+	}
+	return payout, nil
+}
+
+// found replaces the missing row error of a lookup with notFound.
+func found(err, notFound error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFound
+	}
+	return err
+}
@@ -0,0 +1,106 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ledger.go
+
+// Package mock_ledger is a generated GoMock package.
+package ledger
+
+import (
+	reflect "reflect"
+
+	ledger "github.com/arfaghifari/guild-board/src/model/ledger"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockRepository) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRepositoryMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRepository)(nil).Close))
+}
+
+// GetBalance mocks base method.
+func (m *MockRepository) GetBalance(arg0 ledger.Account) (ledger.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", arg0)
+	ret0, _ := ret[0].(ledger.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockRepositoryMockRecorder) GetBalance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockRepository)(nil).GetBalance), arg0)
+}
+
+// GetPayout mocks base method.
+func (m *MockRepository) GetPayout(questID int64) (ledger.Payout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayout", questID)
+	ret0, _ := ret[0].(ledger.Payout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayout indicates an expected call of GetPayout.
+func (mr *MockRepositoryMockRecorder) GetPayout(questID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayout", reflect.TypeOf((*MockRepository)(nil).GetPayout), questID)
+}
+
+// PostTransaction mocks base method.
+func (m *MockRepository) PostTransaction(arg0 ledger.Transaction) (ledger.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostTransaction", arg0)
+	ret0, _ := ret[0].(ledger.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostTransaction indicates an expected call of PostTransaction.
+func (mr *MockRepositoryMockRecorder) PostTransaction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostTransaction", reflect.TypeOf((*MockRepository)(nil).PostTransaction), arg0)
+}
+
+// SavePayout mocks base method.
+func (m *MockRepository) SavePayout(arg0 ledger.Payout) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePayout", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePayout indicates an expected call of SavePayout.
+func (mr *MockRepositoryMockRecorder) SavePayout(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePayout", reflect.TypeOf((*MockRepository)(nil).SavePayout), arg0)
+}
@@ -0,0 +1,257 @@
+package ledger
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	modelAdv "github.com/arfaghifari/guild-board/src/model/adventurer"
+	modelGiver "github.com/arfaghifari/guild-board/src/model/giver"
+	model "github.com/arfaghifari/guild-board/src/model/ledger"
+	modelQuest "github.com/arfaghifari/guild-board/src/model/quest"
+	repoAdv "github.com/arfaghifari/guild-board/src/repository/adventurer"
+	repoGiver "github.com/arfaghifari/guild-board/src/repository/giver"
+	repo "github.com/arfaghifari/guild-board/src/repository/ledger"
+	repoQuest "github.com/arfaghifari/guild-board/src/repository/quest"
+	advUsecase "github.com/arfaghifari/guild-board/src/usecase/adventurer"
+	giverUsecase "github.com/arfaghifari/guild-board/src/usecase/giver"
+	questUsecase "github.com/arfaghifari/guild-board/src/usecase/quest"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUsecase(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ledgerRepo := NewMockRepository(mockCtrl)
+	questRepo := NewQuestMockRepository(mockCtrl)
+	advRepo := NewAdvMockRepository(mockCtrl)
+	giverRepo := NewGiverMockRepository(mockCtrl)
+	tests := []struct {
+		name      string
+		repo      repo.Repository
+		repoQuest repoQuest.Repository
+		repoAdv   repoAdv.Repository
+		repoGiver repoGiver.Repository
+		wantErr   bool
+	}{
+		{name: "all dependencies", repo: ledgerRepo, repoQuest: questRepo, repoAdv: advRepo, repoGiver: giverRepo},
+		{name: "missing ledger repository", repoQuest: questRepo, repoAdv: advRepo, repoGiver: giverRepo, wantErr: true},
+		{name: "missing quest repository", repo: ledgerRepo, repoAdv: advRepo, repoGiver: giverRepo, wantErr: true},
+		{name: "missing adventurer repository", repo: ledgerRepo, repoQuest: questRepo, repoGiver: giverRepo, wantErr: true},
+		{name: "missing giver repository", repo: ledgerRepo, repoQuest: questRepo, repoAdv: advRepo, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := NewUsecase(tt.repo, tt.repoQuest, tt.repoAdv, tt.repoGiver)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, res)
+			}
+		})
+	}
+}
+
+type mocks struct {
+	ledger *MockRepository
+	quest  *QuestMockRepository
+	adv    *AdvMockRepository
+	giver  *GiverMockRepository
+}
+
+func TestGetBalance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	balance := func(account model.Account, amount int64) model.Balance {
+		return model.Balance{Account: account, Balance: amount}
+	}
+	tests := []struct {
+		name       string
+		get        func(Usecase) (model.Balance, error)
+		mock       func(mocks)
+		outBalance model.Balance
+		outErr     error
+		wantErr    bool
+	}{
+		{
+			name: "success get the guild balance",
+			get:  func(u Usecase) (model.Balance, error) { return u.GetGuildBalance() },
+			mock: func(m mocks) {
+				m.ledger.EXPECT().GetBalance(model.Guild).Return(balance(model.Guild, -500), nil).Times(1)
+			},
+			outBalance: balance(model.Guild, -500),
+		},
+		{
+			name: "success get a giver balance",
+			get:  func(u Usecase) (model.Balance, error) { return u.GetGiverBalance(1) },
+			mock: func(m mocks) {
+				m.giver.EXPECT().GetGiver(int64(1)).Return(modelGiver.Giver{ID: 1}, nil).Times(1)
+				m.ledger.EXPECT().GetBalance(model.GiverAccount(1)).Return(balance(model.GiverAccount(1), -300), nil).Times(1)
+			},
+			outBalance: balance(model.GiverAccount(1), -300),
+		},
+		{
+			name: "failed giver not found",
+			get:  func(u Usecase) (model.Balance, error) { return u.GetGiverBalance(9) },
+			mock: func(m mocks) {
+				m.giver.EXPECT().GetGiver(int64(9)).Return(modelGiver.Giver{ID: 9}, sql.ErrNoRows).Times(1)
+			},
+			outErr:  giverUsecase.ErrGiverNotFound,
+			wantErr: true,
+		},
+		{
+			name: "success get an adventurer balance",
+			get:  func(u Usecase) (model.Balance, error) { return u.GetAdventurerBalance(1) },
+			mock: func(m mocks) {
+				m.adv.EXPECT().GetAdventurer(int64(1)).Return(modelAdv.Adventurer{ID: 1}, nil).Times(1)
+				m.ledger.EXPECT().GetBalance(model.AdventurerAccount(1)).Return(balance(model.AdventurerAccount(1), 150), nil).Times(1)
+			},
+			outBalance: balance(model.AdventurerAccount(1), 150),
+		},
+		{
+			name: "failed adventurer not found",
+			get:  func(u Usecase) (model.Balance, error) { return u.GetAdventurerBalance(9) },
+			mock: func(m mocks) {
+				m.adv.EXPECT().GetAdventurer(int64(9)).Return(modelAdv.Adventurer{}, sql.ErrNoRows).Times(1)
+			},
+			outErr:  advUsecase.ErrAdventurerNotFound,
+			wantErr: true,
+		},
+		{
+			name: "success get a quest escrow",
+			get:  func(u Usecase) (model.Balance, error) { return u.GetQuestEscrow(1) },
+			mock: func(m mocks) {
+				m.quest.EXPECT().GetQuest(int64(1)).Return(modelQuest.Quest{ID: 1}, nil).Times(1)
+				m.ledger.EXPECT().GetBalance(model.EscrowAccount(1)).Return(balance(model.EscrowAccount(1), 200), nil).Times(1)
+			},
+			outBalance: balance(model.EscrowAccount(1), 200),
+		},
+		{
+			name: "failed quest not found",
+			get:  func(u Usecase) (model.Balance, error) { return u.GetQuestEscrow(9) },
+			mock: func(m mocks) {
+				m.quest.EXPECT().GetQuest(int64(9)).Return(modelQuest.Quest{}, sql.ErrNoRows).Times(1)
+			},
+			outErr:  questUsecase.ErrQuestNotFound,
+			wantErr: true,
+		},
+		{
+			name: "failed get quest",
+			get:  func(u Usecase) (model.Balance, error) { return u.GetQuestEscrow(1) },
+			mock: func(m mocks) {
+				m.quest.EXPECT().GetQuest(int64(1)).Return(modelQuest.Quest{}, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed get balance",
+			get:  func(u Usecase) (model.Balance, error) { return u.GetGuildBalance() },
+			mock: func(m mocks) {
+				m.ledger.EXPECT().GetBalance(model.Guild).Return(model.Balance{}, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mocks{
+				ledger: NewMockRepository(mockCtrl),
+				quest:  NewQuestMockRepository(mockCtrl),
+				adv:    NewAdvMockRepository(mockCtrl),
+				giver:  NewGiverMockRepository(mockCtrl),
+			}
+			u := &usecase{m.ledger, m.quest, m.adv, m.giver}
+			tt.mock(m)
+			res, err := tt.get(u)
+			assert.Equal(t, tt.outBalance, res)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			if tt.outErr != nil {
+				assert.Equal(t, tt.outErr, err, tt.name)
+			}
+		})
+	}
+}
+
+func TestGetQuestPayout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	payout := model.Payout{QuestID: 1, Gross: 200000, GuildFee: 20000, Net: 180000, FeePercent: 10}
+	tests := []struct {
+		name      string
+		questID   int64
+		mock      func(mocks)
+		outPayout model.Payout
+		outErr    error
+		wantErr   bool
+	}{
+		{
+			name:    "success get a quest payout",
+			questID: 1,
+			mock: func(m mocks) {
+				m.quest.EXPECT().GetQuest(int64(1)).Return(modelQuest.Quest{ID: 1}, nil).Times(1)
+				m.ledger.EXPECT().GetPayout(int64(1)).Return(payout, nil).Times(1)
+			},
+			outPayout: payout,
+		},
+		{
+			name:    "failed quest not found",
+			questID: 9,
+			mock: func(m mocks) {
+				m.quest.EXPECT().GetQuest(int64(9)).Return(modelQuest.Quest{}, sql.ErrNoRows).Times(1)
+			},
+			outErr:  questUsecase.ErrQuestNotFound,
+			wantErr: true,
+		},
+		{
+			name:    "failed quest not paid out",
+			questID: 2,
+			mock: func(m mocks) {
+				m.quest.EXPECT().GetQuest(int64(2)).Return(modelQuest.Quest{ID: 2}, nil).Times(1)
+				m.ledger.EXPECT().GetPayout(int64(2)).Return(model.Payout{QuestID: 2}, sql.ErrNoRows).Times(1)
+			},
+			outErr:  ErrPayoutNotFound,
+			wantErr: true,
+		},
+		{
+			name:    "failed get payout",
+			questID: 1,
+			mock: func(m mocks) {
+				m.quest.EXPECT().GetQuest(int64(1)).Return(modelQuest.Quest{ID: 1}, nil).Times(1)
+				m.ledger.EXPECT().GetPayout(int64(1)).Return(model.Payout{}, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mocks{
+				ledger: NewMockRepository(mockCtrl),
+				quest:  NewQuestMockRepository(mockCtrl),
+				adv:    NewAdvMockRepository(mockCtrl),
+				giver:  NewGiverMockRepository(mockCtrl),
+			}
+			u := &usecase{m.ledger, m.quest, m.adv, m.giver}
+			tt.mock(m)
+			res, err := u.GetQuestPayout(tt.questID)
+			assert.Equal(t, tt.outPayout, res)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			if tt.outErr != nil {
+				assert.Equal(t, tt.outErr, err, tt.name)
+			}
+		})
+	}
+}
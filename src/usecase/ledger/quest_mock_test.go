@@ -0,0 +1,410 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: quest.go
+
+// Package mock_quest is a generated GoMock package.
+package ledger
+
+import (
+	reflect "reflect"
+	time "time"
+
+	quest "github.com/arfaghifari/guild-board/src/model/quest"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// QuestMockRepository is a mock of Repository interface.
+type QuestMockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *QuestMockRepositoryMockRecorder
+}
+
+// QuestMockRepositoryMockRecorder is the mock recorder for QuestMockRepository.
+type QuestMockRepositoryMockRecorder struct {
+	mock *QuestMockRepository
+}
+
+// NewQuestMockRepository creates a new mock instance.
+func NewQuestMockRepository(ctrl *gomock.Controller) *QuestMockRepository {
+	mock := &QuestMockRepository{ctrl: ctrl}
+	mock.recorder = &QuestMockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *QuestMockRepository) EXPECT() *QuestMockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *QuestMockRepository) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *QuestMockRepositoryMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*QuestMockRepository)(nil).Close))
+}
+
+// CreateAssignment mocks base method.
+func (m *QuestMockRepository) CreateAssignment(arg0 quest.Assignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAssignment", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAssignment indicates an expected call of CreateAssignment.
+func (mr *QuestMockRepositoryMockRecorder) CreateAssignment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAssignment", reflect.TypeOf((*QuestMockRepository)(nil).CreateAssignment), arg0)
+}
+
+// CreateQuest mocks base method.
+func (m *QuestMockRepository) CreateQuest(arg0 quest.Quest) (quest.Quest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQuest", arg0)
+	ret0, _ := ret[0].(quest.Quest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQuest indicates an expected call of CreateQuest.
+func (mr *QuestMockRepositoryMockRecorder) CreateQuest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuest", reflect.TypeOf((*QuestMockRepository)(nil).CreateQuest), arg0)
+}
+
+// CreateTakenBy mocks base method.
+func (m *QuestMockRepository) CreateTakenBy(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTakenBy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTakenBy indicates an expected call of CreateTakenBy.
+func (mr *QuestMockRepositoryMockRecorder) CreateTakenBy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTakenBy", reflect.TypeOf((*QuestMockRepository)(nil).CreateTakenBy), arg0, arg1)
+}
+
+// DeleteQuest mocks base method.
+func (m *QuestMockRepository) DeleteQuest(arg0 quest.Quest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQuest", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteQuest indicates an expected call of DeleteQuest.
+func (mr *QuestMockRepositoryMockRecorder) DeleteQuest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuest", reflect.TypeOf((*QuestMockRepository)(nil).DeleteQuest), arg0)
+}
+
+// DeleteTakenBy mocks base method.
+func (m *QuestMockRepository) DeleteTakenBy(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTakenBy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTakenBy indicates an expected call of DeleteTakenBy.
+func (mr *QuestMockRepositoryMockRecorder) DeleteTakenBy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTakenBy", reflect.TypeOf((*QuestMockRepository)(nil).DeleteTakenBy), arg0, arg1)
+}
+
+// FinishAssignment mocks base method.
+func (m *QuestMockRepository) FinishAssignment(arg0 quest.Assignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishAssignment", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishAssignment indicates an expected call of FinishAssignment.
+func (mr *QuestMockRepositoryMockRecorder) FinishAssignment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishAssignment", reflect.TypeOf((*QuestMockRepository)(nil).FinishAssignment), arg0)
+}
+
+// GetOverdueQuests mocks base method.
+func (m *QuestMockRepository) GetOverdueQuests(arg0, arg1 time.Time) ([]quest.Quest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdueQuests", arg0, arg1)
+	ret0, _ := ret[0].([]quest.Quest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdueQuests indicates an expected call of GetOverdueQuests.
+func (mr *QuestMockRepositoryMockRecorder) GetOverdueQuests(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueQuests", reflect.TypeOf((*QuestMockRepository)(nil).GetOverdueQuests), arg0, arg1)
+}
+
+// GetQuest mocks base method.
+func (m *QuestMockRepository) GetQuest(arg0 int64) (quest.Quest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuest", arg0)
+	ret0, _ := ret[0].(quest.Quest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuest indicates an expected call of GetQuest.
+func (mr *QuestMockRepositoryMockRecorder) GetQuest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuest", reflect.TypeOf((*QuestMockRepository)(nil).GetQuest), arg0)
+}
+
+// GetQuestActiveAdventurer mocks base method.
+func (m *QuestMockRepository) GetQuestActiveAdventurer(arg0 int64) ([]quest.Quest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestActiveAdventurer", arg0)
+	ret0, _ := ret[0].([]quest.Quest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestActiveAdventurer indicates an expected call of GetQuestActiveAdventurer.
+func (mr *QuestMockRepositoryMockRecorder) GetQuestActiveAdventurer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestActiveAdventurer", reflect.TypeOf((*QuestMockRepository)(nil).GetQuestActiveAdventurer), arg0)
+}
+
+// GetQuestsByStatus mocks base method.
+func (m *QuestMockRepository) GetQuestsByStatus(arg0 int32) ([]quest.GetQuestByStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestsByStatus", arg0)
+	ret0, _ := ret[0].([]quest.GetQuestByStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestsByStatus indicates an expected call of GetQuestsByStatus.
+func (mr *QuestMockRepositoryMockRecorder) GetQuestsByStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestsByStatus", reflect.TypeOf((*QuestMockRepository)(nil).GetQuestsByStatus), arg0)
+}
+
+// GetTakenBy mocks base method.
+func (m *QuestMockRepository) GetTakenBy(arg0 int64) ([]quest.TakenBy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTakenBy", arg0)
+	ret0, _ := ret[0].([]quest.TakenBy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTakenBy indicates an expected call of GetTakenBy.
+func (mr *QuestMockRepositoryMockRecorder) GetTakenBy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTakenBy", reflect.TypeOf((*QuestMockRepository)(nil).GetTakenBy), arg0)
+}
+
+// IsExistTakenBy mocks base method.
+func (m *QuestMockRepository) IsExistTakenBy(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsExistTakenBy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IsExistTakenBy indicates an expected call of IsExistTakenBy.
+func (mr *QuestMockRepositoryMockRecorder) IsExistTakenBy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExistTakenBy", reflect.TypeOf((*QuestMockRepository)(nil).IsExistTakenBy), arg0, arg1)
+}
+
+// JoinQuest mocks base method.
+func (m *QuestMockRepository) JoinQuest(arg0 quest.Quest) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinQuest", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JoinQuest indicates an expected call of JoinQuest.
+func (mr *QuestMockRepositoryMockRecorder) JoinQuest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinQuest", reflect.TypeOf((*QuestMockRepository)(nil).JoinQuest), arg0)
+}
+
+// ListAssignments mocks base method.
+func (m *QuestMockRepository) ListAssignments(arg0 quest.AssignmentFilter) ([]quest.Assignment, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAssignments", arg0)
+	ret0, _ := ret[0].([]quest.Assignment)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAssignments indicates an expected call of ListAssignments.
+func (mr *QuestMockRepositoryMockRecorder) ListAssignments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssignments", reflect.TypeOf((*QuestMockRepository)(nil).ListAssignments), arg0)
+}
+
+// ListQuests mocks base method.
+func (m *QuestMockRepository) ListQuests(arg0 quest.QuestFilter) ([]quest.Quest, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQuests", arg0)
+	ret0, _ := ret[0].([]quest.Quest)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListQuests indicates an expected call of ListQuests.
+func (mr *QuestMockRepositoryMockRecorder) ListQuests(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQuests", reflect.TypeOf((*QuestMockRepository)(nil).ListQuests), arg0)
+}
+
+// ListTakers mocks base method.
+func (m *QuestMockRepository) ListTakers(arg0 []int64) (map[int64][]quest.Taker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTakers", arg0)
+	ret0, _ := ret[0].(map[int64][]quest.Taker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTakers indicates an expected call of ListTakers.
+func (mr *QuestMockRepositoryMockRecorder) ListTakers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTakers", reflect.TypeOf((*QuestMockRepository)(nil).ListTakers), arg0)
+}
+
+// UpdateQuestRank mocks base method.
+func (m *QuestMockRepository) UpdateQuestRank(arg0 quest.Quest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuestRank", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQuestRank indicates an expected call of UpdateQuestRank.
+func (mr *QuestMockRepositoryMockRecorder) UpdateQuestRank(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuestRank", reflect.TypeOf((*QuestMockRepository)(nil).UpdateQuestRank), arg0)
+}
+
+// UpdateQuestReward mocks base method.
+func (m *QuestMockRepository) UpdateQuestReward(arg0 quest.Quest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuestReward", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQuestReward indicates an expected call of UpdateQuestReward.
+func (mr *QuestMockRepositoryMockRecorder) UpdateQuestReward(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuestReward", reflect.TypeOf((*QuestMockRepository)(nil).UpdateQuestReward), arg0)
+}
+
+// UpdateQuestStatus mocks base method.
+func (m *QuestMockRepository) UpdateQuestStatus(arg0 quest.Quest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuestStatus", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQuestStatus indicates an expected call of UpdateQuestStatus.
+func (mr *QuestMockRepositoryMockRecorder) UpdateQuestStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuestStatus", reflect.TypeOf((*QuestMockRepository)(nil).UpdateQuestStatus), arg0)
+}
+
+// UpdateQuestStatusIf mocks base method.
+func (m *QuestMockRepository) UpdateQuestStatusIf(arg0 quest.Quest, arg1 int32) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuestStatusIf", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateQuestStatusIf indicates an expected call of UpdateQuestStatusIf.
+func (mr *QuestMockRepositoryMockRecorder) UpdateQuestStatusIf(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuestStatusIf", reflect.TypeOf((*QuestMockRepository)(nil).UpdateQuestStatusIf), arg0, arg1)
+}
+
+// UpdateQuestTakenAt mocks base method.
+func (m *QuestMockRepository) UpdateQuestTakenAt(arg0 quest.Quest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuestTakenAt", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQuestTakenAt indicates an expected call of UpdateQuestTakenAt.
+func (mr *QuestMockRepositoryMockRecorder) UpdateQuestTakenAt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuestTakenAt", reflect.TypeOf((*QuestMockRepository)(nil).UpdateQuestTakenAt), arg0)
+}
+
+// UpdateTakenByReward mocks base method.
+func (m *QuestMockRepository) UpdateTakenByReward(arg0 quest.TakenBy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTakenByReward", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTakenByReward indicates an expected call of UpdateTakenByReward.
+func (mr *QuestMockRepositoryMockRecorder) UpdateTakenByReward(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTakenByReward", reflect.TypeOf((*QuestMockRepository)(nil).UpdateTakenByReward), arg0)
+}
+
+// Mockscanner is a mock of scanner interface.
+type Mockscanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockscannerMockRecorder
+}
+
+// MockscannerMockRecorder is the mock recorder for Mockscanner.
+type MockscannerMockRecorder struct {
+	mock *Mockscanner
+}
+
+// NewMockscanner creates a new mock instance.
+func NewMockscanner(ctrl *gomock.Controller) *Mockscanner {
+	mock := &Mockscanner{ctrl: ctrl}
+	mock.recorder = &MockscannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockscanner) EXPECT() *MockscannerMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *Mockscanner) Scan(arg0 ...interface{}) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Scan", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockscannerMockRecorder) Scan(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*Mockscanner)(nil).Scan), arg0...)
+}
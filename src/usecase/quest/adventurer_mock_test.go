@@ -48,6 +48,20 @@ func (mr *AdvMockRepositoryMockRecorder) AddCompletedQuest(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCompletedQuest", reflect.TypeOf((*AdvMockRepository)(nil).AddCompletedQuest), arg0)
 }
 
+// AddFailedQuest mocks base method.
+func (m *AdvMockRepository) AddFailedQuest(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFailedQuest", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFailedQuest indicates an expected call of AddFailedQuest.
+func (mr *AdvMockRepositoryMockRecorder) AddFailedQuest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFailedQuest", reflect.TypeOf((*AdvMockRepository)(nil).AddFailedQuest), arg0)
+}
+
 // Close mocks base method.
 func (m *AdvMockRepository) Close() {
 	m.ctrl.T.Helper()
@@ -90,6 +104,36 @@ func (mr *AdvMockRepositoryMockRecorder) GetAdventurer(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdventurer", reflect.TypeOf((*AdvMockRepository)(nil).GetAdventurer), arg0)
 }
 
+// ListAdventurers mocks base method.
+func (m *AdvMockRepository) ListAdventurers(arg0 adventurer.AdventurerFilter) ([]adventurer.Adventurer, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAdventurers", arg0)
+	ret0, _ := ret[0].([]adventurer.Adventurer)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAdventurers indicates an expected call of ListAdventurers.
+func (mr *AdvMockRepositoryMockRecorder) ListAdventurers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdventurers", reflect.TypeOf((*AdvMockRepository)(nil).ListAdventurers), arg0)
+}
+
+// UpdateAdventurerProgress mocks base method.
+func (m *AdvMockRepository) UpdateAdventurerProgress(arg0 adventurer.Adventurer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAdventurerProgress", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAdventurerProgress indicates an expected call of UpdateAdventurerProgress.
+func (mr *AdvMockRepositoryMockRecorder) UpdateAdventurerProgress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdventurerProgress", reflect.TypeOf((*AdvMockRepository)(nil).UpdateAdventurerProgress), arg0)
+}
+
 // UpdateAdventurerRank mocks base method.
 func (m *AdvMockRepository) UpdateAdventurerRank(arg0 adventurer.Adventurer) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,80 @@
+package quest
+
+import (
+	"errors"
+	"time"
+
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	modelLedger "github.com/arfaghifari/guild-board/src/model/ledger"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+	"github.com/arfaghifari/guild-board/src/repository/unitofwork"
+)
+
+var errUnbalanced = errors.New("ledger entries must add up to zero")
+
+// funder is the account that pays the reward of quest: the quest giver who
+// posted it, or the guild for quests posted by the staff.
+func funder(quest model.Quest) modelLedger.Account {
+	if quest.CreatedBy > 0 {
+		return modelLedger.GiverAccount(quest.CreatedBy)
+	}
+	return modelLedger.Guild
+}
+
+// fundEscrow moves money between the funder of quest and its escrow until the
+// escrow holds amount: an escrow when it grows, a refund when it shrinks.
+func fundEscrow(repos unitofwork.Repositories, quest model.Quest, amount int64, now time.Time) error {
+	escrow := modelLedger.EscrowAccount(quest.ID)
+	held, err := repos.Ledger.GetBalance(escrow)
+	if err != nil {
+		return err
+	}
+	diff := amount - held.Balance
+	from, to, kind := funder(quest), escrow, constant.LedgerEscrow
+	if diff < 0 {
+		from, to, kind, diff = escrow, funder(quest), constant.LedgerRefund, -diff
+	}
+	return post(repos, quest.ID, kind, now,
+		modelLedger.Entry{Account: from, Amount: -diff},
+		modelLedger.Entry{Account: to, Amount: diff})
+}
+
+// releaseEscrow pays the reward of quest out of its escrow: the guild fee of
+// payout to the guild and the rest to payees, whose amounts add up to the net
+// payout, then records the payout. Quests posted before the ledger have nothing
+// in escrow, so it is funded first.
+func releaseEscrow(repos unitofwork.Repositories, quest model.Quest, payout modelLedger.Payout, payees []modelLedger.Entry, now time.Time) error {
+	if err := fundEscrow(repos, quest, payout.Gross, now); err != nil {
+		return err
+	}
+	entries := append([]modelLedger.Entry{
+		{Account: modelLedger.EscrowAccount(quest.ID), Amount: -payout.Gross},
+		{Account: modelLedger.Guild, Amount: payout.GuildFee},
+	}, payees...)
+	if err := post(repos, quest.ID, constant.LedgerRelease, now, entries...); err != nil {
+		return err
+	}
+	payout.CreatedAt = now
+	return repos.Ledger.SavePayout(payout)
+}
+
+// post records the entries that move money as one transaction, or nothing
+// when none does. Entries that do not add up to zero are refused.
+func post(repos unitofwork.Repositories, quest_id int64, kind string, now time.Time, entries ...modelLedger.Entry) error {
+	tx := modelLedger.Transaction{QuestID: quest_id, Kind: kind, CreatedAt: now}
+	var sum int64
+	for _, entry := range entries {
+		sum += entry.Amount
+		if entry.Amount != 0 {
+			tx.Entries = append(tx.Entries, entry)
+		}
+	}
+	if sum != 0 {
+		return errUnbalanced
+	}
+	if len(tx.Entries) == 0 {
+		return nil
+	}
+	_, err := repos.Ledger.PostTransaction(tx)
+	return err
+}
@@ -0,0 +1,179 @@
+package quest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arfaghifari/guild-board/src/clock"
+	"github.com/arfaghifari/guild-board/src/config"
+	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/database/memory"
+	modelLedger "github.com/arfaghifari/guild-board/src/model/ledger"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+	repoAdv "github.com/arfaghifari/guild-board/src/repository/adventurer"
+	repo "github.com/arfaghifari/guild-board/src/repository/quest"
+	"github.com/arfaghifari/guild-board/src/repository/unitofwork"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPost(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	escrow := modelLedger.EscrowAccount(1)
+	tests := []struct {
+		name    string
+		entries []modelLedger.Entry
+		mock    func(*LedgerMockRepository)
+		wantErr bool
+	}{
+		{
+			name:    "success posted the entries",
+			entries: transfer(1, constant.LedgerEscrow, modelLedger.Guild, escrow, 100).Entries,
+			mock: func(ledgerRepo *LedgerMockRepository) {
+				tx := transfer(1, constant.LedgerEscrow, modelLedger.Guild, escrow, 100)
+				ledgerRepo.EXPECT().PostTransaction(tx).Return(tx, nil).Times(1)
+			},
+		},
+		{
+			name: "success left out the entries that move nothing",
+			entries: []modelLedger.Entry{
+				{Account: escrow, Amount: -1},
+				{Account: modelLedger.AdventurerAccount(1), Amount: 1},
+				{Account: modelLedger.AdventurerAccount(2), Amount: 0},
+			},
+			mock: func(ledgerRepo *LedgerMockRepository) {
+				tx := transfer(1, constant.LedgerEscrow, escrow, modelLedger.AdventurerAccount(1), 1)
+				ledgerRepo.EXPECT().PostTransaction(tx).Return(tx, nil).Times(1)
+			},
+		},
+		{
+			name:    "success posted nothing when nothing moves",
+			entries: []modelLedger.Entry{{Account: modelLedger.Guild}, {Account: escrow}},
+			mock:    func(*LedgerMockRepository) {},
+		},
+		{
+			name:    "failed unbalanced entries",
+			entries: []modelLedger.Entry{{Account: modelLedger.Guild, Amount: -100}, {Account: escrow, Amount: 99}},
+			mock:    func(*LedgerMockRepository) {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ledgerRepo := NewLedgerMockRepository(mockCtrl)
+			tt.mock(ledgerRepo)
+			err := post(unitofwork.Repositories{Ledger: ledgerRepo}, 1, constant.LedgerEscrow, now, tt.entries...)
+			if tt.wantErr {
+				assert.Equal(t, errUnbalanced, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+		})
+	}
+}
+
+// checkLedger asserts the invariants of the ledger: every transaction
+// balances, the escrow of an open quest holds its reward and that of a closed
+// or deleted quest holds nothing, and every payout splits its gross between
+// the guild and the party. It returns the balance of every account.
+func checkLedger(t *testing.T, store *memory.Store) map[modelLedger.Account]int64 {
+	balances := map[modelLedger.Account]int64{}
+	store.Read(func(d *memory.Data) {
+		for _, tx := range d.LedgerTransactions {
+			var sum int64
+			for _, entry := range tx.Entries {
+				sum += entry.Amount
+				balances[entry.Account] += entry.Amount
+			}
+			assert.Zero(t, sum, "transaction %d does not balance", tx.ID)
+		}
+		for id := int64(1); id <= d.LastQuestID; id++ {
+			var held int64
+			quest, ok := d.Quests[id]
+			if ok && (quest.Status == constant.AvailableQuest || quest.Status == constant.WorkingQuest) {
+				held = int64(quest.RewardNumber)
+			}
+			assert.Equal(t, held, balances[modelLedger.EscrowAccount(id)], "escrow of quest %d", id)
+		}
+		for id, payout := range d.Payouts {
+			assert.Equal(t, payout.Gross, payout.GuildFee+payout.Net, "payout of quest %d", id)
+			assert.True(t, payout.GuildFee >= 0 && payout.Net >= 0, "payout of quest %d", id)
+		}
+	})
+	return balances
+}
+
+func TestLedgerInvariants(t *testing.T) {
+	store := memory.NewStore()
+	advRepo := repoAdv.NewMemoryRepository(store)
+	for i := 0; i < 3; i++ {
+		advRepo.CreateAdventurer(adv)
+	}
+	u := &usecase{
+		repo:   repo.NewMemoryRepository(store),
+		uow:    unitofwork.NewMemoryUnitOfWork(store),
+		clock:  clock.Fixed(now),
+		policy: policy,
+	}
+	u.fees, _ = NewFeePolicy(config.Commission{Percent: 10, MinimumFee: 20})
+	const giverID = 1
+	deadline := now.Add(time.Hour)
+	postQuest := func(reward int32, members int32, createdBy int64, deadline *time.Time) model.Quest {
+		quest, err := u.CreateQuest(model.Quest{Name: "quest", MinimumRank: 1, RewardNumber: reward, MinMembers: members,
+			CreatedBy: createdBy, Deadline: deadline})
+		assert.NoError(t, err)
+		checkLedger(t, store)
+		return quest
+	}
+	party := postQuest(300, 2, giverID, nil)
+	guild := postQuest(500, 1, 0, nil)
+	cancelled := postQuest(100, 1, giverID, nil)
+	overdue := postQuest(200, 1, giverID, &deadline)
+	deleted := postQuest(50, 1, giverID, nil)
+
+	steps := []struct {
+		name string
+		do   func() error
+	}{
+		{"raise a reward", func() error { return u.UpdateQuestReward(model.Quest{ID: guild.ID, RewardNumber: 600}) }},
+		{"first member joins", func() error { return u.TakeQuest(party.ID, 1) }},
+		{"second member joins", func() error { return u.TakeQuest(party.ID, 2) }},
+		{"party completes", func() error { return u.ReportQuest(party.ID, 1, true) }},
+		{"refuse to lower a completed reward", func() error {
+			assert.Equal(t, ErrQuestDone, u.UpdateQuestReward(model.Quest{ID: party.ID, RewardNumber: 10}))
+			return nil
+		}},
+		{"take a guild quest", func() error { return u.TakeQuest(guild.ID, 3) }},
+		{"fail it", func() error { return u.ReportQuest(guild.ID, 3, false) }},
+		{"take it again", func() error { return u.TakeQuest(guild.ID, 3) }},
+		{"complete it", func() error { return u.ReportQuest(guild.ID, 3, true) }},
+		{"cancel a quest", func() error {
+			_, err := u.CancelQuest(cancelled.ID)
+			return err
+		}},
+		{"delete a quest", func() error { return u.DeleteQuest(model.Quest{ID: deleted.ID}) }},
+		{"expire a quest", func() error {
+			u.clock = clock.Fixed(deadline.Add(time.Minute))
+			_, _, err := u.ExpireOverdueQuests(0)
+			return err
+		}},
+	}
+	for _, step := range steps {
+		assert.NoError(t, step.do(), step.name)
+		checkLedger(t, store)
+	}
+
+	balances := checkLedger(t, store)
+	assert.Equal(t, int64(-300), balances[modelLedger.GiverAccount(giverID)])
+	assert.Equal(t, int64(-600+30+60), balances[modelLedger.Guild])
+	assert.Equal(t, int64(135), balances[modelLedger.AdventurerAccount(1)])
+	assert.Equal(t, int64(135), balances[modelLedger.AdventurerAccount(2)])
+	assert.Equal(t, int64(540), balances[modelLedger.AdventurerAccount(3)])
+	assert.Equal(t, int64(0), balances[modelLedger.EscrowAccount(overdue.ID)])
+	store.Read(func(d *memory.Data) {
+		assert.Equal(t, modelLedger.Payout{QuestID: party.ID, Gross: 300, GuildFee: 30, Net: 270, FeePercent: 10, CreatedAt: now}, d.Payouts[party.ID])
+		assert.Len(t, d.Payouts, 2)
+	})
+}
@@ -0,0 +1,75 @@
+package quest
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/arfaghifari/guild-board/src/config"
+	modelLedger "github.com/arfaghifari/guild-board/src/model/ledger"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+)
+
+// FeePolicy decides the cut the guild keeps of the reward of a completed quest.
+type FeePolicy interface {
+	// Payout splits the reward of quest into the guild fee and what is left for
+	// its party.
+	Payout(quest model.Quest) modelLedger.Payout
+}
+
+type fee struct {
+	minimumRank int32
+	percent     int32
+	minimumFee  int64
+}
+
+type feePolicy struct {
+	base  fee
+	tiers []fee
+}
+
+var ErrInvalidFeePolicy = errors.New("commission needs percents between 0 and 100, no negative minimum fee and distinct positive tier ranks")
+
+func NewFeePolicy(cfg config.Commission) (FeePolicy, error) {
+	if cfg.Validate() != nil {
+		return nil, ErrInvalidFeePolicy
+	}
+
+	p := &feePolicy{base: fee{percent: int32(cfg.Percent), minimumFee: int64(cfg.MinimumFee)}}
+	for _, tier := range cfg.Tiers {
+		p.tiers = append(p.tiers, fee{int32(tier.MinimumRank), int32(tier.Percent), int64(tier.MinimumFee)})
+	}
+	sort.Slice(p.tiers, func(i, j int) bool {
+		return p.tiers[i].minimumRank > p.tiers[j].minimumRank
+	})
+	return p, nil
+}
+
+// tier is the fee charged for a quest asking minimumRank: the highest tier it
+// reaches, or the base fee below every tier.
+func (p *feePolicy) tier(minimumRank int32) fee {
+	for _, tier := range p.tiers {
+		if minimumRank >= tier.minimumRank {
+			return tier
+		}
+	}
+	return p.base
+}
+
+func (p *feePolicy) Payout(quest model.Quest) modelLedger.Payout {
+	tier := p.tier(quest.MinimumRank)
+	gross := int64(quest.RewardNumber)
+	guildFee := gross * int64(tier.percent) / 100
+	if guildFee < tier.minimumFee {
+		guildFee = tier.minimumFee
+	}
+	if guildFee > gross {
+		guildFee = gross
+	}
+	return modelLedger.Payout{
+		QuestID:    quest.ID,
+		Gross:      gross,
+		GuildFee:   guildFee,
+		Net:        gross - guildFee,
+		FeePercent: tier.percent,
+	}
+}
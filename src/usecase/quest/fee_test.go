@@ -0,0 +1,130 @@
+package quest
+
+import (
+	"testing"
+
+	"github.com/arfaghifari/guild-board/src/config"
+	modelLedger "github.com/arfaghifari/guild-board/src/model/ledger"
+	model "github.com/arfaghifari/guild-board/src/model/quest"
+	"github.com/stretchr/testify/assert"
+)
+
+var commission = config.Commission{
+	Percent:    10,
+	MinimumFee: 5000,
+	Tiers: []config.CommissionTier{
+		{MinimumRank: 20, Percent: 20},
+		{MinimumRank: 15, Percent: 15, MinimumFee: 10000},
+	},
+}
+
+func TestNewFeePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.Commission
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			cfg:     commission,
+			wantErr: false,
+		},
+		{
+			name:    "no commission",
+			cfg:     config.Commission{},
+			wantErr: false,
+		},
+		{
+			name:    "percent above 100",
+			cfg:     config.Commission{Percent: 120},
+			wantErr: true,
+		},
+		{
+			name:    "negative minimum fee",
+			cfg:     config.Commission{MinimumFee: -1},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate tiers",
+			cfg:     config.Commission{Tiers: []config.CommissionTier{{MinimumRank: 15}, {MinimumRank: 15}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := NewFeePolicy(tt.cfg)
+			if tt.wantErr {
+				assert.Equal(t, ErrInvalidFeePolicy, err, tt.name)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err, tt.name)
+				assert.NotNil(t, res)
+			}
+		})
+	}
+}
+
+func TestFeePolicyPayout(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        config.Commission
+		quest      model.Quest
+		wantPayout modelLedger.Payout
+	}{
+		{
+			name:       "percent of the reward",
+			cfg:        commission,
+			quest:      model.Quest{ID: 1, MinimumRank: 11, RewardNumber: 200000},
+			wantPayout: modelLedger.Payout{QuestID: 1, Gross: 200000, GuildFee: 20000, Net: 180000, FeePercent: 10},
+		},
+		{
+			name:       "minimum fee",
+			cfg:        commission,
+			quest:      model.Quest{ID: 1, MinimumRank: 11, RewardNumber: 30000},
+			wantPayout: modelLedger.Payout{QuestID: 1, Gross: 30000, GuildFee: 5000, Net: 25000, FeePercent: 10},
+		},
+		{
+			name:       "fee capped at the reward",
+			cfg:        commission,
+			quest:      model.Quest{ID: 1, MinimumRank: 11, RewardNumber: 3000},
+			wantPayout: modelLedger.Payout{QuestID: 1, Gross: 3000, GuildFee: 3000, FeePercent: 10},
+		},
+		{
+			name:       "fee rounded down",
+			cfg:        config.Commission{Percent: 10},
+			quest:      model.Quest{ID: 1, MinimumRank: 11, RewardNumber: 999},
+			wantPayout: modelLedger.Payout{QuestID: 1, Gross: 999, GuildFee: 99, Net: 900, FeePercent: 10},
+		},
+		{
+			name:       "tier reached",
+			cfg:        commission,
+			quest:      model.Quest{ID: 2, MinimumRank: 17, RewardNumber: 200000},
+			wantPayout: modelLedger.Payout{QuestID: 2, Gross: 200000, GuildFee: 30000, Net: 170000, FeePercent: 15},
+		},
+		{
+			name:       "tier minimum fee",
+			cfg:        commission,
+			quest:      model.Quest{ID: 2, MinimumRank: 15, RewardNumber: 40000},
+			wantPayout: modelLedger.Payout{QuestID: 2, Gross: 40000, GuildFee: 10000, Net: 30000, FeePercent: 15},
+		},
+		{
+			name:       "highest tier wins",
+			cfg:        commission,
+			quest:      model.Quest{ID: 3, MinimumRank: 25, RewardNumber: 200000},
+			wantPayout: modelLedger.Payout{QuestID: 3, Gross: 200000, GuildFee: 40000, Net: 160000, FeePercent: 20},
+		},
+		{
+			name:       "no commission",
+			cfg:        config.Commission{},
+			quest:      model.Quest{ID: 1, MinimumRank: 11, RewardNumber: 200000},
+			wantPayout: modelLedger.Payout{QuestID: 1, Gross: 200000, Net: 200000},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewFeePolicy(tt.cfg)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPayout, p.Payout(tt.quest))
+		})
+	}
+}
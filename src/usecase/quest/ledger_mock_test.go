@@ -0,0 +1,106 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ledger.go
+
+// Package mock_ledger is a generated GoMock package.
+package quest
+
+import (
+	reflect "reflect"
+
+	ledger "github.com/arfaghifari/guild-board/src/model/ledger"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// LedgerMockRepository is a mock of Repository interface.
+type LedgerMockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *LedgerMockRepositoryMockRecorder
+}
+
+// LedgerMockRepositoryMockRecorder is the mock recorder for LedgerMockRepository.
+type LedgerMockRepositoryMockRecorder struct {
+	mock *LedgerMockRepository
+}
+
+// NewLedgerMockRepository creates a new mock instance.
+func NewLedgerMockRepository(ctrl *gomock.Controller) *LedgerMockRepository {
+	mock := &LedgerMockRepository{ctrl: ctrl}
+	mock.recorder = &LedgerMockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *LedgerMockRepository) EXPECT() *LedgerMockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *LedgerMockRepository) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *LedgerMockRepositoryMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*LedgerMockRepository)(nil).Close))
+}
+
+// GetBalance mocks base method.
+func (m *LedgerMockRepository) GetBalance(arg0 ledger.Account) (ledger.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", arg0)
+	ret0, _ := ret[0].(ledger.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *LedgerMockRepositoryMockRecorder) GetBalance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*LedgerMockRepository)(nil).GetBalance), arg0)
+}
+
+// GetPayout mocks base method.
+func (m *LedgerMockRepository) GetPayout(questID int64) (ledger.Payout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayout", questID)
+	ret0, _ := ret[0].(ledger.Payout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayout indicates an expected call of GetPayout.
+func (mr *LedgerMockRepositoryMockRecorder) GetPayout(questID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayout", reflect.TypeOf((*LedgerMockRepository)(nil).GetPayout), questID)
+}
+
+// PostTransaction mocks base method.
+func (m *LedgerMockRepository) PostTransaction(arg0 ledger.Transaction) (ledger.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostTransaction", arg0)
+	ret0, _ := ret[0].(ledger.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostTransaction indicates an expected call of PostTransaction.
+func (mr *LedgerMockRepositoryMockRecorder) PostTransaction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostTransaction", reflect.TypeOf((*LedgerMockRepository)(nil).PostTransaction), arg0)
+}
+
+// SavePayout mocks base method.
+func (m *LedgerMockRepository) SavePayout(arg0 ledger.Payout) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePayout", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePayout indicates an expected call of SavePayout.
+func (mr *LedgerMockRepositoryMockRecorder) SavePayout(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePayout", reflect.TypeOf((*LedgerMockRepository)(nil).SavePayout), arg0)
+}
@@ -1,12 +1,21 @@
 package quest
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/arfaghifari/guild-board/src/clock"
 	constant "github.com/arfaghifari/guild-board/src/constant"
+	modelAdv "github.com/arfaghifari/guild-board/src/model/adventurer"
+	modelLedger "github.com/arfaghifari/guild-board/src/model/ledger"
 	model "github.com/arfaghifari/guild-board/src/model/quest"
 	repoAdv "github.com/arfaghifari/guild-board/src/repository/adventurer"
 	repo "github.com/arfaghifari/guild-board/src/repository/quest"
+	"github.com/arfaghifari/guild-board/src/repository/unitofwork"
+	advUsecase "github.com/arfaghifari/guild-board/src/usecase/adventurer"
 )
 
 type Usecase interface {
@@ -15,103 +24,623 @@ type Usecase interface {
 	DeleteQuest(model.Quest) error
 	UpdateQuestRank(model.Quest) error
 	UpdateQuestReward(model.Quest) error
+	PatchQuest(model.Quest) error
 	TakeQuest(int64, int64) error
 	ReportQuest(int64, int64, bool) error
 	GetQuestActiveAdventurer(int64) ([]model.Quest, error)
+	ExpireOverdueQuests(time.Duration) (int, int, error)
+	CancelQuest(int64) ([]int64, error)
+	GetQuest(int64) (model.Quest, error)
+	GetTakers(int64) ([]model.TakenBy, error)
+	ListQuests(model.QuestFilter) ([]model.Quest, int, error)
+	ListPostedQuests(model.QuestFilter) ([]model.PostedQuest, int, error)
+	ListAssignments(model.AssignmentFilter) ([]model.Assignment, int, error)
 }
 
+var (
+	ErrQuestNotFound = apperror.NewNotFound("quest_not_found", "quest not found")
+	ErrQuestTaken    = apperror.NewConflict("quest_taken", "quest have been taken")
+	ErrQuestNotTaken = apperror.NewConflict("quest_not_taken", "quest have not taken")
+	ErrNotInParty    = apperror.NewForbidden("not_in_party", "adventurer is not in the quest party")
+	ErrRankTooLow    = apperror.NewForbidden("rank_too_low", "not capable adventurer rank")
+	ErrQuestExpired  = apperror.NewConflict("quest_expired", "quest deadline has passed")
+	ErrPastDeadline  = apperror.NewValidation("past_deadline", "deadline must be in the future")
+	ErrQuestDone     = apperror.NewConflict("quest_completed", "quest have been completed")
+	ErrQuestCanceled = apperror.NewConflict("quest_cancelled", "quest have been cancelled")
+	ErrQuestChanged  = apperror.NewConflict("quest_changed", "quest have changed, try again")
+	ErrAlreadyJoined = apperror.NewConflict("already_joined", "adventurer already joined the quest")
+	ErrInvalidParty  = apperror.NewValidation("invalid_party", "party size must satisfy 1 <= min_members <= max_members")
+	ErrInvalidRule   = apperror.NewValidation("invalid_rank_rule", "rank_rule must be all or average")
+	ErrInvalidFilter = apperror.NewValidation("invalid_filter", "invalid quest filter")
+	ErrInvalidStatus = apperror.NewValidation("invalid_status", "invalid status number")
+)
+
+var errMissingDependency = errors.New("quest usecase needs repositories, a unit of work, a clock and a rank policy")
+
 type usecase struct {
 	repo    repo.Repository
 	repoAdv repoAdv.Repository
+	uow     unitofwork.UnitOfWork
+	clock   clock.Clock
+	policy  advUsecase.Policy
+	fees    FeePolicy
 }
 
-func NewUsecase() (Usecase, error) {
-	repo, _ := repo.NewRepository()
-	repoAdv, _ := repoAdv.NewRepository()
+func NewUsecase(repo repo.Repository, repoAdv repoAdv.Repository, uow unitofwork.UnitOfWork, clock clock.Clock, policy advUsecase.Policy, fees FeePolicy) (Usecase, error) {
+	if repo == nil || repoAdv == nil || uow == nil || clock == nil || policy == nil || fees == nil {
+		return nil, errMissingDependency
+	}
 
-	return &usecase{repo, repoAdv}, nil
+	return &usecase{repo, repoAdv, uow, clock, policy, fees}, nil
 }
 
+// GetQuestByStatus lists the quests in any status. Working and completed
+// quests come with their party.
 func (u *usecase) GetQuestByStatus(status int32) ([]model.GetQuestByStatus, error) {
-	if status == constant.AvailableQuest {
-		return u.repo.GetAllAvailableQuest()
-	} else {
-		return u.repo.GetAllCompletedQuest()
+	if status < constant.AvailableQuest || status > constant.CancelledQuest {
+		return []model.GetQuestByStatus{}, ErrInvalidStatus
 	}
+	return u.repo.GetQuestsByStatus(status)
 }
 
+// CreateQuest posts a quest for a single adventurer unless it declares a party
+// size. A party needs min_members to start and takes at most max_members. The
+// reward is escrowed from whoever posts the quest.
 func (u *usecase) CreateQuest(quest model.Quest) (model.Quest, error) {
-	return u.repo.CreateQuest(quest)
+	now := u.clock.Now()
+	if quest.Deadline != nil && !quest.Deadline.After(now) {
+		return model.Quest{}, ErrPastDeadline
+	}
+	if quest.MinMembers == 0 {
+		quest.MinMembers = 1
+	}
+	if quest.MaxMembers == 0 {
+		quest.MaxMembers = quest.MinMembers
+	}
+	if quest.MinMembers < 1 || quest.MaxMembers < quest.MinMembers {
+		return model.Quest{}, ErrInvalidParty
+	}
+	switch quest.RankRule {
+	case "":
+		quest.RankRule = constant.RankRuleAll
+	case constant.RankRuleAll, constant.RankRuleAverage:
+	default:
+		return model.Quest{}, ErrInvalidRule
+	}
+	quest.CreatedAt = now
+	err := u.uow.Do(func(repos unitofwork.Repositories) (err error) {
+		if quest, err = repos.Quest.CreateQuest(quest); err != nil {
+			return
+		}
+		return fundEscrow(repos, quest, int64(quest.RewardNumber), now)
+	})
+	if err != nil {
+		return model.Quest{}, err
+	}
+	return quest, nil
+}
+
+func (u *usecase) GetQuest(quest_id int64) (model.Quest, error) {
+	quest, err := u.repo.GetQuest(quest_id)
+	return quest, found(err, ErrQuestNotFound)
+}
+
+// found reports a missing row as notFound and passes other errors through.
+func found(err, notFound error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFound
+	}
+	return err
+}
+
+// closed reports why a quest that is neither available nor working can no
+// longer change, or nil while it is open.
+func closed(quest model.Quest) error {
+	switch quest.Status {
+	case constant.CompletedQuest:
+		return ErrQuestDone
+	case constant.CancelledQuest:
+		return ErrQuestCanceled
+	case constant.ExpiredQuest:
+		return ErrQuestExpired
+	}
+	return nil
+}
+
+// GetTakers lists the party of an existing quest.
+func (u *usecase) GetTakers(quest_id int64) ([]model.TakenBy, error) {
+	if _, err := u.repo.GetQuest(quest_id); err != nil {
+		return nil, found(err, ErrQuestNotFound)
+	}
+	return u.repo.GetTakenBy(quest_id)
+}
+
+// ListQuests returns one page of the quests matching filter and the number of
+// matching quests. A zero limit asks for the default page size by creation time.
+func (u *usecase) ListQuests(filter model.QuestFilter) ([]model.Quest, int, error) {
+	if filter.Limit == 0 {
+		filter.Limit = constant.DefaultPageSize
+	}
+	if filter.Sort == "" {
+		filter.Sort = constant.SortCreatedAt
+	}
+	if err := validateFilter(filter); err != nil {
+		return nil, 0, err
+	}
+	return u.repo.ListQuests(filter)
 }
 
+// ListPostedQuests is ListQuests with the adventurers assigned to each quest.
+func (u *usecase) ListPostedQuests(filter model.QuestFilter) ([]model.PostedQuest, int, error) {
+	quests, total, err := u.ListQuests(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	ids := make([]int64, len(quests))
+	for i, quest := range quests {
+		ids[i] = quest.ID
+	}
+	takers, err := u.repo.ListTakers(ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	posted := make([]model.PostedQuest, len(quests))
+	for i, quest := range quests {
+		posted[i] = model.PostedQuest{Quest: quest, Takers: takers[quest.ID]}
+		if posted[i].Takers == nil {
+			posted[i].Takers = []model.Taker{}
+		}
+	}
+	return posted, total, nil
+}
+
+func validateFilter(filter model.QuestFilter) error {
+	for _, status := range filter.Statuses {
+		if status < constant.AvailableQuest || status > constant.CancelledQuest {
+			return fmt.Errorf("%w: unknown status %d", ErrInvalidFilter, status)
+		}
+	}
+	switch {
+	case filter.MinReward < 0 || filter.MaxReward < 0:
+		return fmt.Errorf("%w: reward must not be negative", ErrInvalidFilter)
+	case filter.MaxReward > 0 && filter.MinReward > filter.MaxReward:
+		return fmt.Errorf("%w: min_reward is above max_reward", ErrInvalidFilter)
+	case filter.MinRank < 0 || filter.MaxRank < 0:
+		return fmt.Errorf("%w: rank must not be negative", ErrInvalidFilter)
+	case filter.MaxRank > 0 && filter.MinRank > filter.MaxRank:
+		return fmt.Errorf("%w: min_rank is above max_rank", ErrInvalidFilter)
+	case filter.Sort != constant.SortCreatedAt && filter.Sort != constant.SortReward && filter.Sort != constant.SortRank:
+		return fmt.Errorf("%w: unknown sort %q", ErrInvalidFilter, filter.Sort)
+	case filter.Limit < 0 || filter.Limit > constant.MaxPageSize:
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidFilter, constant.MaxPageSize)
+	case filter.Offset < 0:
+		return fmt.Errorf("%w: offset must not be negative", ErrInvalidFilter)
+	}
+	return nil
+}
+
+// ListAssignments returns one page of the history of an existing quest or
+// adventurer, newest first, and the number of assignments in that history.
+func (u *usecase) ListAssignments(filter model.AssignmentFilter) ([]model.Assignment, int, error) {
+	if filter.Limit == 0 {
+		filter.Limit = constant.DefaultPageSize
+	}
+	switch {
+	case filter.Limit < 0 || filter.Limit > constant.MaxPageSize:
+		return nil, 0, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidFilter, constant.MaxPageSize)
+	case filter.Offset < 0:
+		return nil, 0, fmt.Errorf("%w: offset must not be negative", ErrInvalidFilter)
+	}
+	if filter.QuestID != 0 {
+		if _, err := u.repo.GetQuest(filter.QuestID); err != nil {
+			return nil, 0, found(err, ErrQuestNotFound)
+		}
+	}
+	if filter.AdventurerID != 0 {
+		if _, err := u.repoAdv.GetAdventurer(filter.AdventurerID); err != nil {
+			return nil, 0, found(err, advUsecase.ErrAdventurerNotFound)
+		}
+	}
+	return u.repo.ListAssignments(filter)
+}
+
+// DeleteQuest removes a quest and refunds whatever its escrow still holds.
 func (u *usecase) DeleteQuest(quest model.Quest) error {
-	return u.repo.DeleteQuest(quest)
+	now := u.clock.Now()
+	return u.uow.Do(func(repos unitofwork.Repositories) error {
+		current, err := repos.Quest.GetQuest(quest.ID)
+		if err != nil {
+			return found(err, ErrQuestNotFound)
+		}
+		if err = fundEscrow(repos, current, 0, now); err != nil {
+			return err
+		}
+		// taken_by rows go with the quest, the assignments stay as history
+		takers, err := repos.Quest.GetTakenBy(quest.ID)
+		if err != nil {
+			return err
+		}
+		for _, taken := range takers {
+			if err = finishAssignment(repos, quest.ID, taken.AdventurerID, constant.OutcomeDeleted, now); err != nil {
+				return err
+			}
+		}
+		return repos.Quest.DeleteQuest(quest)
+	})
 }
 
+// UpdateQuestReward changes the reward of an open quest and moves its escrow
+// along. A closed quest has already been paid or refunded, so its reward stays.
 func (u *usecase) UpdateQuestReward(quest model.Quest) error {
-	return u.repo.UpdateQuestReward(quest)
+	now := u.clock.Now()
+	return u.uow.Do(func(repos unitofwork.Repositories) error {
+		current, err := repos.Quest.GetQuest(quest.ID)
+		if err != nil {
+			return found(err, ErrQuestNotFound)
+		}
+		return updateReward(repos, current, quest, now)
+	})
 }
 
 func (u *usecase) UpdateQuestRank(quest model.Quest) error {
+	if _, err := u.repo.GetQuest(quest.ID); err != nil {
+		return found(err, ErrQuestNotFound)
+	}
 	return u.repo.UpdateQuestRank(quest)
 }
 
+// PatchQuest changes the minimum rank, the reward or both, whichever is set, in
+// one transaction: a change that fails leaves the quest as it was.
+func (u *usecase) PatchQuest(quest model.Quest) error {
+	now := u.clock.Now()
+	return u.uow.Do(func(repos unitofwork.Repositories) error {
+		current, err := repos.Quest.GetQuest(quest.ID)
+		if err != nil {
+			return found(err, ErrQuestNotFound)
+		}
+		if quest.MinimumRank > 0 {
+			if err = repos.Quest.UpdateQuestRank(quest); err != nil {
+				return err
+			}
+		}
+		if quest.RewardNumber > 0 {
+			return updateReward(repos, current, quest, now)
+		}
+		return nil
+	})
+}
+
+// updateReward saves the reward of quest over current and moves its escrow
+// along, refusing a closed quest.
+func updateReward(repos unitofwork.Repositories, current, quest model.Quest, now time.Time) error {
+	if err := closed(current); err != nil {
+		return err
+	}
+	if err := repos.Quest.UpdateQuestReward(quest); err != nil {
+		return err
+	}
+	return fundEscrow(repos, current, int64(quest.RewardNumber), now)
+}
+
+// TakeQuest adds the adventurer to the quest's party. Places are taken with a
+// conditional update inside the transaction, so when more adventurers race for
+// a quest than it has places, only as many as fit get in.
 func (u *usecase) TakeQuest(quest_id, adventurer_id int64) error {
-	quest, err := u.repo.GetQuest(quest_id)
+	return u.uow.Do(func(repos unitofwork.Repositories) error {
+		quest, err := repos.Quest.GetQuest(quest_id)
+		if err != nil {
+			return found(err, ErrQuestNotFound)
+		}
+		now := u.clock.Now()
+		if quest.Status == constant.ExpiredQuest || (quest.Deadline != nil && !quest.Deadline.After(now)) {
+			return ErrQuestExpired
+		}
+		if quest.Status == constant.CancelledQuest {
+			return ErrQuestCanceled
+		}
+		open := quest.Status == constant.AvailableQuest || quest.Status == constant.WorkingQuest
+		if !open || quest.Members >= quest.MaxMembers {
+			return ErrQuestTaken
+		}
+		if err = repos.Quest.IsExistTakenBy(quest_id, adventurer_id); err == nil {
+			return ErrAlreadyJoined
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		adv, err := repos.Adventurer.GetAdventurer(adventurer_id)
+		if err != nil {
+			return found(err, advUsecase.ErrAdventurerNotFound)
+		}
+		if err = checkRank(repos, quest, adv); err != nil {
+			return err
+		}
+		joined, err := repos.Quest.JoinQuest(model.Quest{ID: quest_id, TakenAt: &now})
+		if err != nil {
+			return err
+		}
+		if !joined {
+			return ErrQuestTaken
+		}
+		if err = repos.Quest.CreateTakenBy(quest_id, adventurer_id); err != nil {
+			return err
+		}
+		return repos.Quest.CreateAssignment(model.Assignment{QuestID: quest_id, AdventurerID: adventurer_id, TakenAt: now})
+	})
+}
+
+// checkRank applies the quest's rank rule to the party adv would join. Under
+// the average rule the members already in the party can make up for a lower
+// ranked newcomer.
+func checkRank(repos unitofwork.Repositories, quest model.Quest, adv modelAdv.Adventurer) error {
+	if quest.RankRule != constant.RankRuleAverage {
+		if adv.Rank < quest.MinimumRank {
+			return ErrRankTooLow
+		}
+		return nil
+	}
+
+	takers, err := repos.Quest.GetTakenBy(quest.ID)
 	if err != nil {
 		return err
 	}
-	if quest.Status != constant.AvailableQuest {
-		return errors.New("quest have been taken")
+	total := int64(adv.Rank)
+	for _, taken := range takers {
+		member, err := repos.Adventurer.GetAdventurer(taken.AdventurerID)
+		if err != nil {
+			return err
+		}
+		total += int64(member.Rank)
+	}
+	if total < int64(quest.MinimumRank)*int64(len(takers)+1) {
+		return ErrRankTooLow
+	}
+	return nil
+}
+
+// ReportQuest closes the party's assignment on behalf of one of its members. A
+// completed quest credits every member with a completed quest and an equal
+// share of what the reward leaves after the guild fee, paid out of the quest's
+// escrow; a failed report releases the quest back to the board and unlinks the
+// whole party so it can be taken again. Either way every member's
+// rank moves on according to the rank policy and their assignment is closed.
+func (u *usecase) ReportQuest(quest_id, adventurer_id int64, is bool) error {
+	now := u.clock.Now()
+	return u.uow.Do(func(repos unitofwork.Repositories) error {
+		if err := repos.Quest.IsExistTakenBy(quest_id, adventurer_id); err != nil {
+			return found(err, ErrNotInParty)
+		}
+		quest, err := repos.Quest.GetQuest(quest_id)
+		if err != nil {
+			return found(err, ErrQuestNotFound)
+		}
+		if quest.Status != constant.WorkingQuest {
+			return ErrQuestNotTaken
+		}
+		// like TakeQuest, not waiting for the scheduler to expire the quest
+		if quest.Deadline != nil && !quest.Deadline.After(now) {
+			return ErrQuestExpired
+		}
+
+		next := model.Quest{
+			ID:     quest_id,
+			Status: constant.AvailableQuest,
+		}
+		if is {
+			next.Status = constant.CompletedQuest
+		}
+		updated, err := repos.Quest.UpdateQuestStatusIf(next, constant.WorkingQuest)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return ErrQuestNotTaken
+		}
+
+		if !is {
+			released, err := unlinkTakers(repos, quest_id, constant.OutcomeFailed, now)
+			if err != nil {
+				return err
+			}
+			return failTakers(repos, u.policy, released)
+		}
+		takers, err := repos.Quest.GetTakenBy(quest_id)
+		if err != nil {
+			return err
+		}
+		payout := u.fees.Payout(quest)
+		shares := splitReward(int32(payout.Net), len(takers))
+		payees := make([]modelLedger.Entry, len(takers))
+		for i, taken := range takers {
+			if err = repos.Adventurer.AddCompletedQuest(taken.AdventurerID); err != nil {
+				return err
+			}
+			taken.Reward = shares[i]
+			if err = repos.Quest.UpdateTakenByReward(taken); err != nil {
+				return err
+			}
+			payees[i] = modelLedger.Entry{Account: modelLedger.AdventurerAccount(taken.AdventurerID), Amount: int64(taken.Reward)}
+			if err = finishAssignment(repos, taken.QuestID, taken.AdventurerID, constant.OutcomeCompleted, now); err != nil {
+				return err
+			}
+			err = progressRank(repos, taken.AdventurerID, func(adv modelAdv.Adventurer) modelAdv.Adventurer {
+				return u.policy.Completed(adv, quest.MinimumRank, taken.Reward)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return releaseEscrow(repos, quest, payout, payees, now)
+	})
+}
+
+// progressRank applies a step of the rank policy to the adventurer and saves it.
+// failTakers charges a failed quest to each adventurer of a party that lost
+// its quest: failed_quest goes up and the rank policy counts the failure.
+func failTakers(repos unitofwork.Repositories, policy advUsecase.Policy, advIDs []int64) error {
+	for _, adv_id := range advIDs {
+		if err := repos.Adventurer.AddFailedQuest(adv_id); err != nil {
+			return err
+		}
+		if err := progressRank(repos, adv_id, policy.Failed); err != nil {
+			return err
+		}
 	}
-	adv, err := u.repoAdv.GetAdventurer(adventurer_id)
+	return nil
+}
+
+func progressRank(repos unitofwork.Repositories, adv_id int64, step func(modelAdv.Adventurer) modelAdv.Adventurer) error {
+	adv, err := repos.Adventurer.GetAdventurer(adv_id)
 	if err != nil {
 		return err
 	}
-	if adv.Rank < quest.MinimumRank {
-		return errors.New("not capable adventurer rank")
+	return repos.Adventurer.UpdateAdventurerProgress(step(adv))
+}
+
+// splitReward divides reward into n shares that differ by at most one and add
+// up to reward; the first members get the remainder.
+func splitReward(reward int32, n int) []int32 {
+	shares := make([]int32, n)
+	if n == 0 {
+		return shares
 	}
-	err = u.repo.CreateTakenBy(quest_id, adventurer_id)
+	share, rest := reward/int32(n), reward%int32(n)
+	for i := range shares {
+		shares[i] = share
+		if int32(i) < rest {
+			shares[i]++
+		}
+	}
+	return shares
+}
+
+func (u *usecase) GetQuestActiveAdventurer(adv_id int64) ([]model.Quest, error) {
+	return u.repo.GetQuestActiveAdventurer(adv_id)
+}
+
+// ExpireOverdueQuests moves quests whose deadline has passed to expired, and
+// releases working quests taken more than workingTimeout ago back to available
+// (a zero timeout never releases). The adventurers who held them get a failed
+// quest. It returns how many quests were expired and released.
+func (u *usecase) ExpireOverdueQuests(workingTimeout time.Duration) (expired, released int, err error) {
+	now := u.clock.Now()
+	var takenBefore time.Time
+	if workingTimeout > 0 {
+		takenBefore = now.Add(-workingTimeout)
+	}
+	quests, err := u.repo.GetOverdueQuests(now, takenBefore)
 	if err != nil {
-		return err
+		return
 	}
-	quest.Status = constant.WorkingQuest
-	err = u.repo.UpdateQuestStatus(quest)
 
-	return err
+	for _, quest := range quests {
+		next := model.Quest{ID: quest.ID, Status: constant.AvailableQuest}
+		if quest.Deadline != nil && quest.Deadline.Before(now) {
+			next.Status = constant.ExpiredQuest
+		}
+		var moved bool
+		err = u.uow.Do(func(repos unitofwork.Repositories) (err error) {
+			moved, err = closeOverdueQuest(repos, u.policy, quest, next, now)
+			return
+		})
+		if err != nil {
+			return
+		}
+		if !moved {
+			continue
+		}
+		if next.Status == constant.ExpiredQuest {
+			expired++
+		} else {
+			released++
+		}
+	}
+	return
 }
 
-func (u *usecase) ReportQuest(quest_id, adventurer_id int64, is bool) error {
-	if err := u.repo.IsExistTakenBy(quest_id, adventurer_id); err != nil {
-		return err
+// closeOverdueQuest moves quest to next unless it changed since it was read,
+// then unlinks its adventurers, whose assignments expire with the quest or are
+// abandoned when it is released. A party that was already working counts the
+// quest as failed; one still gathering members does not. An expired quest's
+// escrow is refunded; a released one stays on the board with its escrow.
+func closeOverdueQuest(repos unitofwork.Repositories, policy advUsecase.Policy, quest, next model.Quest, now time.Time) (bool, error) {
+	updated, err := repos.Quest.UpdateQuestStatusIf(next, quest.Status)
+	if err != nil || !updated {
+		return false, err
 	}
-	quest, err := u.repo.GetQuest(quest_id)
+
+	outcome := constant.OutcomeAbandoned
+	if next.Status == constant.ExpiredQuest {
+		outcome = constant.OutcomeExpired
+		if err = fundEscrow(repos, quest, 0, now); err != nil {
+			return false, err
+		}
+	}
+	takers, err := unlinkTakers(repos, quest.ID, outcome, now)
 	if err != nil {
-		return err
+		return false, err
 	}
 	if quest.Status != constant.WorkingQuest {
-		return errors.New("quest have not taken")
+		return true, nil
 	}
-	if !is {
-		quest := model.Quest{
-			ID:     quest_id,
-			Status: constant.AvailableQuest,
+	return true, failTakers(repos, policy, takers)
+}
+
+// CancelQuest withdraws an available or working quest from the board. The
+// quest row is kept with the cancelled status, its escrow is refunded and the
+// adventurers in its party are unlinked without a failed quest; their ids are
+// returned so they can be told.
+func (u *usecase) CancelQuest(quest_id int64) (released []int64, err error) {
+	now := u.clock.Now()
+	err = u.uow.Do(func(repos unitofwork.Repositories) (err error) {
+		quest, err := repos.Quest.GetQuest(quest_id)
+		if err != nil {
+			return found(err, ErrQuestNotFound)
 		}
-		return u.repo.UpdateQuestStatus(quest)
-	} else {
-		err := u.repoAdv.AddCompletedQuest(adventurer_id)
+		if err = closed(quest); err != nil {
+			return
+		}
+
+		updated, err := repos.Quest.UpdateQuestStatusIf(model.Quest{ID: quest_id, Status: constant.CancelledQuest}, quest.Status)
 		if err != nil {
-			return err
+			return
 		}
-		quest := model.Quest{
-			ID:     quest_id,
-			Status: constant.CompletedQuest,
+		if !updated {
+			return ErrQuestChanged
 		}
-		err = u.repo.UpdateQuestStatus(quest)
-		return err
+		if err = fundEscrow(repos, quest, 0, now); err != nil {
+			return
+		}
+		released, err = unlinkTakers(repos, quest_id, constant.OutcomeCancelled, now)
+		return
+	})
+	if err != nil {
+		return nil, err
 	}
+	return
 }
 
-func (u *usecase) GetQuestActiveAdventurer(adv_id int64) ([]model.Quest, error) {
-	return u.repo.GetQuestActiveAdventurer(adv_id)
+// unlinkTakers removes every adventurer from the quest, closing their
+// assignments with outcome at now, clears when it was taken and returns who was
+// removed.
+func unlinkTakers(repos unitofwork.Repositories, quest_id int64, outcome string, now time.Time) ([]int64, error) {
+	takers, err := repos.Quest.GetTakenBy(quest_id)
+	if err != nil {
+		return nil, err
+	}
+	advIDs := make([]int64, 0, len(takers))
+	for _, taken := range takers {
+		if err = repos.Quest.DeleteTakenBy(taken.QuestID, taken.AdventurerID); err != nil {
+			return nil, err
+		}
+		if err = finishAssignment(repos, taken.QuestID, taken.AdventurerID, outcome, now); err != nil {
+			return nil, err
+		}
+		advIDs = append(advIDs, taken.AdventurerID)
+	}
+	return advIDs, repos.Quest.UpdateQuestTakenAt(model.Quest{ID: quest_id})
+}
+
+func finishAssignment(repos unitofwork.Repositories, quest_id, adv_id int64, outcome string, now time.Time) error {
+	return repos.Quest.FinishAssignment(model.Assignment{QuestID: quest_id, AdventurerID: adv_id, FinishedAt: &now, Outcome: outcome})
 }
@@ -6,6 +6,7 @@ package quest
 
 import (
 	reflect "reflect"
+	time "time"
 
 	quest "github.com/arfaghifari/guild-board/src/model/quest"
 	gomock "github.com/golang/mock/gomock"
@@ -46,6 +47,20 @@ func (mr *MockRepositoryMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRepository)(nil).Close))
 }
 
+// CreateAssignment mocks base method.
+func (m *MockRepository) CreateAssignment(arg0 quest.Assignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAssignment", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAssignment indicates an expected call of CreateAssignment.
+func (mr *MockRepositoryMockRecorder) CreateAssignment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAssignment", reflect.TypeOf((*MockRepository)(nil).CreateAssignment), arg0)
+}
+
 // CreateQuest mocks base method.
 func (m *MockRepository) CreateQuest(arg0 quest.Quest) (quest.Quest, error) {
 	m.ctrl.T.Helper()
@@ -89,34 +104,47 @@ func (mr *MockRepositoryMockRecorder) DeleteQuest(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuest", reflect.TypeOf((*MockRepository)(nil).DeleteQuest), arg0)
 }
 
-// GetAllAvailableQuest mocks base method.
-func (m *MockRepository) GetAllAvailableQuest() ([]quest.GetQuestByStatus, error) {
+// DeleteTakenBy mocks base method.
+func (m *MockRepository) DeleteTakenBy(arg0, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllAvailableQuest")
-	ret0, _ := ret[0].([]quest.GetQuestByStatus)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DeleteTakenBy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetAllAvailableQuest indicates an expected call of GetAllAvailableQuest.
-func (mr *MockRepositoryMockRecorder) GetAllAvailableQuest() *gomock.Call {
+// DeleteTakenBy indicates an expected call of DeleteTakenBy.
+func (mr *MockRepositoryMockRecorder) DeleteTakenBy(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAvailableQuest", reflect.TypeOf((*MockRepository)(nil).GetAllAvailableQuest))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTakenBy", reflect.TypeOf((*MockRepository)(nil).DeleteTakenBy), arg0, arg1)
 }
 
-// GetAllCompletedQuest mocks base method.
-func (m *MockRepository) GetAllCompletedQuest() ([]quest.GetQuestByStatus, error) {
+// FinishAssignment mocks base method.
+func (m *MockRepository) FinishAssignment(arg0 quest.Assignment) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllCompletedQuest")
-	ret0, _ := ret[0].([]quest.GetQuestByStatus)
+	ret := m.ctrl.Call(m, "FinishAssignment", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishAssignment indicates an expected call of FinishAssignment.
+func (mr *MockRepositoryMockRecorder) FinishAssignment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishAssignment", reflect.TypeOf((*MockRepository)(nil).FinishAssignment), arg0)
+}
+
+// GetOverdueQuests mocks base method.
+func (m *MockRepository) GetOverdueQuests(arg0, arg1 time.Time) ([]quest.Quest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdueQuests", arg0, arg1)
+	ret0, _ := ret[0].([]quest.Quest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllCompletedQuest indicates an expected call of GetAllCompletedQuest.
-func (mr *MockRepositoryMockRecorder) GetAllCompletedQuest() *gomock.Call {
+// GetOverdueQuests indicates an expected call of GetOverdueQuests.
+func (mr *MockRepositoryMockRecorder) GetOverdueQuests(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCompletedQuest", reflect.TypeOf((*MockRepository)(nil).GetAllCompletedQuest))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueQuests", reflect.TypeOf((*MockRepository)(nil).GetOverdueQuests), arg0, arg1)
 }
 
 // GetQuest mocks base method.
@@ -149,6 +177,36 @@ func (mr *MockRepositoryMockRecorder) GetQuestActiveAdventurer(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestActiveAdventurer", reflect.TypeOf((*MockRepository)(nil).GetQuestActiveAdventurer), arg0)
 }
 
+// GetQuestsByStatus mocks base method.
+func (m *MockRepository) GetQuestsByStatus(arg0 int32) ([]quest.GetQuestByStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestsByStatus", arg0)
+	ret0, _ := ret[0].([]quest.GetQuestByStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestsByStatus indicates an expected call of GetQuestsByStatus.
+func (mr *MockRepositoryMockRecorder) GetQuestsByStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestsByStatus", reflect.TypeOf((*MockRepository)(nil).GetQuestsByStatus), arg0)
+}
+
+// GetTakenBy mocks base method.
+func (m *MockRepository) GetTakenBy(arg0 int64) ([]quest.TakenBy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTakenBy", arg0)
+	ret0, _ := ret[0].([]quest.TakenBy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTakenBy indicates an expected call of GetTakenBy.
+func (mr *MockRepositoryMockRecorder) GetTakenBy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTakenBy", reflect.TypeOf((*MockRepository)(nil).GetTakenBy), arg0)
+}
+
 // IsExistTakenBy mocks base method.
 func (m *MockRepository) IsExistTakenBy(arg0, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -163,6 +221,68 @@ func (mr *MockRepositoryMockRecorder) IsExistTakenBy(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExistTakenBy", reflect.TypeOf((*MockRepository)(nil).IsExistTakenBy), arg0, arg1)
 }
 
+// JoinQuest mocks base method.
+func (m *MockRepository) JoinQuest(arg0 quest.Quest) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinQuest", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JoinQuest indicates an expected call of JoinQuest.
+func (mr *MockRepositoryMockRecorder) JoinQuest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinQuest", reflect.TypeOf((*MockRepository)(nil).JoinQuest), arg0)
+}
+
+// ListAssignments mocks base method.
+func (m *MockRepository) ListAssignments(arg0 quest.AssignmentFilter) ([]quest.Assignment, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAssignments", arg0)
+	ret0, _ := ret[0].([]quest.Assignment)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAssignments indicates an expected call of ListAssignments.
+func (mr *MockRepositoryMockRecorder) ListAssignments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssignments", reflect.TypeOf((*MockRepository)(nil).ListAssignments), arg0)
+}
+
+// ListQuests mocks base method.
+func (m *MockRepository) ListQuests(arg0 quest.QuestFilter) ([]quest.Quest, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQuests", arg0)
+	ret0, _ := ret[0].([]quest.Quest)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListQuests indicates an expected call of ListQuests.
+func (mr *MockRepositoryMockRecorder) ListQuests(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQuests", reflect.TypeOf((*MockRepository)(nil).ListQuests), arg0)
+}
+
+// ListTakers mocks base method.
+func (m *MockRepository) ListTakers(arg0 []int64) (map[int64][]quest.Taker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTakers", arg0)
+	ret0, _ := ret[0].(map[int64][]quest.Taker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTakers indicates an expected call of ListTakers.
+func (mr *MockRepositoryMockRecorder) ListTakers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTakers", reflect.TypeOf((*MockRepository)(nil).ListTakers), arg0)
+}
+
 // UpdateQuestRank mocks base method.
 func (m *MockRepository) UpdateQuestRank(arg0 quest.Quest) error {
 	m.ctrl.T.Helper()
@@ -203,4 +323,88 @@ func (m *MockRepository) UpdateQuestStatus(arg0 quest.Quest) error {
 func (mr *MockRepositoryMockRecorder) UpdateQuestStatus(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuestStatus", reflect.TypeOf((*MockRepository)(nil).UpdateQuestStatus), arg0)
-}
\ No newline at end of file
+}
+
+// UpdateQuestStatusIf mocks base method.
+func (m *MockRepository) UpdateQuestStatusIf(arg0 quest.Quest, arg1 int32) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuestStatusIf", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateQuestStatusIf indicates an expected call of UpdateQuestStatusIf.
+func (mr *MockRepositoryMockRecorder) UpdateQuestStatusIf(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuestStatusIf", reflect.TypeOf((*MockRepository)(nil).UpdateQuestStatusIf), arg0, arg1)
+}
+
+// UpdateQuestTakenAt mocks base method.
+func (m *MockRepository) UpdateQuestTakenAt(arg0 quest.Quest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuestTakenAt", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQuestTakenAt indicates an expected call of UpdateQuestTakenAt.
+func (mr *MockRepositoryMockRecorder) UpdateQuestTakenAt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuestTakenAt", reflect.TypeOf((*MockRepository)(nil).UpdateQuestTakenAt), arg0)
+}
+
+// UpdateTakenByReward mocks base method.
+func (m *MockRepository) UpdateTakenByReward(arg0 quest.TakenBy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTakenByReward", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTakenByReward indicates an expected call of UpdateTakenByReward.
+func (mr *MockRepositoryMockRecorder) UpdateTakenByReward(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTakenByReward", reflect.TypeOf((*MockRepository)(nil).UpdateTakenByReward), arg0)
+}
+
+// Mockscanner is a mock of scanner interface.
+type Mockscanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockscannerMockRecorder
+}
+
+// MockscannerMockRecorder is the mock recorder for Mockscanner.
+type MockscannerMockRecorder struct {
+	mock *Mockscanner
+}
+
+// NewMockscanner creates a new mock instance.
+func NewMockscanner(ctrl *gomock.Controller) *Mockscanner {
+	mock := &Mockscanner{ctrl: ctrl}
+	mock.recorder = &MockscannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockscanner) EXPECT() *MockscannerMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *Mockscanner) Scan(arg0 ...interface{}) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Scan", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockscannerMockRecorder) Scan(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*Mockscanner)(nil).Scan), arg0...)
+}
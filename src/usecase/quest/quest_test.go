@@ -1,12 +1,25 @@
 package quest
 
 import (
+	"database/sql"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/arfaghifari/guild-board/src/clock"
+	"github.com/arfaghifari/guild-board/src/config"
 	constant "github.com/arfaghifari/guild-board/src/constant"
+	"github.com/arfaghifari/guild-board/src/database"
+	"github.com/arfaghifari/guild-board/src/database/databasetest"
+	"github.com/arfaghifari/guild-board/src/database/memory"
 	modelAdv "github.com/arfaghifari/guild-board/src/model/adventurer"
+	modelLedger "github.com/arfaghifari/guild-board/src/model/ledger"
 	model "github.com/arfaghifari/guild-board/src/model/quest"
+	repoAdv "github.com/arfaghifari/guild-board/src/repository/adventurer"
+	repo "github.com/arfaghifari/guild-board/src/repository/quest"
+	"github.com/arfaghifari/guild-board/src/repository/unitofwork"
+	advUsecase "github.com/arfaghifari/guild-board/src/usecase/adventurer"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
@@ -18,6 +31,15 @@ var adv = modelAdv.Adventurer{
 	CompletedQuest: 1,
 }
 
+// policy is the rank policy given to every usecase under test.
+var policy, _ = advUsecase.NewPolicy(config.Progression{PromotionPoints: 10, RewardUnit: 100000, DemotionFailures: 3, MinRank: 1, MaxRank: 100})
+
+// noFees is the fee policy of a guild that keeps nothing of the rewards.
+var noFees, _ = NewFeePolicy(config.Commission{})
+
+// now is the time on the clock given to every usecase under test.
+var now = time.Date(2023, time.July, 1, 9, 0, 0, 0, time.UTC)
+
 var bulkQuest = []model.Quest{
 	{
 		ID:           1,
@@ -26,6 +48,9 @@ var bulkQuest = []model.Quest{
 		MinimumRank:  11,
 		RewardNumber: 200000,
 		Status:       constant.AvailableQuest,
+		MinMembers:   1,
+		MaxMembers:   1,
+		RankRule:     constant.RankRuleAll,
 	},
 	{
 		ID:           2,
@@ -34,6 +59,9 @@ var bulkQuest = []model.Quest{
 		MinimumRank:  12,
 		RewardNumber: 200000,
 		Status:       constant.AvailableQuest,
+		MinMembers:   1,
+		MaxMembers:   1,
+		RankRule:     constant.RankRuleAll,
 	},
 	{
 		ID:           3,
@@ -42,6 +70,10 @@ var bulkQuest = []model.Quest{
 		MinimumRank:  13,
 		RewardNumber: 600000,
 		Status:       constant.CompletedQuest,
+		MinMembers:   1,
+		MaxMembers:   1,
+		RankRule:     constant.RankRuleAll,
+		Members:      1,
 	},
 	{
 		ID:           4,
@@ -50,6 +82,10 @@ var bulkQuest = []model.Quest{
 		MinimumRank:  12,
 		RewardNumber: 200000,
 		Status:       constant.WorkingQuest,
+		MinMembers:   1,
+		MaxMembers:   1,
+		RankRule:     constant.RankRuleAll,
+		Members:      1,
 	},
 }
 
@@ -78,9 +114,43 @@ var bulkQuestByStatus = []model.GetQuestByStatus{
 }
 
 func TestNewUsecase(t *testing.T) {
-	res, err := NewUsecase()
-	assert.NoError(t, err)
-	assert.NotNil(t, res)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	questRepo := NewMockRepository(mockCtrl)
+	advRepo := NewAdvMockRepository(mockCtrl)
+	uow := NewMockUnitOfWork(mockCtrl)
+	clk := clock.NewClock()
+
+	tests := []struct {
+		name    string
+		repo    repo.Repository
+		repoAdv repoAdv.Repository
+		uow     unitofwork.UnitOfWork
+		clock   clock.Clock
+		policy  advUsecase.Policy
+		fees    FeePolicy
+		wantErr bool
+	}{
+		{name: "all dependencies", repo: questRepo, repoAdv: advRepo, uow: uow, clock: clk, policy: policy, fees: noFees},
+		{name: "missing quest repository", repoAdv: advRepo, uow: uow, clock: clk, policy: policy, fees: noFees, wantErr: true},
+		{name: "missing adventurer repository", repo: questRepo, uow: uow, clock: clk, policy: policy, fees: noFees, wantErr: true},
+		{name: "missing unit of work", repo: questRepo, repoAdv: advRepo, clock: clk, policy: policy, fees: noFees, wantErr: true},
+		{name: "missing clock", repo: questRepo, repoAdv: advRepo, uow: uow, policy: policy, fees: noFees, wantErr: true},
+		{name: "missing policy", repo: questRepo, repoAdv: advRepo, uow: uow, clock: clk, fees: noFees, wantErr: true},
+		{name: "missing fee policy", repo: questRepo, repoAdv: advRepo, uow: uow, clock: clk, policy: policy, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := NewUsecase(tt.repo, tt.repoAdv, tt.uow, tt.clock, tt.policy, tt.fees)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+				assert.Nil(t, res)
+				return
+			}
+			assert.NoError(t, err, tt.name)
+			assert.NotNil(t, res)
+		})
+	}
 }
 
 func TestCreateQuest(t *testing.T) {
@@ -93,12 +163,37 @@ func TestCreateQuest(t *testing.T) {
 	type args struct {
 		quest model.Quest
 	}
+	created := bulkQuest[0]
+	created.CreatedAt = now
+	deadline := now.Add(24 * time.Hour)
+	withDeadline := created
+	withDeadline.Deadline = &deadline
+	past := now.Add(-time.Minute)
+	withPastDeadline := bulkQuest[0]
+	withPastDeadline.Deadline = &past
+	withoutParty := bulkQuest[0]
+	withoutParty.MinMembers, withoutParty.MaxMembers, withoutParty.RankRule = 0, 0, ""
+	party := created
+	party.MinMembers, party.MaxMembers, party.RankRule = 2, 4, constant.RankRuleAverage
+	minOnly := bulkQuest[0]
+	minOnly.MinMembers, minOnly.MaxMembers = 3, 0
+	minOnlyCreated := created
+	minOnlyCreated.MinMembers, minOnlyCreated.MaxMembers = 3, 3
+	invalidParty := bulkQuest[0]
+	invalidParty.MinMembers, invalidParty.MaxMembers = 3, 2
+	invalidRule := bulkQuest[0]
+	invalidRule.RankRule = "best"
+	posted := bulkQuest[0]
+	posted.CreatedBy = 7
+	postedCreated := created
+	postedCreated.CreatedBy = 7
 	tests := []struct {
 		name     string
 		fields   fields
 		args     args
-		mock     func(*MockRepository)
+		mock     func(*MockRepository, *LedgerMockRepository)
 		outQuest model.Quest
+		outErr   error
 		wantErr  bool
 	}{
 		{
@@ -109,12 +204,142 @@ func TestCreateQuest(t *testing.T) {
 			args: args{
 				quest: bulkQuest[0],
 			},
-			mock: func(repo *MockRepository) {
-				repo.EXPECT().CreateQuest(bulkQuest[0]).Return(bulkQuest[0], nil).Times(1)
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().CreateQuest(created).Return(created, nil).Times(1)
+				expectEscrow(ledgerRepo, 1, 0, transfer(1, constant.LedgerEscrow, modelLedger.Guild, modelLedger.EscrowAccount(1), 200000))
 			},
-			outQuest: bulkQuest[0],
+			outQuest: created,
+			wantErr:  false,
+		},
+		{
+			name: "success created a quest with deadline",
+			fields: fields{
+				r: NewMockRepository(mockCtrl),
+			},
+			args: args{
+				quest: withDeadline,
+			},
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().CreateQuest(withDeadline).Return(withDeadline, nil).Times(1)
+				expectEscrow(ledgerRepo, 1, 0, transfer(1, constant.LedgerEscrow, modelLedger.Guild, modelLedger.EscrowAccount(1), 200000))
+			},
+			outQuest: withDeadline,
+			wantErr:  false,
+		},
+		{
+			name: "success created a quest for one adventurer by default",
+			fields: fields{
+				r: NewMockRepository(mockCtrl),
+			},
+			args: args{
+				quest: withoutParty,
+			},
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().CreateQuest(created).Return(created, nil).Times(1)
+				expectEscrow(ledgerRepo, 1, 0, transfer(1, constant.LedgerEscrow, modelLedger.Guild, modelLedger.EscrowAccount(1), 200000))
+			},
+			outQuest: created,
+			wantErr:  false,
+		},
+		{
+			name: "success created a party quest",
+			fields: fields{
+				r: NewMockRepository(mockCtrl),
+			},
+			args: args{
+				quest: party,
+			},
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().CreateQuest(party).Return(party, nil).Times(1)
+				expectEscrow(ledgerRepo, 1, 0, transfer(1, constant.LedgerEscrow, modelLedger.Guild, modelLedger.EscrowAccount(1), 200000))
+			},
+			outQuest: party,
+			wantErr:  false,
+		},
+		{
+			name: "success created a party quest with only a minimum size",
+			fields: fields{
+				r: NewMockRepository(mockCtrl),
+			},
+			args: args{
+				quest: minOnly,
+			},
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().CreateQuest(minOnlyCreated).Return(minOnlyCreated, nil).Times(1)
+				expectEscrow(ledgerRepo, 1, 0, transfer(1, constant.LedgerEscrow, modelLedger.Guild, modelLedger.EscrowAccount(1), 200000))
+			},
+			outQuest: minOnlyCreated,
+			wantErr:  false,
+		},
+		{
+			name: "success escrowed the reward from the quest giver",
+			fields: fields{
+				r: NewMockRepository(mockCtrl),
+			},
+			args: args{
+				quest: posted,
+			},
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().CreateQuest(postedCreated).Return(postedCreated, nil).Times(1)
+				expectEscrow(ledgerRepo, 1, 0, transfer(1, constant.LedgerEscrow, modelLedger.GiverAccount(7), modelLedger.EscrowAccount(1), 200000))
+			},
+			outQuest: postedCreated,
 			wantErr:  false,
 		},
+		{
+			name: "failed escrowing the reward",
+			fields: fields{
+				r: NewMockRepository(mockCtrl),
+			},
+			args: args{
+				quest: bulkQuest[0],
+			},
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().CreateQuest(created).Return(created, nil).Times(1)
+				ledgerRepo.EXPECT().GetBalance(modelLedger.EscrowAccount(1)).Return(modelLedger.Balance{}, errors.New("any error")).Times(1)
+			},
+			outQuest: model.Quest{},
+			wantErr:  true,
+		},
+		{
+			name: "failed created a quest with invalid party size",
+			fields: fields{
+				r: NewMockRepository(mockCtrl),
+			},
+			args: args{
+				quest: invalidParty,
+			},
+			mock:     func(*MockRepository, *LedgerMockRepository) {},
+			outQuest: model.Quest{},
+			outErr:   ErrInvalidParty,
+			wantErr:  true,
+		},
+		{
+			name: "failed created a quest with invalid rank rule",
+			fields: fields{
+				r: NewMockRepository(mockCtrl),
+			},
+			args: args{
+				quest: invalidRule,
+			},
+			mock:     func(*MockRepository, *LedgerMockRepository) {},
+			outQuest: model.Quest{},
+			outErr:   ErrInvalidRule,
+			wantErr:  true,
+		},
+		{
+			name: "failed created a quest with past deadline",
+			fields: fields{
+				r: NewMockRepository(mockCtrl),
+			},
+			args: args{
+				quest: withPastDeadline,
+			},
+			mock:     func(*MockRepository, *LedgerMockRepository) {},
+			outQuest: model.Quest{},
+			outErr:   ErrPastDeadline,
+			wantErr:  true,
+		},
 		{
 			name: "failed created a quest",
 			fields: fields{
@@ -123,8 +348,8 @@ func TestCreateQuest(t *testing.T) {
 			args: args{
 				quest: bulkQuest[0],
 			},
-			mock: func(repo *MockRepository) {
-				repo.EXPECT().CreateQuest(bulkQuest[0]).Return(model.Quest{}, errors.New("any error")).Times(1)
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().CreateQuest(created).Return(model.Quest{}, errors.New("any error")).Times(1)
 			},
 			outQuest: model.Quest{},
 			wantErr:  true,
@@ -132,10 +357,16 @@ func TestCreateQuest(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			uow, ledgerRepo := NewMockUnitOfWork(mockCtrl), NewLedgerMockRepository(mockCtrl)
 			u := &usecase{
-				repo: tt.fields.r,
+				repo:  tt.fields.r,
+				uow:   uow,
+				clock: clock.Fixed(now),
 			}
-			tt.mock(tt.fields.r)
+			if tt.outErr == nil {
+				withinTx(uow, tt.fields.r, nil, ledgerRepo)
+			}
+			tt.mock(tt.fields.r, ledgerRepo)
 			res, err := u.CreateQuest(tt.args.quest)
 			assert.Equal(t, tt.outQuest, res)
 			if tt.wantErr {
@@ -143,6 +374,9 @@ func TestCreateQuest(t *testing.T) {
 			} else {
 				assert.NoError(t, err, tt.name)
 			}
+			if tt.outErr != nil {
+				assert.Equal(t, tt.outErr, err, tt.name)
+			}
 		})
 	}
 }
@@ -162,6 +396,7 @@ func TestUpdateQuestRank(t *testing.T) {
 		fields  fields
 		args    args
 		mock    func(*MockRepository)
+		outErr  error
 		wantErr bool
 	}{
 		{
@@ -173,10 +408,25 @@ func TestUpdateQuestRank(t *testing.T) {
 				quest: bulkQuest[0],
 			},
 			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[0].ID).Return(bulkQuest[0], nil).Times(1)
 				repo.EXPECT().UpdateQuestRank(bulkQuest[0]).Return(nil).Times(1)
 			},
 			wantErr: false,
 		},
+		{
+			name: "failed quest not found",
+			fields: fields{
+				r: NewMockRepository(mockCtrl),
+			},
+			args: args{
+				quest: bulkQuest[0],
+			},
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[0].ID).Return(model.Quest{}, sql.ErrNoRows).Times(1)
+			},
+			outErr:  ErrQuestNotFound,
+			wantErr: true,
+		},
 		{
 			name: "failed updated a quest rank",
 			fields: fields{
@@ -186,6 +436,7 @@ func TestUpdateQuestRank(t *testing.T) {
 				quest: bulkQuest[0],
 			},
 			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[0].ID).Return(bulkQuest[0], nil).Times(1)
 				repo.EXPECT().UpdateQuestRank(bulkQuest[0]).Return(errors.New("any error")).Times(1)
 			},
 			wantErr: true,
@@ -203,6 +454,9 @@ func TestUpdateQuestRank(t *testing.T) {
 			} else {
 				assert.NoError(t, err, tt.name)
 			}
+			if tt.outErr != nil {
+				assert.Equal(t, tt.outErr, err, tt.name)
+			}
 		})
 	}
 }
@@ -211,58 +465,191 @@ func TestUpdateQuestReward(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
-	type fields struct {
-		r *MockRepository
+	raised := model.Quest{ID: bulkQuest[0].ID, RewardNumber: 300000}
+	lowered := model.Quest{ID: bulkQuest[3].ID, RewardNumber: 150000}
+	completed := model.Quest{ID: bulkQuest[2].ID, RewardNumber: 700000}
+	escrow := modelLedger.EscrowAccount(bulkQuest[0].ID)
+	tests := []struct {
+		name    string
+		quest   model.Quest
+		mock    func(*MockRepository, *LedgerMockRepository)
+		outErr  error
+		wantErr bool
+	}{
+		{
+			name:  "success raised the reward and its escrow",
+			quest: raised,
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(raised.ID).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().UpdateQuestReward(raised).Return(nil).Times(1)
+				expectEscrow(ledgerRepo, raised.ID, 200000, transfer(raised.ID, constant.LedgerEscrow, modelLedger.Guild, escrow, 100000))
+			},
+			wantErr: false,
+		},
+		{
+			name:  "success lowered the reward of a working quest and refunded the difference",
+			quest: lowered,
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(lowered.ID).Return(bulkQuest[3], nil).Times(1)
+				repo.EXPECT().UpdateQuestReward(lowered).Return(nil).Times(1)
+				expectEscrow(ledgerRepo, lowered.ID, 200000, transfer(lowered.ID, constant.LedgerRefund,
+					modelLedger.EscrowAccount(lowered.ID), modelLedger.Guild, 50000))
+			},
+			wantErr: false,
+		},
+		{
+			name:  "failed quest completed",
+			quest: completed,
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(completed.ID).Return(bulkQuest[2], nil).Times(1)
+			},
+			outErr:  ErrQuestDone,
+			wantErr: true,
+		},
+		{
+			name:  "failed quest not found",
+			quest: raised,
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(raised.ID).Return(model.Quest{}, sql.ErrNoRows).Times(1)
+			},
+			outErr:  ErrQuestNotFound,
+			wantErr: true,
+		},
+		{
+			name:  "failed updated a quest reward",
+			quest: raised,
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(raised.ID).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().UpdateQuestReward(raised).Return(errors.New("any errors")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "failed escrowed the difference",
+			quest: raised,
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(raised.ID).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().UpdateQuestReward(raised).Return(nil).Times(1)
+				ledgerRepo.EXPECT().GetBalance(escrow).Return(modelLedger.Balance{}, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
 	}
-	type args struct {
-		quest model.Quest
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, ledgerRepo, uow := NewMockRepository(mockCtrl), NewLedgerMockRepository(mockCtrl), NewMockUnitOfWork(mockCtrl)
+			u := &usecase{
+				uow:   uow,
+				clock: clock.Fixed(now),
+			}
+			withinTx(uow, r, nil, ledgerRepo)
+			tt.mock(r, ledgerRepo)
+			err := u.UpdateQuestReward(tt.quest)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			if tt.outErr != nil {
+				assert.Equal(t, tt.outErr, err, tt.name)
+			}
+		})
 	}
+}
+
+func TestPatchQuest(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	both := model.Quest{ID: bulkQuest[0].ID, MinimumRank: 12, RewardNumber: 300000}
+	rank := model.Quest{ID: bulkQuest[0].ID, MinimumRank: 12}
+	reward := model.Quest{ID: bulkQuest[0].ID, RewardNumber: 300000}
+	escrow := modelLedger.EscrowAccount(bulkQuest[0].ID)
 	tests := []struct {
 		name    string
-		fields  fields
-		args    args
-		mock    func(*MockRepository)
+		quest   model.Quest
+		mock    func(*MockRepository, *LedgerMockRepository)
+		outErr  error
 		wantErr bool
 	}{
 		{
-			name: "success updated a quest reward",
-			fields: fields{
-				r: NewMockRepository(mockCtrl),
+			name:  "success changed the rank and the reward",
+			quest: both,
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(both.ID).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().UpdateQuestRank(both).Return(nil).Times(1)
+				repo.EXPECT().UpdateQuestReward(both).Return(nil).Times(1)
+				expectEscrow(ledgerRepo, both.ID, 200000, transfer(both.ID, constant.LedgerEscrow, modelLedger.Guild, escrow, 100000))
 			},
-			args: args{
-				quest: bulkQuest[0],
+			wantErr: false,
+		},
+		{
+			name:  "success changed the rank only",
+			quest: rank,
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(rank.ID).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().UpdateQuestRank(rank).Return(nil).Times(1)
 			},
-			mock: func(repo *MockRepository) {
-				repo.EXPECT().UpdateQuestReward(bulkQuest[0]).Return(nil).Times(1)
+			wantErr: false,
+		},
+		{
+			name:  "success changed the reward only",
+			quest: reward,
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(reward.ID).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().UpdateQuestReward(reward).Return(nil).Times(1)
+				expectEscrow(ledgerRepo, reward.ID, 200000, transfer(reward.ID, constant.LedgerEscrow, modelLedger.Guild, escrow, 100000))
 			},
 			wantErr: false,
 		},
 		{
-			name: "failed updated a quest reward",
-			fields: fields{
-				r: NewMockRepository(mockCtrl),
+			name:  "failed quest not found",
+			quest: rank,
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(rank.ID).Return(model.Quest{}, sql.ErrNoRows).Times(1)
 			},
-			args: args{
-				quest: bulkQuest[0],
+			outErr:  ErrQuestNotFound,
+			wantErr: true,
+		},
+		{
+			name:  "failed changed the rank",
+			quest: both,
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(both.ID).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().UpdateQuestRank(both).Return(errors.New("any error")).Times(1)
 			},
-			mock: func(repo *MockRepository) {
-				repo.EXPECT().UpdateQuestReward(bulkQuest[0]).Return(errors.New("any errors")).Times(1)
+			wantErr: true,
+		},
+		{
+			name:  "failed escrowed the difference",
+			quest: both,
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(both.ID).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().UpdateQuestRank(both).Return(nil).Times(1)
+				repo.EXPECT().UpdateQuestReward(both).Return(nil).Times(1)
+				ledgerRepo.EXPECT().GetBalance(escrow).Return(modelLedger.Balance{}, errors.New("any error")).Times(1)
 			},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			r, ledgerRepo, uow := NewMockRepository(mockCtrl), NewLedgerMockRepository(mockCtrl), NewMockUnitOfWork(mockCtrl)
 			u := &usecase{
-				repo: tt.fields.r,
+				uow:   uow,
+				clock: clock.Fixed(now),
 			}
-			tt.mock(tt.fields.r)
-			err := u.UpdateQuestReward(tt.args.quest)
+			withinTx(uow, r, nil, ledgerRepo)
+			tt.mock(r, ledgerRepo)
+			err := u.PatchQuest(tt.quest)
 			if tt.wantErr {
 				assert.Error(t, err, tt.name)
 			} else {
 				assert.NoError(t, err, tt.name)
 			}
+			if tt.outErr != nil {
+				assert.Equal(t, tt.outErr, err, tt.name)
+			}
 		})
 	}
 }
@@ -271,62 +658,121 @@ func TestDeleteQuest(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
-	type fields struct {
-		r *MockRepository
-	}
-	type args struct {
-		quest model.Quest
-	}
+	quest := model.Quest{ID: bulkQuest[0].ID}
+	escrow := modelLedger.EscrowAccount(quest.ID)
+	takers := []model.TakenBy{{QuestID: quest.ID, AdventurerID: 1}, {QuestID: quest.ID, AdventurerID: 2}}
 	tests := []struct {
 		name    string
-		fields  fields
-		args    args
-		mock    func(*MockRepository)
+		mock    func(*MockRepository, *LedgerMockRepository)
+		outErr  error
 		wantErr bool
 	}{
 		{
-			name: "success deleted a quest",
-			fields: fields{
-				r: NewMockRepository(mockCtrl),
+			name: "success deleted a quest and refunded its escrow",
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(quest.ID).Return(bulkQuest[0], nil).Times(1)
+				expectEscrow(ledgerRepo, quest.ID, 200000, transfer(quest.ID, constant.LedgerRefund, escrow, modelLedger.Guild, 200000))
+				repo.EXPECT().GetTakenBy(quest.ID).Return(nil, nil).Times(1)
+				repo.EXPECT().DeleteQuest(quest).Return(nil).Times(1)
 			},
-			args: args{
-				quest: bulkQuest[0],
+			wantErr: false,
+		},
+		{
+			name: "success deleted a quest with an empty escrow",
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(quest.ID).Return(bulkQuest[0], nil).Times(1)
+				expectEscrow(ledgerRepo, quest.ID, 0)
+				repo.EXPECT().GetTakenBy(quest.ID).Return(nil, nil).Times(1)
+				repo.EXPECT().DeleteQuest(quest).Return(nil).Times(1)
 			},
-			mock: func(repo *MockRepository) {
-				repo.EXPECT().DeleteQuest(bulkQuest[0]).Return(nil).Times(1)
+			wantErr: false,
+		},
+		{
+			name: "success deleted a quest and closed the history of its party",
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(quest.ID).Return(bulkQuest[0], nil).Times(1)
+				expectEscrow(ledgerRepo, quest.ID, 0)
+				repo.EXPECT().GetTakenBy(quest.ID).Return(takers, nil).Times(1)
+				repo.EXPECT().FinishAssignment(finished(quest.ID, 1, constant.OutcomeDeleted)).Return(nil).Times(1)
+				repo.EXPECT().FinishAssignment(finished(quest.ID, 2, constant.OutcomeDeleted)).Return(nil).Times(1)
+				repo.EXPECT().DeleteQuest(quest).Return(nil).Times(1)
 			},
 			wantErr: false,
 		},
 		{
-			name: "failed deleted a quest",
-			fields: fields{
-				r: NewMockRepository(mockCtrl),
+			name: "failed quest not found",
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(quest.ID).Return(model.Quest{}, sql.ErrNoRows).Times(1)
 			},
-			args: args{
-				quest: bulkQuest[0],
+			outErr:  ErrQuestNotFound,
+			wantErr: true,
+		},
+		{
+			name: "failed refunded the escrow",
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(quest.ID).Return(bulkQuest[0], nil).Times(1)
+				ledgerRepo.EXPECT().GetBalance(escrow).Return(modelLedger.Balance{}, errors.New("any error")).Times(1)
 			},
-			mock: func(repo *MockRepository) {
-				repo.EXPECT().DeleteQuest(bulkQuest[0]).Return(errors.New("any error")).Times(1)
+			wantErr: true,
+		},
+		{
+			name: "failed closed the history of its party",
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(quest.ID).Return(bulkQuest[0], nil).Times(1)
+				expectEscrow(ledgerRepo, quest.ID, 0)
+				repo.EXPECT().GetTakenBy(quest.ID).Return(takers, nil).Times(1)
+				repo.EXPECT().FinishAssignment(finished(quest.ID, 1, constant.OutcomeDeleted)).Return(errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed deleted a quest",
+			mock: func(repo *MockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(quest.ID).Return(bulkQuest[0], nil).Times(1)
+				expectEscrow(ledgerRepo, quest.ID, 0)
+				repo.EXPECT().GetTakenBy(quest.ID).Return(nil, nil).Times(1)
+				repo.EXPECT().DeleteQuest(quest).Return(errors.New("any error")).Times(1)
 			},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			r, ledgerRepo, uow := NewMockRepository(mockCtrl), NewLedgerMockRepository(mockCtrl), NewMockUnitOfWork(mockCtrl)
 			u := &usecase{
-				repo: tt.fields.r,
+				uow:   uow,
+				clock: clock.Fixed(now),
 			}
-			tt.mock(tt.fields.r)
-			err := u.DeleteQuest(tt.args.quest)
+			withinTx(uow, r, nil, ledgerRepo)
+			tt.mock(r, ledgerRepo)
+			err := u.DeleteQuest(quest)
 			if tt.wantErr {
 				assert.Error(t, err, tt.name)
 			} else {
 				assert.NoError(t, err, tt.name)
 			}
+			if tt.outErr != nil {
+				assert.Equal(t, tt.outErr, err, tt.name)
+			}
 		})
 	}
 }
 
+var workingQuestByStatus = []model.GetQuestByStatus{
+	{
+		ID:           2,
+		Name:         "membersihkan selokan",
+		MinimumRank:  11,
+		RewardNumber: 200000,
+		Status:       constant.WorkingQuest,
+		MinMembers:   1,
+		MaxMembers:   1,
+		RankRule:     constant.RankRuleAll,
+		Members:      1,
+		Takers:       []model.Taker{{AdventurerID: 1, Name: "andi", Rank: 11}},
+	},
+}
+
 func TestGetQuestByStatus(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -355,7 +801,7 @@ func TestGetQuestByStatus(t *testing.T) {
 				status: constant.AvailableQuest,
 			},
 			mock: func(repo *MockRepository) {
-				repo.EXPECT().GetAllAvailableQuest().Return([]model.GetQuestByStatus{}, nil).Times(1)
+				repo.EXPECT().GetQuestsByStatus(int32(constant.AvailableQuest)).Return([]model.GetQuestByStatus{}, nil).Times(1)
 			},
 			outLen:   0,
 			outQuest: []model.GetQuestByStatus{},
@@ -370,7 +816,7 @@ func TestGetQuestByStatus(t *testing.T) {
 				status: constant.AvailableQuest,
 			},
 			mock: func(repo *MockRepository) {
-				repo.EXPECT().GetAllAvailableQuest().Return(bulkQuestByStatus[:2], nil).Times(1)
+				repo.EXPECT().GetQuestsByStatus(int32(constant.AvailableQuest)).Return(bulkQuestByStatus[:2], nil).Times(1)
 			},
 			outQuest: bulkQuestByStatus[:2],
 			outLen:   2,
@@ -385,7 +831,7 @@ func TestGetQuestByStatus(t *testing.T) {
 				status: constant.AvailableQuest,
 			},
 			mock: func(repo *MockRepository) {
-				repo.EXPECT().GetAllAvailableQuest().Return([]model.GetQuestByStatus{}, errors.New("any error")).Times(1)
+				repo.EXPECT().GetQuestsByStatus(int32(constant.AvailableQuest)).Return([]model.GetQuestByStatus{}, errors.New("any error")).Times(1)
 			},
 			outQuest: []model.GetQuestByStatus{},
 			outLen:   0,
@@ -400,7 +846,7 @@ func TestGetQuestByStatus(t *testing.T) {
 				status: constant.CompletedQuest,
 			},
 			mock: func(repo *MockRepository) {
-				repo.EXPECT().GetAllCompletedQuest().Return([]model.GetQuestByStatus{}, nil).Times(1)
+				repo.EXPECT().GetQuestsByStatus(int32(constant.CompletedQuest)).Return([]model.GetQuestByStatus{}, nil).Times(1)
 			},
 			outQuest: []model.GetQuestByStatus{},
 			outLen:   0,
@@ -415,7 +861,7 @@ func TestGetQuestByStatus(t *testing.T) {
 				status: constant.CompletedQuest,
 			},
 			mock: func(repo *MockRepository) {
-				repo.EXPECT().GetAllCompletedQuest().Return(bulkQuestByStatus[2:], nil).Times(1)
+				repo.EXPECT().GetQuestsByStatus(int32(constant.CompletedQuest)).Return(bulkQuestByStatus[2:], nil).Times(1)
 			},
 			outQuest: bulkQuestByStatus[2:],
 			outLen:   1,
@@ -430,18 +876,61 @@ func TestGetQuestByStatus(t *testing.T) {
 				status: constant.CompletedQuest,
 			},
 			mock: func(repo *MockRepository) {
-				repo.EXPECT().GetAllCompletedQuest().Return([]model.GetQuestByStatus{}, errors.New("any error")).Times(1)
+				repo.EXPECT().GetQuestsByStatus(int32(constant.CompletedQuest)).Return([]model.GetQuestByStatus{}, errors.New("any error")).Times(1)
 			},
 			outQuest: []model.GetQuestByStatus{},
 			outLen:   0,
 			wantErr:  true,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			u := &usecase{
-				repo: tt.fields.r,
-			}
+		{
+			name: "success get working quest with takers",
+			fields: fields{
+				r: NewMockRepository(mockCtrl),
+			},
+			args: args{
+				status: constant.WorkingQuest,
+			},
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetQuestsByStatus(int32(constant.WorkingQuest)).Return(workingQuestByStatus, nil).Times(1)
+			},
+			outQuest: workingQuestByStatus,
+			outLen:   1,
+			wantErr:  false,
+		},
+		{
+			name: "success get none cancelled quest",
+			fields: fields{
+				r: NewMockRepository(mockCtrl),
+			},
+			args: args{
+				status: constant.CancelledQuest,
+			},
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetQuestsByStatus(int32(constant.CancelledQuest)).Return([]model.GetQuestByStatus{}, nil).Times(1)
+			},
+			outQuest: []model.GetQuestByStatus{},
+			outLen:   0,
+			wantErr:  false,
+		},
+		{
+			name: "invalid status",
+			fields: fields{
+				r: NewMockRepository(mockCtrl),
+			},
+			args: args{
+				status: 5,
+			},
+			mock:     func(repo *MockRepository) {},
+			outQuest: []model.GetQuestByStatus{},
+			outLen:   0,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				repo: tt.fields.r,
+			}
 			tt.mock(tt.fields.r)
 			res, err := u.GetQuestByStatus(tt.args.status)
 			assert.NotNil(t, res)
@@ -456,30 +945,83 @@ func TestGetQuestByStatus(t *testing.T) {
 	}
 }
 
+// finished is the assignment of adv_id on quest_id closed now with outcome.
+func finished(quest_id, adv_id int64, outcome string) model.Assignment {
+	return model.Assignment{QuestID: quest_id, AdventurerID: adv_id, FinishedAt: &now, Outcome: outcome}
+}
+
+// withinTx makes the unit of work run the callback against the given mocks.
+func withinTx(uow *MockUnitOfWork, repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+	uow.EXPECT().Do(gomock.Any()).DoAndReturn(func(fn func(unitofwork.Repositories) error) error {
+		return fn(unitofwork.Repositories{Quest: repo, Adventurer: advRepo, Ledger: ledgerRepo})
+	}).Times(1)
+}
+
+// transfer is the ledger transaction of kind moving amount from one account to
+// another for the quest.
+func transfer(quest_id int64, kind string, from, to modelLedger.Account, amount int64) modelLedger.Transaction {
+	return modelLedger.Transaction{
+		QuestID:   quest_id,
+		Kind:      kind,
+		Entries:   []modelLedger.Entry{{Account: from, Amount: -amount}, {Account: to, Amount: amount}},
+		CreatedAt: now,
+	}
+}
+
+// expectEscrow makes the escrow of the quest hold held and expects the
+// transactions to be posted in order.
+func expectEscrow(ledgerRepo *LedgerMockRepository, quest_id, held int64, posted ...modelLedger.Transaction) {
+	escrow := modelLedger.EscrowAccount(quest_id)
+	ledgerRepo.EXPECT().GetBalance(escrow).Return(modelLedger.Balance{Account: escrow, Balance: held}, nil).Times(1)
+	var prev *gomock.Call
+	for _, tx := range posted {
+		call := ledgerRepo.EXPECT().PostTransaction(tx).Return(tx, nil).Times(1)
+		if prev != nil {
+			call.After(prev)
+		}
+		prev = call
+	}
+}
+
 func TestTakeQuest(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
 	type fields struct {
-		r *MockRepository
-		a *AdvMockRepository
+		r   *MockRepository
+		a   *AdvMockRepository
+		uow *MockUnitOfWork
 	}
 	type args struct {
 		quest_id int64
 		adv_id   int64
 	}
+	joining := model.Quest{ID: 1, TakenAt: &now}
+	party := bulkQuest[3]
+	party.MinimumRank, party.MinMembers, party.MaxMembers, party.Members = 11, 2, 3, 2
+	averageParty := bulkQuest[0]
+	averageParty.MinimumRank, averageParty.RankRule, averageParty.MinMembers, averageParty.MaxMembers, averageParty.Members = 12, constant.RankRuleAverage, 2, 2, 1
+	members := []model.TakenBy{{QuestID: 1, AdventurerID: 2}}
+	expiredQuest := bulkQuest[0]
+	expiredQuest.Status = constant.ExpiredQuest
+	overdueQuest := bulkQuest[0]
+	overdueQuest.Deadline = &now
+	cancelledQuest := bulkQuest[0]
+	cancelledQuest.Status = constant.CancelledQuest
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
 		mock    func(*MockRepository, *AdvMockRepository)
+		outErr  error
 		wantErr bool
 	}{
 		{
 			name: "success took a quest",
 			fields: fields{
-				r: NewMockRepository(mockCtrl),
-				a: NewAdvMockRepository(mockCtrl),
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
 			},
 			args: args{
 				quest_id: 1,
@@ -487,19 +1029,61 @@ func TestTakeQuest(t *testing.T) {
 			},
 			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
 				repo.EXPECT().GetQuest(int64(1)).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().IsExistTakenBy(int64(1), int64(1)).Return(sql.ErrNoRows).Times(1)
 				advRepo.EXPECT().GetAdventurer(int64(1)).Return(adv, nil).Times(1)
+				repo.EXPECT().JoinQuest(joining).Return(true, nil).Times(1)
 				repo.EXPECT().CreateTakenBy(int64(1), int64(1)).Return(nil).Times(1)
-				copyQuest := bulkQuest[0]
-				copyQuest.Status = constant.WorkingQuest
-				repo.EXPECT().UpdateQuestStatus(copyQuest).Return(nil).Times(1)
+				repo.EXPECT().CreateAssignment(model.Assignment{QuestID: 1, AdventurerID: 1, TakenAt: now}).Return(nil).Times(1)
 			},
 			wantErr: false,
 		},
+		{
+			name: "failed took a quest because history not recorded",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id: 1,
+				adv_id:   1,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+				repo.EXPECT().GetQuest(int64(1)).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().IsExistTakenBy(int64(1), int64(1)).Return(sql.ErrNoRows).Times(1)
+				advRepo.EXPECT().GetAdventurer(int64(1)).Return(adv, nil).Times(1)
+				repo.EXPECT().JoinQuest(joining).Return(true, nil).Times(1)
+				repo.EXPECT().CreateTakenBy(int64(1), int64(1)).Return(nil).Times(1)
+				repo.EXPECT().CreateAssignment(model.Assignment{QuestID: 1, AdventurerID: 1, TakenAt: now}).Return(errors.New("any error")).Times(1)
+			},
+			outErr:  errors.New("any error"),
+			wantErr: true,
+		},
+		{
+			name: "failed took a quest because adventurer not found",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id: 1,
+				adv_id:   9,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+				repo.EXPECT().GetQuest(int64(1)).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().IsExistTakenBy(int64(1), int64(9)).Return(sql.ErrNoRows).Times(1)
+				advRepo.EXPECT().GetAdventurer(int64(9)).Return(modelAdv.Adventurer{}, sql.ErrNoRows).Times(1)
+			},
+			outErr:  advUsecase.ErrAdventurerNotFound,
+			wantErr: true,
+		},
 		{
 			name: "failed took a quest because taken",
 			fields: fields{
-				r: NewMockRepository(mockCtrl),
-				a: NewAdvMockRepository(mockCtrl),
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
 			},
 			args: args{
 				quest_id: 3,
@@ -508,13 +1092,35 @@ func TestTakeQuest(t *testing.T) {
 			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
 				repo.EXPECT().GetQuest(int64(3)).Return(bulkQuest[2], nil).Times(1)
 			},
+			outErr:  ErrQuestTaken,
+			wantErr: true,
+		},
+		{
+			name: "failed took a quest because taken concurrently",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id: 1,
+				adv_id:   1,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+				repo.EXPECT().GetQuest(int64(1)).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().IsExistTakenBy(int64(1), int64(1)).Return(sql.ErrNoRows).Times(1)
+				advRepo.EXPECT().GetAdventurer(int64(1)).Return(adv, nil).Times(1)
+				repo.EXPECT().JoinQuest(joining).Return(false, nil).Times(1)
+			},
+			outErr:  ErrQuestTaken,
 			wantErr: true,
 		},
 		{
 			name: "failed took a quest because not enough rank",
 			fields: fields{
-				r: NewMockRepository(mockCtrl),
-				a: NewAdvMockRepository(mockCtrl),
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
 			},
 			args: args{
 				quest_id: 2,
@@ -522,15 +1128,18 @@ func TestTakeQuest(t *testing.T) {
 			},
 			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
 				repo.EXPECT().GetQuest(int64(2)).Return(bulkQuest[1], nil).Times(1)
+				repo.EXPECT().IsExistTakenBy(int64(2), int64(1)).Return(sql.ErrNoRows).Times(1)
 				advRepo.EXPECT().GetAdventurer(int64(1)).Return(adv, nil).Times(1)
 			},
+			outErr:  ErrRankTooLow,
 			wantErr: true,
 		},
 		{
 			name: "failed took a quest because no quest",
 			fields: fields{
-				r: NewMockRepository(mockCtrl),
-				a: NewAdvMockRepository(mockCtrl),
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
 			},
 			args: args{
 				quest_id: 1,
@@ -544,8 +1153,9 @@ func TestTakeQuest(t *testing.T) {
 		{
 			name: "failed took a quest because no adv",
 			fields: fields{
-				r: NewMockRepository(mockCtrl),
-				a: NewAdvMockRepository(mockCtrl),
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
 			},
 			args: args{
 				quest_id: 1,
@@ -553,15 +1163,36 @@ func TestTakeQuest(t *testing.T) {
 			},
 			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
 				repo.EXPECT().GetQuest(int64(1)).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().IsExistTakenBy(int64(1), int64(1)).Return(sql.ErrNoRows).Times(1)
 				advRepo.EXPECT().GetAdventurer(int64(1)).Return(modelAdv.Adventurer{}, errors.New("err")).Times(1)
 			},
 			wantErr: true,
 		},
+		{
+			name: "error update status quest db",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id: 1,
+				adv_id:   1,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+				repo.EXPECT().GetQuest(int64(1)).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().IsExistTakenBy(int64(1), int64(1)).Return(sql.ErrNoRows).Times(1)
+				advRepo.EXPECT().GetAdventurer(int64(1)).Return(adv, nil).Times(1)
+				repo.EXPECT().JoinQuest(joining).Return(false, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
 		{
 			name: "error add taken by to db",
 			fields: fields{
-				r: NewMockRepository(mockCtrl),
-				a: NewAdvMockRepository(mockCtrl),
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
 			},
 			args: args{
 				quest_id: 1,
@@ -569,38 +1200,208 @@ func TestTakeQuest(t *testing.T) {
 			},
 			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
 				repo.EXPECT().GetQuest(int64(1)).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().IsExistTakenBy(int64(1), int64(1)).Return(sql.ErrNoRows).Times(1)
 				advRepo.EXPECT().GetAdventurer(int64(1)).Return(adv, nil).Times(1)
+				repo.EXPECT().JoinQuest(joining).Return(true, nil).Times(1)
 				repo.EXPECT().CreateTakenBy(int64(1), int64(1)).Return(errors.New("any error")).Times(1)
 			},
 			wantErr: true,
 		},
 		{
-			name: "error update status quest db",
+			name: "failed took a full party quest",
 			fields: fields{
-				r: NewMockRepository(mockCtrl),
-				a: NewAdvMockRepository(mockCtrl),
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id: 4,
+				adv_id:   1,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+				repo.EXPECT().GetQuest(int64(4)).Return(bulkQuest[3], nil).Times(1)
+			},
+			outErr:  ErrQuestTaken,
+			wantErr: true,
+		},
+		{
+			name: "success joined a working party",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id: 4,
+				adv_id:   1,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+				repo.EXPECT().GetQuest(int64(4)).Return(party, nil).Times(1)
+				repo.EXPECT().IsExistTakenBy(int64(4), int64(1)).Return(sql.ErrNoRows).Times(1)
+				advRepo.EXPECT().GetAdventurer(int64(1)).Return(adv, nil).Times(1)
+				repo.EXPECT().JoinQuest(model.Quest{ID: 4, TakenAt: &now}).Return(true, nil).Times(1)
+				repo.EXPECT().CreateTakenBy(int64(4), int64(1)).Return(nil).Times(1)
+				repo.EXPECT().CreateAssignment(model.Assignment{QuestID: 4, AdventurerID: 1, TakenAt: now}).Return(nil).Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed joined a party twice",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id: 4,
+				adv_id:   1,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+				repo.EXPECT().GetQuest(int64(4)).Return(party, nil).Times(1)
+				repo.EXPECT().IsExistTakenBy(int64(4), int64(1)).Return(nil).Times(1)
+			},
+			outErr:  ErrAlreadyJoined,
+			wantErr: true,
+		},
+		{
+			name: "error check party member",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id: 4,
+				adv_id:   1,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+				repo.EXPECT().GetQuest(int64(4)).Return(party, nil).Times(1)
+				repo.EXPECT().IsExistTakenBy(int64(4), int64(1)).Return(errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success joined with the party average rank",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
 			},
 			args: args{
 				quest_id: 1,
 				adv_id:   1,
 			},
 			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
-				repo.EXPECT().GetQuest(int64(1)).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().GetQuest(int64(1)).Return(averageParty, nil).Times(1)
+				repo.EXPECT().IsExistTakenBy(int64(1), int64(1)).Return(sql.ErrNoRows).Times(1)
 				advRepo.EXPECT().GetAdventurer(int64(1)).Return(adv, nil).Times(1)
+				repo.EXPECT().GetTakenBy(int64(1)).Return(members, nil).Times(1)
+				advRepo.EXPECT().GetAdventurer(int64(2)).Return(modelAdv.Adventurer{ID: 2, Rank: 13}, nil).Times(1)
+				repo.EXPECT().JoinQuest(joining).Return(true, nil).Times(1)
 				repo.EXPECT().CreateTakenBy(int64(1), int64(1)).Return(nil).Times(1)
-				copyQuest := bulkQuest[0]
-				copyQuest.Status = constant.WorkingQuest
-				repo.EXPECT().UpdateQuestStatus(copyQuest).Return(errors.New("any error")).Times(1)
+				repo.EXPECT().CreateAssignment(model.Assignment{QuestID: 1, AdventurerID: 1, TakenAt: now}).Return(nil).Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed joined below the party average rank",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id: 1,
+				adv_id:   1,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+				repo.EXPECT().GetQuest(int64(1)).Return(averageParty, nil).Times(1)
+				repo.EXPECT().IsExistTakenBy(int64(1), int64(1)).Return(sql.ErrNoRows).Times(1)
+				advRepo.EXPECT().GetAdventurer(int64(1)).Return(adv, nil).Times(1)
+				repo.EXPECT().GetTakenBy(int64(1)).Return(members, nil).Times(1)
+				advRepo.EXPECT().GetAdventurer(int64(2)).Return(modelAdv.Adventurer{ID: 2, Rank: 12}, nil).Times(1)
+			},
+			outErr:  ErrRankTooLow,
+			wantErr: true,
+		},
+		{
+			name: "error get party members",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id: 1,
+				adv_id:   1,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+				repo.EXPECT().GetQuest(int64(1)).Return(averageParty, nil).Times(1)
+				repo.EXPECT().IsExistTakenBy(int64(1), int64(1)).Return(sql.ErrNoRows).Times(1)
+				advRepo.EXPECT().GetAdventurer(int64(1)).Return(adv, nil).Times(1)
+				repo.EXPECT().GetTakenBy(int64(1)).Return(nil, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed took an expired quest",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id: 1,
+				adv_id:   1,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+				repo.EXPECT().GetQuest(int64(1)).Return(expiredQuest, nil).Times(1)
+			},
+			outErr:  ErrQuestExpired,
+			wantErr: true,
+		},
+		{
+			name: "failed took a cancelled quest",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
 			},
+			args: args{
+				quest_id: 1,
+				adv_id:   1,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+				repo.EXPECT().GetQuest(int64(1)).Return(cancelledQuest, nil).Times(1)
+			},
+			outErr:  ErrQuestCanceled,
+			wantErr: true,
+		},
+		{
+			name: "failed took a quest past its deadline",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id: 1,
+				adv_id:   1,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+				repo.EXPECT().GetQuest(int64(1)).Return(overdueQuest, nil).Times(1)
+			},
+			outErr:  ErrQuestExpired,
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			u := &usecase{
-				repo:    tt.fields.r,
-				repoAdv: tt.fields.a,
+				uow:   tt.fields.uow,
+				clock: clock.Fixed(now),
 			}
+			withinTx(tt.fields.uow, tt.fields.r, tt.fields.a, NewLedgerMockRepository(mockCtrl))
 			tt.mock(tt.fields.r, tt.fields.a)
 			err := u.TakeQuest(tt.args.quest_id, tt.args.adv_id)
 			if tt.wantErr {
@@ -608,6 +1409,9 @@ func TestTakeQuest(t *testing.T) {
 			} else {
 				assert.NoError(t, err, tt.name)
 			}
+			if tt.outErr != nil {
+				assert.Equal(t, tt.outErr, err, tt.name)
+			}
 		})
 	}
 }
@@ -617,177 +1421,997 @@ func TestReportQuest(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	type fields struct {
-		r *MockRepository
-		a *AdvMockRepository
+		r   *MockRepository
+		a   *AdvMockRepository
+		uow *MockUnitOfWork
 	}
 	type args struct {
 		quest_id     int64
 		adv_id       int64
 		is_completed bool
 	}
+	completedQuest := model.Quest{ID: bulkQuest[3].ID, Status: constant.CompletedQuest}
+	releasedQuest := model.Quest{ID: bulkQuest[3].ID, Status: constant.AvailableQuest}
+	takers := []model.TakenBy{{QuestID: bulkQuest[3].ID, AdventurerID: adv.ID}}
+	promoted := adv
+	promoted.RankPoints = 4
+	failing := adv
+	failing.FailedStreak = 1
+	party := []model.TakenBy{
+		{QuestID: bulkQuest[3].ID, AdventurerID: 1},
+		{QuestID: bulkQuest[3].ID, AdventurerID: 2},
+		{QuestID: bulkQuest[3].ID, AdventurerID: 3},
+	}
+	payout := modelLedger.Payout{QuestID: bulkQuest[3].ID, Gross: 200000, Net: 200000, CreatedAt: now}
+	tenPercent, _ := NewFeePolicy(config.Commission{Percent: 10, MinimumFee: 5000})
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
-		mock    func(*MockRepository, *AdvMockRepository)
+		fees    FeePolicy
+		mock    func(*MockRepository, *AdvMockRepository, *LedgerMockRepository)
+		outErr  error
 		wantErr bool
 	}{
 		{
 			name: "report completed quest",
 			fields: fields{
-				r: NewMockRepository(mockCtrl),
-				a: NewAdvMockRepository(mockCtrl),
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
 			},
 			args: args{
 				quest_id:     bulkQuest[3].ID,
 				adv_id:       adv.ID,
 				is_completed: true,
 			},
-			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
 				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
 				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
-				repo.EXPECT().UpdateQuestStatus(model.Quest{ID: bulkQuest[3].ID, Status: constant.CompletedQuest}).Return(nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(completedQuest, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(takers, nil).Times(1)
 				advRepo.EXPECT().AddCompletedQuest(int64(1)).Return(nil).Times(1)
+				repo.EXPECT().UpdateTakenByReward(model.TakenBy{QuestID: bulkQuest[3].ID, AdventurerID: 1, Reward: 200000}).Return(nil).Times(1)
+				repo.EXPECT().FinishAssignment(finished(bulkQuest[3].ID, 1, constant.OutcomeCompleted)).Return(nil).Times(1)
+				advRepo.EXPECT().GetAdventurer(adv.ID).Return(adv, nil).Times(1)
+				advRepo.EXPECT().UpdateAdventurerProgress(promoted).Return(nil).Times(1)
+				expectEscrow(ledgerRepo, bulkQuest[3].ID, 200000, transfer(bulkQuest[3].ID, constant.LedgerRelease,
+					modelLedger.EscrowAccount(bulkQuest[3].ID), modelLedger.AdventurerAccount(1), 200000))
+				ledgerRepo.EXPECT().SavePayout(payout).Return(nil).Times(1)
 			},
 			wantErr: false,
 		},
 		{
-			name: "quest not taken",
+			name: "report completed quest posted before the ledger",
 			fields: fields{
-				r: NewMockRepository(mockCtrl),
-				a: NewAdvMockRepository(mockCtrl),
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
 			},
 			args: args{
 				quest_id:     bulkQuest[3].ID,
 				adv_id:       adv.ID,
 				is_completed: true,
 			},
-			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
-				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(errors.New("any error")).Times(1)
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(completedQuest, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(takers, nil).Times(1)
+				advRepo.EXPECT().AddCompletedQuest(int64(1)).Return(nil).Times(1)
+				repo.EXPECT().UpdateTakenByReward(model.TakenBy{QuestID: bulkQuest[3].ID, AdventurerID: 1, Reward: 200000}).Return(nil).Times(1)
+				repo.EXPECT().FinishAssignment(finished(bulkQuest[3].ID, 1, constant.OutcomeCompleted)).Return(nil).Times(1)
+				advRepo.EXPECT().GetAdventurer(adv.ID).Return(adv, nil).Times(1)
+				advRepo.EXPECT().UpdateAdventurerProgress(promoted).Return(nil).Times(1)
+				escrow := modelLedger.EscrowAccount(bulkQuest[3].ID)
+				expectEscrow(ledgerRepo, bulkQuest[3].ID, 0,
+					transfer(bulkQuest[3].ID, constant.LedgerEscrow, modelLedger.Guild, escrow, 200000),
+					transfer(bulkQuest[3].ID, constant.LedgerRelease, escrow, modelLedger.AdventurerAccount(1), 200000))
+				ledgerRepo.EXPECT().SavePayout(payout).Return(nil).Times(1)
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
-			name: "quest not exist",
+			name: "report completed quest failed release the reward",
 			fields: fields{
-				r: NewMockRepository(mockCtrl),
-				a: NewAdvMockRepository(mockCtrl),
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
 			},
 			args: args{
 				quest_id:     bulkQuest[3].ID,
 				adv_id:       adv.ID,
 				is_completed: true,
 			},
-			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
 				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
-				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], errors.New("any error")).Times(1)
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(completedQuest, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(takers, nil).Times(1)
+				advRepo.EXPECT().AddCompletedQuest(int64(1)).Return(nil).Times(1)
+				repo.EXPECT().UpdateTakenByReward(model.TakenBy{QuestID: bulkQuest[3].ID, AdventurerID: 1, Reward: 200000}).Return(nil).Times(1)
+				repo.EXPECT().FinishAssignment(finished(bulkQuest[3].ID, 1, constant.OutcomeCompleted)).Return(nil).Times(1)
+				advRepo.EXPECT().GetAdventurer(adv.ID).Return(adv, nil).Times(1)
+				advRepo.EXPECT().UpdateAdventurerProgress(promoted).Return(nil).Times(1)
+				expectEscrow(ledgerRepo, bulkQuest[3].ID, 200000)
+				ledgerRepo.EXPECT().PostTransaction(gomock.Any()).Return(modelLedger.Transaction{}, errors.New("any error")).Times(1)
 			},
 			wantErr: true,
 		},
 		{
-			name: "quest status not working quest",
+			name: "report completed quest failed save rank progress",
 			fields: fields{
-				r: NewMockRepository(mockCtrl),
-				a: NewAdvMockRepository(mockCtrl),
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
 			},
 			args: args{
-				quest_id:     bulkQuest[0].ID,
+				quest_id:     bulkQuest[3].ID,
 				adv_id:       adv.ID,
 				is_completed: true,
 			},
-			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
-				repo.EXPECT().IsExistTakenBy(bulkQuest[0].ID, adv.ID).Return(nil).Times(1)
-				repo.EXPECT().GetQuest(bulkQuest[0].ID).Return(bulkQuest[0], nil).Times(1)
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(completedQuest, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(takers, nil).Times(1)
+				advRepo.EXPECT().AddCompletedQuest(int64(1)).Return(nil).Times(1)
+				repo.EXPECT().UpdateTakenByReward(model.TakenBy{QuestID: bulkQuest[3].ID, AdventurerID: 1, Reward: 200000}).Return(nil).Times(1)
+				repo.EXPECT().FinishAssignment(finished(bulkQuest[3].ID, 1, constant.OutcomeCompleted)).Return(nil).Times(1)
+				advRepo.EXPECT().GetAdventurer(adv.ID).Return(adv, nil).Times(1)
+				advRepo.EXPECT().UpdateAdventurerProgress(promoted).Return(errors.New("any error")).Times(1)
 			},
 			wantErr: true,
 		},
 		{
-			name: "report completed quest failed update status",
+			name: "report completed party quest",
 			fields: fields{
-				r: NewMockRepository(mockCtrl),
-				a: NewAdvMockRepository(mockCtrl),
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
 			},
 			args: args{
 				quest_id:     bulkQuest[3].ID,
 				adv_id:       adv.ID,
 				is_completed: true,
 			},
-			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
 				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
 				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
-				advRepo.EXPECT().AddCompletedQuest(adv.ID).Return(nil).Times(1)
-				repo.EXPECT().UpdateQuestStatus(model.Quest{ID: bulkQuest[3].ID, Status: constant.CompletedQuest}).Return(errors.New("any error")).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(completedQuest, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(party, nil).Times(1)
+				for i, share := range []int32{66667, 66667, 66666} {
+					advRepo.EXPECT().AddCompletedQuest(party[i].AdventurerID).Return(nil).Times(1)
+					taken := party[i]
+					taken.Reward = share
+					repo.EXPECT().UpdateTakenByReward(taken).Return(nil).Times(1)
+					repo.EXPECT().FinishAssignment(finished(taken.QuestID, taken.AdventurerID, constant.OutcomeCompleted)).Return(nil).Times(1)
+					member := modelAdv.Adventurer{ID: taken.AdventurerID, Rank: 12}
+					advRepo.EXPECT().GetAdventurer(member.ID).Return(member, nil).Times(1)
+					member.RankPoints = 1
+					advRepo.EXPECT().UpdateAdventurerProgress(member).Return(nil).Times(1)
+				}
+				expectEscrow(ledgerRepo, bulkQuest[3].ID, 200000, modelLedger.Transaction{
+					QuestID: bulkQuest[3].ID,
+					Kind:    constant.LedgerRelease,
+					Entries: []modelLedger.Entry{
+						{Account: modelLedger.EscrowAccount(bulkQuest[3].ID), Amount: -200000},
+						{Account: modelLedger.AdventurerAccount(1), Amount: 66667},
+						{Account: modelLedger.AdventurerAccount(2), Amount: 66667},
+						{Account: modelLedger.AdventurerAccount(3), Amount: 66666},
+					},
+					CreatedAt: now,
+				})
+				ledgerRepo.EXPECT().SavePayout(payout).Return(nil).Times(1)
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
-			name: "report completed quest failed add completed test",
+			name: "report completed party quest with a guild fee",
 			fields: fields{
-				r: NewMockRepository(mockCtrl),
-				a: NewAdvMockRepository(mockCtrl),
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
 			},
 			args: args{
 				quest_id:     bulkQuest[3].ID,
 				adv_id:       adv.ID,
 				is_completed: true,
 			},
-			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+			fees: tenPercent,
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
 				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
 				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
-				advRepo.EXPECT().AddCompletedQuest(adv.ID).Return(errors.New("any error")).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(completedQuest, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(party, nil).Times(1)
+				for i := range party {
+					advRepo.EXPECT().AddCompletedQuest(party[i].AdventurerID).Return(nil).Times(1)
+					taken := party[i]
+					taken.Reward = 60000
+					repo.EXPECT().UpdateTakenByReward(taken).Return(nil).Times(1)
+					repo.EXPECT().FinishAssignment(finished(taken.QuestID, taken.AdventurerID, constant.OutcomeCompleted)).Return(nil).Times(1)
+					member := modelAdv.Adventurer{ID: taken.AdventurerID, Rank: 12}
+					advRepo.EXPECT().GetAdventurer(member.ID).Return(member, nil).Times(1)
+					member.RankPoints = 1
+					advRepo.EXPECT().UpdateAdventurerProgress(member).Return(nil).Times(1)
+				}
+				expectEscrow(ledgerRepo, bulkQuest[3].ID, 200000, modelLedger.Transaction{
+					QuestID: bulkQuest[3].ID,
+					Kind:    constant.LedgerRelease,
+					Entries: []modelLedger.Entry{
+						{Account: modelLedger.EscrowAccount(bulkQuest[3].ID), Amount: -200000},
+						{Account: modelLedger.Guild, Amount: 20000},
+						{Account: modelLedger.AdventurerAccount(1), Amount: 60000},
+						{Account: modelLedger.AdventurerAccount(2), Amount: 60000},
+						{Account: modelLedger.AdventurerAccount(3), Amount: 60000},
+					},
+					CreatedAt: now,
+				})
+				ledgerRepo.EXPECT().SavePayout(modelLedger.Payout{QuestID: bulkQuest[3].ID, Gross: 200000, GuildFee: 20000, Net: 180000,
+					FeePercent: 10, CreatedAt: now}).Return(nil).Times(1)
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
-			name: "report uncompleted quest",
+			name: "report completed quest failed save the payout",
 			fields: fields{
-				r: NewMockRepository(mockCtrl),
-				a: NewAdvMockRepository(mockCtrl),
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
 			},
 			args: args{
 				quest_id:     bulkQuest[3].ID,
 				adv_id:       adv.ID,
-				is_completed: false,
+				is_completed: true,
 			},
-			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
 				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
 				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
-				repo.EXPECT().UpdateQuestStatus(model.Quest{ID: bulkQuest[3].ID, Status: constant.AvailableQuest}).Return(nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(completedQuest, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(takers, nil).Times(1)
+				advRepo.EXPECT().AddCompletedQuest(int64(1)).Return(nil).Times(1)
+				repo.EXPECT().UpdateTakenByReward(model.TakenBy{QuestID: bulkQuest[3].ID, AdventurerID: 1, Reward: 200000}).Return(nil).Times(1)
+				repo.EXPECT().FinishAssignment(finished(bulkQuest[3].ID, 1, constant.OutcomeCompleted)).Return(nil).Times(1)
+				advRepo.EXPECT().GetAdventurer(adv.ID).Return(adv, nil).Times(1)
+				advRepo.EXPECT().UpdateAdventurerProgress(promoted).Return(nil).Times(1)
+				expectEscrow(ledgerRepo, bulkQuest[3].ID, 200000, transfer(bulkQuest[3].ID, constant.LedgerRelease,
+					modelLedger.EscrowAccount(bulkQuest[3].ID), modelLedger.AdventurerAccount(1), 200000))
+				ledgerRepo.EXPECT().SavePayout(payout).Return(errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "report completed quest failed get takers",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id:     bulkQuest[3].ID,
+				adv_id:       adv.ID,
+				is_completed: true,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(completedQuest, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(nil, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "report completed quest failed save reward",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id:     bulkQuest[3].ID,
+				adv_id:       adv.ID,
+				is_completed: true,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(completedQuest, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(takers, nil).Times(1)
+				advRepo.EXPECT().AddCompletedQuest(int64(1)).Return(nil).Times(1)
+				repo.EXPECT().UpdateTakenByReward(model.TakenBy{QuestID: bulkQuest[3].ID, AdventurerID: 1, Reward: 200000}).Return(errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "quest not taken",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id:     bulkQuest[3].ID,
+				adv_id:       adv.ID,
+				is_completed: true,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "quest not exist",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id:     bulkQuest[3].ID,
+				adv_id:       adv.ID,
+				is_completed: true,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "quest status not working quest",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id:     bulkQuest[0].ID,
+				adv_id:       adv.ID,
+				is_completed: true,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().IsExistTakenBy(bulkQuest[0].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().GetQuest(bulkQuest[0].ID).Return(bulkQuest[0], nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "quest reported concurrently",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id:     bulkQuest[3].ID,
+				adv_id:       adv.ID,
+				is_completed: true,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(completedQuest, int32(constant.WorkingQuest)).Return(false, nil).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "report completed quest failed update status",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id:     bulkQuest[3].ID,
+				adv_id:       adv.ID,
+				is_completed: true,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(completedQuest, int32(constant.WorkingQuest)).Return(false, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "report completed quest failed add completed test",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id:     bulkQuest[3].ID,
+				adv_id:       adv.ID,
+				is_completed: true,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(completedQuest, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(takers, nil).Times(1)
+				advRepo.EXPECT().AddCompletedQuest(adv.ID).Return(errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "report uncompleted quest",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id:     bulkQuest[3].ID,
+				adv_id:       adv.ID,
+				is_completed: false,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(releasedQuest, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(takers, nil).Times(1)
+				repo.EXPECT().DeleteTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().FinishAssignment(finished(bulkQuest[3].ID, adv.ID, constant.OutcomeFailed)).Return(nil).Times(1)
+				repo.EXPECT().UpdateQuestTakenAt(model.Quest{ID: bulkQuest[3].ID}).Return(nil).Times(1)
+				advRepo.EXPECT().AddFailedQuest(adv.ID).Return(nil).Times(1)
+				advRepo.EXPECT().GetAdventurer(adv.ID).Return(adv, nil).Times(1)
+				advRepo.EXPECT().UpdateAdventurerProgress(failing).Return(nil).Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "report uncompleted quest too many times",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id:     bulkQuest[3].ID,
+				adv_id:       adv.ID,
+				is_completed: false,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(releasedQuest, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(takers, nil).Times(1)
+				repo.EXPECT().DeleteTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().FinishAssignment(finished(bulkQuest[3].ID, adv.ID, constant.OutcomeFailed)).Return(nil).Times(1)
+				repo.EXPECT().UpdateQuestTakenAt(model.Quest{ID: bulkQuest[3].ID}).Return(nil).Times(1)
+				streak := adv
+				streak.FailedStreak = 2
+				advRepo.EXPECT().AddFailedQuest(adv.ID).Return(nil).Times(1)
+				advRepo.EXPECT().GetAdventurer(adv.ID).Return(streak, nil).Times(1)
+				demoted := adv
+				demoted.Rank = 10
+				advRepo.EXPECT().UpdateAdventurerProgress(demoted).Return(nil).Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "report uncompleted quest failed get adventurer",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id:     bulkQuest[3].ID,
+				adv_id:       adv.ID,
+				is_completed: false,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(releasedQuest, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(takers, nil).Times(1)
+				repo.EXPECT().DeleteTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().FinishAssignment(finished(bulkQuest[3].ID, adv.ID, constant.OutcomeFailed)).Return(nil).Times(1)
+				repo.EXPECT().UpdateQuestTakenAt(model.Quest{ID: bulkQuest[3].ID}).Return(nil).Times(1)
+				advRepo.EXPECT().AddFailedQuest(adv.ID).Return(nil).Times(1)
+				advRepo.EXPECT().GetAdventurer(adv.ID).Return(modelAdv.Adventurer{}, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "report uncompleted quest failed close assignment",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id:     bulkQuest[3].ID,
+				adv_id:       adv.ID,
+				is_completed: false,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(releasedQuest, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(takers, nil).Times(1)
+				repo.EXPECT().DeleteTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().FinishAssignment(finished(bulkQuest[3].ID, adv.ID, constant.OutcomeFailed)).Return(errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "report uncompleted quest failed",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id:     bulkQuest[3].ID,
+				adv_id:       adv.ID,
+				is_completed: false,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(releasedQuest, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(takers, nil).Times(1)
+				repo.EXPECT().DeleteTakenBy(bulkQuest[3].ID, adv.ID).Return(errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "report a quest past its deadline",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			args: args{
+				quest_id:     bulkQuest[3].ID,
+				adv_id:       adv.ID,
+				is_completed: true,
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				overdue := bulkQuest[3]
+				deadline := now
+				overdue.Deadline = &deadline
+				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(overdue, nil).Times(1)
+			},
+			outErr:  ErrQuestExpired,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				uow:    tt.fields.uow,
+				clock:  clock.Fixed(now),
+				policy: policy,
+				fees:   noFees,
+			}
+			if tt.fees != nil {
+				u.fees = tt.fees
+			}
+			ledgerRepo := NewLedgerMockRepository(mockCtrl)
+			withinTx(tt.fields.uow, tt.fields.r, tt.fields.a, ledgerRepo)
+			tt.mock(tt.fields.r, tt.fields.a, ledgerRepo)
+			err := u.ReportQuest(tt.args.quest_id, tt.args.adv_id, tt.args.is_completed)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			if tt.outErr != nil {
+				assert.Equal(t, tt.outErr, err, tt.name)
+			}
+		})
+	}
+}
+
+func TestSplitReward(t *testing.T) {
+	tests := []struct {
+		name   string
+		reward int32
+		n      int
+		out    []int32
+	}{
+		{name: "no member", reward: 100, n: 0, out: []int32{}},
+		{name: "single member", reward: 100, n: 1, out: []int32{100}},
+		{name: "even split", reward: 90, n: 3, out: []int32{30, 30, 30}},
+		{name: "remainder to first members", reward: 200000, n: 3, out: []int32{66667, 66667, 66666}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.out, splitReward(tt.reward, tt.n))
+		})
+	}
+}
+
+func TestGetQuest(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name     string
+		r        *MockRepository
+		mock     func(*MockRepository)
+		outQuest model.Quest
+		outErr   error
+		wantErr  bool
+	}{
+		{
+			name: "success get a quest",
+			r:    NewMockRepository(mockCtrl),
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[0].ID).Return(bulkQuest[0], nil).Times(1)
+			},
+			outQuest: bulkQuest[0],
+			wantErr:  false,
+		},
+		{
+			name: "failed get a quest",
+			r:    NewMockRepository(mockCtrl),
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[0].ID).Return(model.Quest{}, sql.ErrNoRows).Times(1)
+			},
+			outQuest: model.Quest{},
+			outErr:   ErrQuestNotFound,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				repo: tt.r,
+			}
+			tt.mock(tt.r)
+			res, err := u.GetQuest(bulkQuest[0].ID)
+			assert.Equal(t, tt.outQuest, res)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			if tt.outErr != nil {
+				assert.Equal(t, tt.outErr, err, tt.name)
+			}
+		})
+	}
+}
+
+func TestGetTakers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	takers := []model.TakenBy{{QuestID: bulkQuest[3].ID, AdventurerID: adv.ID, Reward: 200000}}
+	tests := []struct {
+		name      string
+		r         *MockRepository
+		mock      func(*MockRepository)
+		outTakers []model.TakenBy
+		outErr    error
+		wantErr   bool
+	}{
+		{
+			name: "success get takers",
+			r:    NewMockRepository(mockCtrl),
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(takers, nil).Times(1)
+			},
+			outTakers: takers,
+			wantErr:   false,
+		},
+		{
+			name: "quest not exist",
+			r:    NewMockRepository(mockCtrl),
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(model.Quest{}, sql.ErrNoRows).Times(1)
+			},
+			outErr:  ErrQuestNotFound,
+			wantErr: true,
+		},
+		{
+			name: "failed get takers",
+			r:    NewMockRepository(mockCtrl),
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(nil, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				repo: tt.r,
+			}
+			tt.mock(tt.r)
+			res, err := u.GetTakers(bulkQuest[3].ID)
+			assert.Equal(t, tt.outTakers, res)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			if tt.outErr != nil {
+				assert.Equal(t, tt.outErr, err, tt.name)
+			}
+		})
+	}
+}
+
+func TestListQuests(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name      string
+		filter    model.QuestFilter
+		mock      func(*MockRepository)
+		outQuests []model.Quest
+		outTotal  int
+		wantErr   bool
+	}{
+		{
+			name:   "defaults to the first page by creation time",
+			filter: model.QuestFilter{},
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().ListQuests(model.QuestFilter{Sort: constant.SortCreatedAt, Limit: constant.DefaultPageSize}).Return(bulkQuest[0:2], 7, nil).Times(1)
+			},
+			outQuests: bulkQuest[0:2],
+			outTotal:  7,
+		},
+		{
+			name: "passes a valid filter through",
+			filter: model.QuestFilter{Statuses: []int32{constant.AvailableQuest, constant.CancelledQuest}, MinReward: 100, MaxReward: 100,
+				MinRank: 11, MaxRank: 13, Name: "kucing", Sort: constant.SortReward, Desc: true, Limit: constant.MaxPageSize, Offset: 40},
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().ListQuests(model.QuestFilter{Statuses: []int32{constant.AvailableQuest, constant.CancelledQuest}, MinReward: 100, MaxReward: 100,
+					MinRank: 11, MaxRank: 13, Name: "kucing", Sort: constant.SortReward, Desc: true, Limit: constant.MaxPageSize, Offset: 40}).Return(bulkQuest[0:1], 41, nil).Times(1)
+			},
+			outQuests: bulkQuest[0:1],
+			outTotal:  41,
+		},
+		{
+			name:    "unknown status",
+			filter:  model.QuestFilter{Statuses: []int32{5}},
+			mock:    func(repo *MockRepository) {},
+			wantErr: true,
+		},
+		{
+			name:    "negative reward",
+			filter:  model.QuestFilter{MinReward: -1},
+			mock:    func(repo *MockRepository) {},
+			wantErr: true,
+		},
+		{
+			name:    "reward range inverted",
+			filter:  model.QuestFilter{MinReward: 300, MaxReward: 200},
+			mock:    func(repo *MockRepository) {},
+			wantErr: true,
+		},
+		{
+			name:    "negative rank",
+			filter:  model.QuestFilter{MaxRank: -1},
+			mock:    func(repo *MockRepository) {},
+			wantErr: true,
+		},
+		{
+			name:    "rank range inverted",
+			filter:  model.QuestFilter{MinRank: 13, MaxRank: 12},
+			mock:    func(repo *MockRepository) {},
+			wantErr: true,
+		},
+		{
+			name:    "unknown sort",
+			filter:  model.QuestFilter{Sort: "name"},
+			mock:    func(repo *MockRepository) {},
+			wantErr: true,
+		},
+		{
+			name:    "limit too large",
+			filter:  model.QuestFilter{Limit: constant.MaxPageSize + 1},
+			mock:    func(repo *MockRepository) {},
+			wantErr: true,
+		},
+		{
+			name:    "negative offset",
+			filter:  model.QuestFilter{Offset: -1},
+			mock:    func(repo *MockRepository) {},
+			wantErr: true,
+		},
+		{
+			name:   "failed list quests",
+			filter: model.QuestFilter{},
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().ListQuests(gomock.Any()).Return(nil, 0, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewMockRepository(mockCtrl)
+			u := &usecase{
+				repo: r,
+			}
+			tt.mock(r)
+			res, total, err := u.ListQuests(tt.filter)
+			assert.Equal(t, tt.outQuests, res)
+			assert.Equal(t, tt.outTotal, total)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+		})
+	}
+}
+
+func TestListQuestsInvalidFilter(t *testing.T) {
+	u := &usecase{}
+	_, _, err := u.ListQuests(model.QuestFilter{Sort: "name"})
+	assert.True(t, errors.Is(err, ErrInvalidFilter))
+	assert.EqualError(t, err, `invalid quest filter: unknown sort "name"`)
+}
+
+func TestListPostedQuests(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	filter := model.QuestFilter{CreatedBy: 1, Sort: constant.SortCreatedAt, Limit: constant.DefaultPageSize}
+	party := []model.Taker{{AdventurerID: 1, Name: "andi", Rank: 11}}
+	tests := []struct {
+		name     string
+		filter   model.QuestFilter
+		mock     func(*MockRepository)
+		outPosts []model.PostedQuest
+		outTotal int
+		wantErr  bool
+	}{
+		{
+			name:   "success with the party of each quest",
+			filter: model.QuestFilter{CreatedBy: 1},
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().ListQuests(filter).Return(bulkQuest[0:2], 2, nil).Times(1)
+				repo.EXPECT().ListTakers([]int64{bulkQuest[0].ID, bulkQuest[1].ID}).Return(map[int64][]model.Taker{bulkQuest[1].ID: party}, nil).Times(1)
+			},
+			outPosts: []model.PostedQuest{{Quest: bulkQuest[0], Takers: []model.Taker{}}, {Quest: bulkQuest[1], Takers: party}},
+			outTotal: 2,
+		},
+		{
+			name:    "failed invalid filter",
+			filter:  model.QuestFilter{Sort: "name"},
+			mock:    func(repo *MockRepository) {},
+			wantErr: true,
+		},
+		{
+			name:   "failed list takers",
+			filter: model.QuestFilter{CreatedBy: 1},
+			mock: func(repo *MockRepository) {
+				repo.EXPECT().ListQuests(filter).Return(bulkQuest[0:2], 2, nil).Times(1)
+				repo.EXPECT().ListTakers(gomock.Any()).Return(nil, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewMockRepository(mockCtrl)
+			u := &usecase{
+				repo: r,
+			}
+			tt.mock(r)
+			res, total, err := u.ListPostedQuests(tt.filter)
+			assert.Equal(t, tt.outPosts, res)
+			assert.Equal(t, tt.outTotal, total)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+		})
+	}
+}
+
+func TestListAssignments(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	history := []model.Assignment{
+		{ID: 2, QuestID: 1, AdventurerID: 1, TakenAt: now},
+		{ID: 1, QuestID: 1, AdventurerID: 2, TakenAt: now, FinishedAt: &now, Outcome: constant.OutcomeFailed},
+	}
+	tests := []struct {
+		name       string
+		filter     model.AssignmentFilter
+		mock       func(*MockRepository, *AdvMockRepository)
+		outHistory []model.Assignment
+		outTotal   int
+		outErr     error
+		wantErr    bool
+	}{
+		{
+			name:   "history of a quest defaults to the first page",
+			filter: model.AssignmentFilter{QuestID: 1},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+				repo.EXPECT().GetQuest(int64(1)).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().ListAssignments(model.AssignmentFilter{QuestID: 1, Limit: constant.DefaultPageSize}).Return(history, 2, nil).Times(1)
 			},
-			wantErr: false,
+			outHistory: history,
+			outTotal:   2,
 		},
 		{
-			name: "report uncompleted quest failed",
-			fields: fields{
-				r: NewMockRepository(mockCtrl),
-				a: NewAdvMockRepository(mockCtrl),
+			name:   "history of an adventurer",
+			filter: model.AssignmentFilter{AdventurerID: 1, Limit: 1, Offset: 1},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+				advRepo.EXPECT().GetAdventurer(int64(1)).Return(adv, nil).Times(1)
+				repo.EXPECT().ListAssignments(model.AssignmentFilter{AdventurerID: 1, Limit: 1, Offset: 1}).Return(history[1:], 2, nil).Times(1)
 			},
-			args: args{
-				quest_id:     bulkQuest[3].ID,
-				adv_id:       adv.ID,
-				is_completed: false,
+			outHistory: history[1:],
+			outTotal:   2,
+		},
+		{
+			name:   "quest not found",
+			filter: model.AssignmentFilter{QuestID: 9},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+				repo.EXPECT().GetQuest(int64(9)).Return(model.Quest{}, sql.ErrNoRows).Times(1)
 			},
+			outErr:  ErrQuestNotFound,
+			wantErr: true,
+		},
+		{
+			name:   "adventurer not found",
+			filter: model.AssignmentFilter{AdventurerID: 9},
 			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
-				repo.EXPECT().IsExistTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
-				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(bulkQuest[3], nil).Times(1)
-				repo.EXPECT().UpdateQuestStatus(model.Quest{ID: bulkQuest[3].ID, Status: 0}).Return(errors.New("any error")).Times(1)
+				advRepo.EXPECT().GetAdventurer(int64(9)).Return(modelAdv.Adventurer{}, sql.ErrNoRows).Times(1)
+			},
+			outErr:  advUsecase.ErrAdventurerNotFound,
+			wantErr: true,
+		},
+		{
+			name:    "limit too large",
+			filter:  model.AssignmentFilter{QuestID: 1, Limit: constant.MaxPageSize + 1},
+			mock:    func(repo *MockRepository, advRepo *AdvMockRepository) {},
+			wantErr: true,
+		},
+		{
+			name:    "negative offset",
+			filter:  model.AssignmentFilter{QuestID: 1, Offset: -1},
+			mock:    func(repo *MockRepository, advRepo *AdvMockRepository) {},
+			wantErr: true,
+		},
+		{
+			name:   "failed list assignments",
+			filter: model.AssignmentFilter{QuestID: 1},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository) {
+				repo.EXPECT().GetQuest(int64(1)).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().ListAssignments(gomock.Any()).Return(nil, 0, errors.New("any error")).Times(1)
 			},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			r := NewMockRepository(mockCtrl)
+			a := NewAdvMockRepository(mockCtrl)
 			u := &usecase{
-				repo:    tt.fields.r,
-				repoAdv: tt.fields.a,
+				repo:    r,
+				repoAdv: a,
 			}
-			tt.mock(tt.fields.r, tt.fields.a)
-			err := u.ReportQuest(tt.args.quest_id, tt.args.adv_id, tt.args.is_completed)
+			tt.mock(r, a)
+			res, total, err := u.ListAssignments(tt.filter)
+			assert.Equal(t, tt.outHistory, res)
+			assert.Equal(t, tt.outTotal, total)
 			if tt.wantErr {
 				assert.Error(t, err, tt.name)
 			} else {
 				assert.NoError(t, err, tt.name)
 			}
+			if tt.outErr != nil {
+				assert.Equal(t, tt.outErr, err, tt.name)
+			}
 		})
 	}
 }
@@ -875,3 +2499,643 @@ func TestGetQuestActiveAdventurer(t *testing.T) {
 		})
 	}
 }
+
+// raceQuestRepository keeps quest rows behind a mutex so UpdateQuestStatusIf
+// and JoinQuest behave like the conditional UPDATEs of the SQL repository.
+type raceQuestRepository struct {
+	repo.Repository
+	mu      sync.Mutex
+	quests  map[int64]model.Quest
+	takenBy []model.TakenBy
+	readers sync.WaitGroup
+}
+
+func (r *raceQuestRepository) GetQuest(id int64) (model.Quest, error) {
+	r.mu.Lock()
+	quest := r.quests[id]
+	r.mu.Unlock()
+	// hold every taker here until all of them have read the quest as available
+	r.readers.Done()
+	r.readers.Wait()
+	return quest, nil
+}
+
+func (r *raceQuestRepository) UpdateQuestStatusIf(quest model.Quest, current int32) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.quests[quest.ID]
+	if stored.Status != current {
+		return false, nil
+	}
+	stored.Status = quest.Status
+	r.quests[quest.ID] = stored
+	return true, nil
+}
+
+func (r *raceQuestRepository) JoinQuest(quest model.Quest) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.quests[quest.ID]
+	open := stored.Status == constant.AvailableQuest || stored.Status == constant.WorkingQuest
+	if !open || stored.Members >= stored.MaxMembers {
+		return false, nil
+	}
+	stored.Members++
+	if stored.Members >= stored.MinMembers {
+		stored.Status = constant.WorkingQuest
+	}
+	if stored.Members == stored.MinMembers {
+		stored.TakenAt = quest.TakenAt
+	}
+	r.quests[quest.ID] = stored
+	return true, nil
+}
+
+func (r *raceQuestRepository) IsExistTakenBy(quest_id, adv_id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, taken := range r.takenBy {
+		if taken.QuestID == quest_id && taken.AdventurerID == adv_id {
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func (r *raceQuestRepository) UpdateQuestTakenAt(quest model.Quest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.quests[quest.ID]
+	stored.TakenAt = quest.TakenAt
+	r.quests[quest.ID] = stored
+	return nil
+}
+
+func (r *raceQuestRepository) CreateTakenBy(quest_id, adv_id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.takenBy = append(r.takenBy, model.TakenBy{QuestID: quest_id, AdventurerID: adv_id})
+	return nil
+}
+
+func (r *raceQuestRepository) CreateAssignment(assignment model.Assignment) error {
+	return nil
+}
+
+type raceAdvRepository struct {
+	repoAdv.Repository
+}
+
+func (r *raceAdvRepository) GetAdventurer(id int64) (modelAdv.Adventurer, error) {
+	return modelAdv.Adventurer{ID: id, Rank: 20}, nil
+}
+
+type passThroughUnitOfWork struct {
+	repos unitofwork.Repositories
+}
+
+func (u *passThroughUnitOfWork) Do(fn func(unitofwork.Repositories) error) error {
+	return fn(u.repos)
+}
+
+func TestExpireOverdueQuests(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	timeout := 72 * time.Hour
+	takenBefore := now.Add(-timeout)
+	yesterday := now.Add(-24 * time.Hour)
+	overdue := bulkQuest[0]
+	overdue.Deadline = &yesterday
+	stale := bulkQuest[3]
+	stale.TakenAt = &takenBefore
+	expiredQuest := model.Quest{ID: overdue.ID, Status: constant.ExpiredQuest}
+	releasedQuest := model.Quest{ID: stale.ID, Status: constant.AvailableQuest}
+	takers := []model.TakenBy{{QuestID: stale.ID, AdventurerID: adv.ID}}
+	failing := adv
+	failing.FailedStreak = 1
+	refund := transfer(overdue.ID, constant.LedgerRefund, modelLedger.EscrowAccount(overdue.ID), modelLedger.Guild, 200000)
+
+	type fields struct {
+		r   *MockRepository
+		a   *AdvMockRepository
+		uow *MockUnitOfWork
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		timeout     time.Duration
+		mock        func(*MockRepository, *AdvMockRepository, *LedgerMockRepository, *MockUnitOfWork)
+		outExpired  int
+		outReleased int
+		wantErr     bool
+	}{
+		{
+			name: "success expire and release",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			timeout: timeout,
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository, uow *MockUnitOfWork) {
+				repo.EXPECT().GetOverdueQuests(now, takenBefore).Return([]model.Quest{overdue, stale}, nil).Times(1)
+				withinTx(uow, repo, advRepo, ledgerRepo)
+				repo.EXPECT().UpdateQuestStatusIf(expiredQuest, int32(constant.AvailableQuest)).Return(true, nil).Times(1)
+				expectEscrow(ledgerRepo, overdue.ID, 200000, refund)
+				repo.EXPECT().GetTakenBy(overdue.ID).Return([]model.TakenBy{}, nil).Times(1)
+				repo.EXPECT().UpdateQuestTakenAt(model.Quest{ID: overdue.ID}).Return(nil).Times(1)
+				withinTx(uow, repo, advRepo, ledgerRepo)
+				repo.EXPECT().UpdateQuestStatusIf(releasedQuest, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				repo.EXPECT().GetTakenBy(stale.ID).Return(takers, nil).Times(1)
+				repo.EXPECT().DeleteTakenBy(stale.ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().FinishAssignment(finished(stale.ID, adv.ID, constant.OutcomeAbandoned)).Return(nil).Times(1)
+				repo.EXPECT().UpdateQuestTakenAt(model.Quest{ID: stale.ID}).Return(nil).Times(1)
+				advRepo.EXPECT().AddFailedQuest(adv.ID).Return(nil).Times(1)
+				advRepo.EXPECT().GetAdventurer(adv.ID).Return(adv, nil).Times(1)
+				advRepo.EXPECT().UpdateAdventurerProgress(failing).Return(nil).Times(1)
+			},
+			outExpired:  1,
+			outReleased: 1,
+			wantErr:     false,
+		},
+		{
+			name: "success expire gathering party without failed quest",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			timeout: timeout,
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository, uow *MockUnitOfWork) {
+				repo.EXPECT().GetOverdueQuests(now, takenBefore).Return([]model.Quest{overdue}, nil).Times(1)
+				withinTx(uow, repo, advRepo, ledgerRepo)
+				repo.EXPECT().UpdateQuestStatusIf(expiredQuest, int32(constant.AvailableQuest)).Return(true, nil).Times(1)
+				expectEscrow(ledgerRepo, overdue.ID, 200000, refund)
+				repo.EXPECT().GetTakenBy(overdue.ID).Return([]model.TakenBy{{QuestID: overdue.ID, AdventurerID: adv.ID}}, nil).Times(1)
+				repo.EXPECT().DeleteTakenBy(overdue.ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().FinishAssignment(finished(overdue.ID, adv.ID, constant.OutcomeExpired)).Return(nil).Times(1)
+				repo.EXPECT().UpdateQuestTakenAt(model.Quest{ID: overdue.ID}).Return(nil).Times(1)
+			},
+			outExpired: 1,
+			wantErr:    false,
+		},
+		{
+			name: "success skip quest changed meanwhile",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			timeout: timeout,
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository, uow *MockUnitOfWork) {
+				repo.EXPECT().GetOverdueQuests(now, takenBefore).Return([]model.Quest{overdue}, nil).Times(1)
+				withinTx(uow, repo, advRepo, ledgerRepo)
+				repo.EXPECT().UpdateQuestStatusIf(expiredQuest, int32(constant.AvailableQuest)).Return(false, nil).Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success zero timeout",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository, uow *MockUnitOfWork) {
+				repo.EXPECT().GetOverdueQuests(now, time.Time{}).Return([]model.Quest{}, nil).Times(1)
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed get overdue quests",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			timeout: timeout,
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository, uow *MockUnitOfWork) {
+				repo.EXPECT().GetOverdueQuests(now, takenBefore).Return(nil, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed refund expired quest",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			timeout: timeout,
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository, uow *MockUnitOfWork) {
+				repo.EXPECT().GetOverdueQuests(now, takenBefore).Return([]model.Quest{overdue}, nil).Times(1)
+				withinTx(uow, repo, advRepo, ledgerRepo)
+				repo.EXPECT().UpdateQuestStatusIf(expiredQuest, int32(constant.AvailableQuest)).Return(true, nil).Times(1)
+				ledgerRepo.EXPECT().GetBalance(modelLedger.EscrowAccount(overdue.ID)).Return(modelLedger.Balance{}, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed add failed quest",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			timeout: timeout,
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository, uow *MockUnitOfWork) {
+				repo.EXPECT().GetOverdueQuests(now, takenBefore).Return([]model.Quest{stale}, nil).Times(1)
+				withinTx(uow, repo, advRepo, ledgerRepo)
+				repo.EXPECT().UpdateQuestStatusIf(releasedQuest, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				repo.EXPECT().GetTakenBy(stale.ID).Return(takers, nil).Times(1)
+				repo.EXPECT().DeleteTakenBy(stale.ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().FinishAssignment(finished(stale.ID, adv.ID, constant.OutcomeAbandoned)).Return(nil).Times(1)
+				repo.EXPECT().UpdateQuestTakenAt(model.Quest{ID: stale.ID}).Return(nil).Times(1)
+				advRepo.EXPECT().AddFailedQuest(adv.ID).Return(errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				repo:   tt.fields.r,
+				uow:    tt.fields.uow,
+				clock:  clock.Fixed(now),
+				policy: policy,
+			}
+			tt.mock(tt.fields.r, tt.fields.a, NewLedgerMockRepository(mockCtrl), tt.fields.uow)
+			expired, released, err := u.ExpireOverdueQuests(tt.timeout)
+			assert.Equal(t, tt.outExpired, expired)
+			assert.Equal(t, tt.outReleased, released)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+		})
+	}
+}
+
+func TestCancelQuest(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	expiredQuest := bulkQuest[0]
+	expiredQuest.Status = constant.ExpiredQuest
+	cancelledQuest := bulkQuest[0]
+	cancelledQuest.Status = constant.CancelledQuest
+	takers := []model.TakenBy{{QuestID: bulkQuest[3].ID, AdventurerID: adv.ID}}
+	const giverID = 7
+	posted := bulkQuest[3]
+	posted.CreatedBy = giverID
+
+	type fields struct {
+		r   *MockRepository
+		a   *AdvMockRepository
+		uow *MockUnitOfWork
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		quest_id int64
+		mock     func(*MockRepository, *AdvMockRepository, *LedgerMockRepository)
+		outAdvs  []int64
+		outErr   error
+		wantErr  bool
+	}{
+		{
+			name: "success cancel available quest",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			quest_id: bulkQuest[0].ID,
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[0].ID).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(model.Quest{ID: bulkQuest[0].ID, Status: constant.CancelledQuest}, int32(constant.AvailableQuest)).Return(true, nil).Times(1)
+				expectEscrow(ledgerRepo, bulkQuest[0].ID, 200000, transfer(bulkQuest[0].ID, constant.LedgerRefund,
+					modelLedger.EscrowAccount(bulkQuest[0].ID), modelLedger.Guild, 200000))
+				repo.EXPECT().GetTakenBy(bulkQuest[0].ID).Return([]model.TakenBy{}, nil).Times(1)
+				repo.EXPECT().UpdateQuestTakenAt(model.Quest{ID: bulkQuest[0].ID}).Return(nil).Times(1)
+			},
+			outAdvs: []int64{},
+			wantErr: false,
+		},
+		{
+			name: "success cancel working quest",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			quest_id: bulkQuest[3].ID,
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(posted, nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(model.Quest{ID: bulkQuest[3].ID, Status: constant.CancelledQuest}, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				expectEscrow(ledgerRepo, bulkQuest[3].ID, 200000, transfer(bulkQuest[3].ID, constant.LedgerRefund,
+					modelLedger.EscrowAccount(bulkQuest[3].ID), modelLedger.GiverAccount(giverID), 200000))
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(takers, nil).Times(1)
+				repo.EXPECT().DeleteTakenBy(bulkQuest[3].ID, adv.ID).Return(nil).Times(1)
+				repo.EXPECT().FinishAssignment(finished(bulkQuest[3].ID, adv.ID, constant.OutcomeCancelled)).Return(nil).Times(1)
+				repo.EXPECT().UpdateQuestTakenAt(model.Quest{ID: bulkQuest[3].ID}).Return(nil).Times(1)
+			},
+			outAdvs: []int64{adv.ID},
+			wantErr: false,
+		},
+		{
+			name: "failed refund the reward",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			quest_id: bulkQuest[0].ID,
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[0].ID).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(model.Quest{ID: bulkQuest[0].ID, Status: constant.CancelledQuest}, int32(constant.AvailableQuest)).Return(true, nil).Times(1)
+				ledgerRepo.EXPECT().GetBalance(modelLedger.EscrowAccount(bulkQuest[0].ID)).Return(modelLedger.Balance{}, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed cancel completed quest",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			quest_id: bulkQuest[2].ID,
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[2].ID).Return(bulkQuest[2], nil).Times(1)
+			},
+			outErr:  ErrQuestDone,
+			wantErr: true,
+		},
+		{
+			name: "failed cancel cancelled quest",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			quest_id: bulkQuest[0].ID,
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[0].ID).Return(cancelledQuest, nil).Times(1)
+			},
+			outErr:  ErrQuestCanceled,
+			wantErr: true,
+		},
+		{
+			name: "failed cancel expired quest",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			quest_id: bulkQuest[0].ID,
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[0].ID).Return(expiredQuest, nil).Times(1)
+			},
+			outErr:  ErrQuestExpired,
+			wantErr: true,
+		},
+		{
+			name: "failed quest changed meanwhile",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			quest_id: bulkQuest[0].ID,
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[0].ID).Return(bulkQuest[0], nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(model.Quest{ID: bulkQuest[0].ID, Status: constant.CancelledQuest}, int32(constant.AvailableQuest)).Return(false, nil).Times(1)
+			},
+			outErr:  ErrQuestChanged,
+			wantErr: true,
+		},
+		{
+			name: "failed quest not found",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			quest_id: bulkQuest[0].ID,
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[0].ID).Return(model.Quest{}, sql.ErrNoRows).Times(1)
+			},
+			outErr:  ErrQuestNotFound,
+			wantErr: true,
+		},
+		{
+			name: "failed get quest",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			quest_id: bulkQuest[0].ID,
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[0].ID).Return(model.Quest{}, errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed unlink adventurer",
+			fields: fields{
+				r:   NewMockRepository(mockCtrl),
+				a:   NewAdvMockRepository(mockCtrl),
+				uow: NewMockUnitOfWork(mockCtrl),
+			},
+			quest_id: bulkQuest[3].ID,
+			mock: func(repo *MockRepository, advRepo *AdvMockRepository, ledgerRepo *LedgerMockRepository) {
+				repo.EXPECT().GetQuest(bulkQuest[3].ID).Return(posted, nil).Times(1)
+				repo.EXPECT().UpdateQuestStatusIf(model.Quest{ID: bulkQuest[3].ID, Status: constant.CancelledQuest}, int32(constant.WorkingQuest)).Return(true, nil).Times(1)
+				expectEscrow(ledgerRepo, bulkQuest[3].ID, 200000, transfer(bulkQuest[3].ID, constant.LedgerRefund,
+					modelLedger.EscrowAccount(bulkQuest[3].ID), modelLedger.GiverAccount(giverID), 200000))
+				repo.EXPECT().GetTakenBy(bulkQuest[3].ID).Return(takers, nil).Times(1)
+				repo.EXPECT().DeleteTakenBy(bulkQuest[3].ID, adv.ID).Return(errors.New("any error")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				uow:   tt.fields.uow,
+				clock: clock.Fixed(now),
+			}
+			ledgerRepo := NewLedgerMockRepository(mockCtrl)
+			withinTx(tt.fields.uow, tt.fields.r, tt.fields.a, ledgerRepo)
+			tt.mock(tt.fields.r, tt.fields.a, ledgerRepo)
+			res, err := u.CancelQuest(tt.quest_id)
+			assert.Equal(t, tt.outAdvs, res)
+			if tt.wantErr {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			if tt.outErr != nil {
+				assert.Equal(t, tt.outErr, err, tt.name)
+			}
+		})
+	}
+}
+
+func TestTakeQuestConcurrently(t *testing.T) {
+	const takers = 8
+	party := bulkQuest[0]
+	party.MinMembers, party.MaxMembers = 2, 3
+	tests := []struct {
+		name       string
+		quest      model.Quest
+		outWinners int
+	}{
+		{
+			name:       "single adventurer quest",
+			quest:      bulkQuest[0],
+			outWinners: 1,
+		},
+		{
+			name:       "party quest",
+			quest:      party,
+			outWinners: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questRepo := &raceQuestRepository{
+				quests: map[int64]model.Quest{tt.quest.ID: tt.quest},
+			}
+			questRepo.readers.Add(takers)
+			u := &usecase{
+				uow:   &passThroughUnitOfWork{unitofwork.Repositories{Quest: questRepo, Adventurer: &raceAdvRepository{}}},
+				clock: clock.Fixed(now),
+			}
+
+			errs := make(chan error, takers)
+			var wg sync.WaitGroup
+			for i := 1; i <= takers; i++ {
+				wg.Add(1)
+				go func(adv_id int64) {
+					defer wg.Done()
+					errs <- u.TakeQuest(tt.quest.ID, adv_id)
+				}(int64(i))
+			}
+			wg.Wait()
+			close(errs)
+
+			winners := 0
+			for err := range errs {
+				if err == nil {
+					winners++
+					continue
+				}
+				assert.Equal(t, ErrQuestTaken, err)
+			}
+			assert.Equal(t, tt.outWinners, winners)
+			assert.Len(t, questRepo.takenBy, tt.outWinners)
+			assert.Equal(t, int32(tt.outWinners), questRepo.quests[tt.quest.ID].Members)
+			assert.Equal(t, int32(constant.WorkingQuest), questRepo.quests[tt.quest.ID].Status)
+		})
+	}
+}
+
+func TestTakeQuestConcurrentlyInMemory(t *testing.T) {
+	const takers = 8
+	store := memory.NewStore()
+	questRepo := repo.NewMemoryRepository(store)
+	advRepo := repoAdv.NewMemoryRepository(store)
+	quest, _ := questRepo.CreateQuest(bulkQuest[0])
+	for i := 0; i < takers; i++ {
+		advRepo.CreateAdventurer(adv)
+	}
+	u := &usecase{
+		repo:  questRepo,
+		uow:   unitofwork.NewMemoryUnitOfWork(store),
+		clock: clock.Fixed(now),
+	}
+
+	errs := make(chan error, takers)
+	var wg sync.WaitGroup
+	for i := 1; i <= takers; i++ {
+		wg.Add(1)
+		go func(adv_id int64) {
+			defer wg.Done()
+			errs <- u.TakeQuest(quest.ID, adv_id)
+		}(int64(i))
+	}
+	wg.Wait()
+	close(errs)
+
+	winners := 0
+	for err := range errs {
+		if err == nil {
+			winners++
+			continue
+		}
+		assert.Equal(t, ErrQuestTaken, err)
+	}
+	assert.Equal(t, 1, winners)
+	taken := 0
+	for i := int64(1); i <= takers; i++ {
+		if questRepo.IsExistTakenBy(quest.ID, i) == nil {
+			taken++
+		}
+	}
+	assert.Equal(t, 1, taken)
+}
+
+func TestTakeQuestConcurrentlyInSQLite(t *testing.T) {
+	const takers = 8
+	db := databasetest.NewSQLite(t)
+	questRepo, _ := repo.NewRepository(db, database.SQLite)
+	advRepo, _ := repoAdv.NewRepository(db, database.SQLite)
+	uow, _ := unitofwork.NewUnitOfWork(db, database.SQLite)
+	party := bulkQuest[0]
+	party.MinMembers, party.MaxMembers = 2, 3
+	quest, err := questRepo.CreateQuest(party)
+	assert.NoError(t, err)
+	for i := 0; i < takers; i++ {
+		_, err = advRepo.CreateAdventurer(adv)
+		assert.NoError(t, err)
+	}
+	u := &usecase{
+		repo:  questRepo,
+		uow:   uow,
+		clock: clock.Fixed(now),
+	}
+
+	errs := make(chan error, takers)
+	var wg sync.WaitGroup
+	for i := 1; i <= takers; i++ {
+		wg.Add(1)
+		go func(adv_id int64) {
+			defer wg.Done()
+			errs <- u.TakeQuest(quest.ID, adv_id)
+		}(int64(i))
+	}
+	wg.Wait()
+	close(errs)
+
+	winners := 0
+	for err := range errs {
+		if err == nil {
+			winners++
+			continue
+		}
+		assert.Equal(t, ErrQuestTaken, err)
+	}
+	assert.Equal(t, 3, winners)
+	res, err := questRepo.GetQuest(quest.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), res.Members)
+	assert.Equal(t, int32(constant.WorkingQuest), res.Status)
+	var rows int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM taken_by WHERE quest_id = $1", quest.ID).Scan(&rows))
+	assert.Equal(t, 3, rows)
+}
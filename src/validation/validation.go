@@ -0,0 +1,148 @@
+// Package validation checks request fields against declarative rules and
+// reports every field at fault at once.
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+)
+
+// Rule returns what is wrong with a value, or "" when nothing is. Every rule
+// but Required and RequiredWithout accepts an empty value, so an optional field
+// is only checked when it is set.
+type Rule func(value interface{}) string
+
+// Check holds the rules one field must follow.
+type Check struct {
+	name  string
+	value interface{}
+	rules []Rule
+}
+
+// Field checks value, sent as the field name, against rules in order.
+func Field(name string, value interface{}, rules ...Rule) Check {
+	return Check{name: name, value: value, rules: rules}
+}
+
+// Validate runs the checks and rejects the request with every field that
+// breaks a rule, each with the message of the first rule it breaks.
+func Validate(checks ...Check) error {
+	var fields []apperror.FieldError
+	for _, c := range checks {
+		for _, rule := range c.rules {
+			if message := rule(c.value); message != "" {
+				fields = append(fields, apperror.FieldError{Field: c.name, Message: message})
+				break
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return apperror.NewInvalidFields(fields)
+}
+
+// Required rejects zero values and nil pointers.
+func Required(value interface{}) string {
+	if isEmpty(value) {
+		return "is required"
+	}
+	return ""
+}
+
+// RequiredWithout requires the value when the field named other, holding
+// otherValue, is empty too.
+func RequiredWithout(other string, otherValue interface{}) Rule {
+	return func(value interface{}) string {
+		if isEmpty(value) && isEmpty(otherValue) {
+			return "is required without " + other
+		}
+		return ""
+	}
+}
+
+// MaxLength caps the number of characters of a string.
+func MaxLength(max int) Rule {
+	return func(value interface{}) string {
+		s, _ := value.(string)
+		if utf8.RuneCountInString(s) > max {
+			return fmt.Sprintf("must be at most %d characters", max)
+		}
+		return ""
+	}
+}
+
+// Min rejects integers below min.
+func Min(min int64) Rule {
+	return func(value interface{}) string {
+		if n, ok := integer(value); ok && n < min {
+			return fmt.Sprintf("must be at least %d", min)
+		}
+		return ""
+	}
+}
+
+// Between rejects integers outside [min, max].
+func Between(min, max int64) Rule {
+	return func(value interface{}) string {
+		if n, ok := integer(value); ok && (n < min || n > max) {
+			return fmt.Sprintf("must be between %d and %d", min, max)
+		}
+		return ""
+	}
+}
+
+// OneOf limits a string to the given values.
+func OneOf(values ...string) Rule {
+	return func(value interface{}) string {
+		s, _ := value.(string)
+		if s == "" {
+			return ""
+		}
+		for _, v := range values {
+			if s == v {
+				return ""
+			}
+		}
+		return "must be one of " + strings.Join(values, ", ")
+	}
+}
+
+// After rejects times, or pointers to them, that are not after t.
+func After(t time.Time) Rule {
+	return func(value interface{}) string {
+		var at time.Time
+		switch v := value.(type) {
+		case time.Time:
+			at = v
+		case *time.Time:
+			if v != nil {
+				at = *v
+			}
+		}
+		if !at.IsZero() && !at.After(t) {
+			return "must be in the future"
+		}
+		return ""
+	}
+}
+
+func isEmpty(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	return !v.IsValid() || v.IsZero()
+}
+
+// integer reads a non-zero signed integer; zero counts as not set.
+func integer(value interface{}) (int64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), v.Int() != 0
+	}
+	return 0, false
+}
@@ -0,0 +1,92 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arfaghifari/guild-board/src/apperror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	completed := false
+	now := time.Date(2023, time.July, 1, 9, 0, 0, 0, time.UTC)
+	tomorrow, yesterday := now.Add(24*time.Hour), now.Add(-24*time.Hour)
+	tests := []struct {
+		name       string
+		checks     []Check
+		wantFields []apperror.FieldError
+	}{
+		{
+			name: "valid",
+			checks: []Check{
+				Field("name", "menyelamatkan kucing", Required, MaxLength(20)),
+				Field("description", "", MaxLength(5)),
+				Field("minimum_rank", int32(3), Required, Between(1, 10)),
+				Field("adv_id", int64(1), Required, Min(1)),
+				Field("is_completed", &completed, Required),
+				Field("rank_rule", "all", OneOf("all", "average")),
+				Field("deadline", &tomorrow, After(now)),
+				Field("unset_deadline", (*time.Time)(nil), After(now)),
+			},
+		},
+		{
+			name: "every field at fault is reported",
+			checks: []Check{
+				Field("name", "", Required, MaxLength(20)),
+				Field("description", strings.Repeat("é", 6), MaxLength(5)),
+				Field("minimum_rank", int32(11), Required, Between(1, 10)),
+				Field("adv_id", int64(-1), Required, Min(1)),
+				Field("is_completed", (*bool)(nil), Required),
+				Field("rank_rule", "leader", OneOf("all", "average")),
+				Field("deadline", &yesterday, After(now)),
+			},
+			wantFields: []apperror.FieldError{
+				{Field: "name", Message: "is required"},
+				{Field: "description", Message: "must be at most 5 characters"},
+				{Field: "minimum_rank", Message: "must be between 1 and 10"},
+				{Field: "adv_id", Message: "must be at least 1"},
+				{Field: "is_completed", Message: "is required"},
+				{Field: "rank_rule", Message: "must be one of all, average"},
+				{Field: "deadline", Message: "must be in the future"},
+			},
+		},
+		{
+			name: "only the first broken rule of a field",
+			checks: []Check{
+				Field("reward_number", int32(0), Required, Between(1, 10)),
+			},
+			wantFields: []apperror.FieldError{{Field: "reward_number", Message: "is required"}},
+		},
+		{
+			name: "required without",
+			checks: []Check{
+				Field("minimum_rank", int32(0), RequiredWithout("reward_number", int32(0))),
+				Field("reward_number", int32(0), RequiredWithout("minimum_rank", int32(0))),
+			},
+			wantFields: []apperror.FieldError{
+				{Field: "minimum_rank", Message: "is required without reward_number"},
+				{Field: "reward_number", Message: "is required without minimum_rank"},
+			},
+		},
+		{
+			name: "required without is met by the other field",
+			checks: []Check{
+				Field("minimum_rank", int32(0), RequiredWithout("reward_number", int32(5))),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.checks...)
+			if tt.wantFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+			_, code, _ := apperror.Describe(err)
+			assert.Equal(t, apperror.CodeInvalidFields, code)
+			assert.Equal(t, tt.wantFields, apperror.Fields(err))
+		})
+	}
+}